@@ -0,0 +1,103 @@
+package cloudcanary
+
+import (
+	"fmt"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// defaultAccept is the Accept header probes send when accept is unset
+const defaultAccept = "*/*"
+
+// acceptedMediaRanges parses an Accept header value into its media ranges,
+// such as application/json or text/*, leaving out any with q=0, which the
+// client explicitly refuses
+func acceptedMediaRanges(accept string) ([]string, error) {
+	var ranges []string
+	for _, part := range strings.Split(accept, ",") {
+		if strings.TrimSpace(part) == "" {
+			continue
+		}
+		mediaRange, params, err := mime.ParseMediaType(part)
+		if err != nil {
+			return nil, fmt.Errorf("invalid media range %q: %w", strings.TrimSpace(part), err)
+		}
+		if !strings.Contains(mediaRange, "/") {
+			return nil, fmt.Errorf("invalid media range %q: expected type/subtype", mediaRange)
+		}
+		if q, ok := params["q"]; ok {
+			weight, err := strconv.ParseFloat(q, 64)
+			if err != nil || weight < 0 || weight > 1 {
+				return nil, fmt.Errorf("invalid quality %q for media range %q", q, mediaRange)
+			}
+			if weight == 0 {
+				continue
+			}
+		}
+		ranges = append(ranges, mediaRange)
+	}
+	if len(ranges) == 0 {
+		return nil, fmt.Errorf("no acceptable media range")
+	}
+	return ranges, nil
+}
+
+// mediaTypeAccepted reports whether mediaType, without parameters, falls
+// within one of the media ranges
+func mediaTypeAccepted(ranges []string, mediaType string) bool {
+	typ, _, _ := strings.Cut(mediaType, "/")
+	for _, mediaRange := range ranges {
+		rangeType, rangeSubtype, _ := strings.Cut(mediaRange, "/")
+		switch {
+		case mediaRange == "*/*", mediaRange == mediaType:
+			return true
+		case rangeSubtype == "*" && rangeType == typ:
+			return true
+		}
+	}
+	return false
+}
+
+// setAccept sets the Accept header of a probe request. An accept attribute
+// replaces one given in headers, whatever its casing; otherwise a header
+// from headers is kept and defaultAccept sent when there is none.
+func setAccept(req *http.Request, accept types.String) {
+	if accept.IsNull() {
+		for name := range req.Header {
+			if strings.EqualFold(name, "Accept") {
+				return
+			}
+		}
+		req.Header.Set("Accept", defaultAccept)
+		return
+	}
+
+	for name := range req.Header {
+		if strings.EqualFold(name, "Accept") {
+			delete(req.Header, name)
+		}
+	}
+	req.Header.Set("Accept", accept.ValueString())
+}
+
+// checkAccepted returns the reason a response's media type falls outside
+// accept, or an empty string if it is acceptable or accept is unset
+func checkAccepted(accept types.String, header http.Header) string {
+	if accept.IsNull() {
+		return ""
+	}
+
+	ranges, err := acceptedMediaRanges(accept.ValueString())
+	if err != nil {
+		return fmt.Sprintf("invalid accept: %s", err)
+	}
+	if got := mediaType(header.Get("Content-Type")); !mediaTypeAccepted(ranges, got) {
+		return fmt.Sprintf("content type %q is not one of the accepted %q", got, accept.ValueString())
+	}
+
+	return ""
+}
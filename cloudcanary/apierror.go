@@ -0,0 +1,84 @@
+package cloudcanary
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// requestIDHeader carries the ID the API assigns each request, for support tickets
+const requestIDHeader = "X-Request-Id"
+
+// APIError is an error response from the CloudCanary API
+type APIError struct {
+	// StatusCode is the HTTP status of the response
+	StatusCode int
+	// RequestID is the ID the API assigned the request, if it sent one
+	RequestID string
+	// Message is the error the API reported, or the status text when it didn't
+	Message string
+}
+
+// Error returns the API's message along with the status and request ID
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("CloudCanary API returned %d: %s", e.StatusCode, e.Message)
+	if e.RequestID != "" {
+		msg += fmt.Sprintf(" (request ID %s)", e.RequestID)
+	}
+	return msg
+}
+
+// newAPIError builds an APIError from an unsuccessful API response, taking the
+// message from a JSON body of the form {"message": "..."} when there is one
+func newAPIError(resp *http.Response) *APIError {
+	apiErr := &APIError{
+		StatusCode: resp.StatusCode,
+		RequestID:  resp.Header.Get(requestIDHeader),
+		Message:    http.StatusText(resp.StatusCode),
+	}
+
+	var body struct {
+		Message string `json:"message"`
+	}
+	if data, err := io.ReadAll(io.LimitReader(resp.Body, 64*1024)); err == nil && json.Unmarshal(data, &body) == nil && strings.TrimSpace(body.Message) != "" {
+		apiErr.Message = body.Message
+	}
+	return apiErr
+}
+
+// errorDetail returns the detail of a diagnostic reporting err. With
+// structured_errors, it is a JSON object instead, holding the human-readable
+// detail alongside the API status and request ID of an APIError and the
+// attributes identifying what failed, so automation can parse it. The
+// diagnostic's summary is left as is either way.
+func (c *cloudCanaryClient) errorDetail(detail string, err error, attributes map[string]string) string {
+	if !c.structuredErrors {
+		return detail
+	}
+
+	structured := struct {
+		Message    string            `json:"message"`
+		Error      string            `json:"error"`
+		Status     int               `json:"status,omitempty"`
+		RequestID  string            `json:"request_id,omitempty"`
+		Attributes map[string]string `json:"attributes,omitempty"`
+	}{
+		Message:    detail,
+		Error:      err.Error(),
+		Attributes: attributes,
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		structured.Status = apiErr.StatusCode
+		structured.RequestID = apiErr.RequestID
+	}
+
+	encoded, marshalErr := json.Marshal(structured)
+	if marshalErr != nil {
+		return detail
+	}
+	return string(encoded)
+}
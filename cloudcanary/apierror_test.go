@@ -0,0 +1,86 @@
+package cloudcanary
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestAPICallErrorsAreAPIErrors(t *testing.T) {
+	tests := []struct {
+		name          string
+		status        int
+		body          string
+		requestID     string
+		wantMessage   string
+		wantRequestID string
+	}{
+		{name: "JSON message", status: http.StatusConflict, body: `{"message":"a check with this name already exists"}`, requestID: "req-123", wantMessage: "a check with this name already exists", wantRequestID: "req-123"},
+		{name: "no body", status: http.StatusForbidden, wantMessage: "Forbidden"},
+		{name: "body without message", status: http.StatusBadRequest, body: `{"error":"bad"}`, requestID: "req-456", wantMessage: "Bad Request", wantRequestID: "req-456"},
+		{name: "retried until exhausted", status: http.StatusServiceUnavailable, body: `{"message":"maintenance"}`, requestID: "req-789", wantMessage: "maintenance", wantRequestID: "req-789"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := recordingAPI(t, func(w http.ResponseWriter, r *http.Request) {
+				if tt.requestID != "" {
+					w.Header().Set(requestIDHeader, tt.requestID)
+				}
+				w.WriteHeader(tt.status)
+				fmt.Fprint(w, tt.body)
+			})
+
+			err := c.send(context.Background(), http.MethodPost, "/checks/http")
+
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("send error = %v, want an *APIError", err)
+			}
+			if apiErr.StatusCode != tt.status || apiErr.Message != tt.wantMessage || apiErr.RequestID != tt.wantRequestID {
+				t.Errorf("APIError = %+v, want status %d, message %q and request ID %q", *apiErr, tt.status, tt.wantMessage, tt.wantRequestID)
+			}
+		})
+	}
+}
+
+func TestErrorDetailFromAPICall(t *testing.T) {
+	c, _ := recordingAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(requestIDHeader, "req-123")
+		w.WriteHeader(http.StatusConflict)
+		fmt.Fprint(w, `{"message":"a check with this name already exists"}`)
+	})
+	c.structuredErrors = true
+
+	err := c.send(context.Background(), http.MethodPost, "/checks/http")
+	if err == nil {
+		t.Fatal("send succeeded, want a conflict")
+	}
+
+	detail := c.errorDetail(fmt.Sprintf("Could not create HTTP check: %s", err), err, map[string]string{"name": "conflicting"})
+	var structured struct {
+		Message    string            `json:"message"`
+		Error      string            `json:"error"`
+		Status     int               `json:"status"`
+		RequestID  string            `json:"request_id"`
+		Attributes map[string]string `json:"attributes"`
+	}
+	if err := json.Unmarshal([]byte(detail), &structured); err != nil {
+		t.Fatalf("detail %q is not JSON: %s", detail, err)
+	}
+	if structured.Status != http.StatusConflict || structured.RequestID != "req-123" || structured.Attributes["name"] != "conflicting" {
+		t.Errorf("structured detail = %+v, want status 409, request ID req-123 and the check name", structured)
+	}
+	if structured.Error != err.Error() {
+		t.Errorf("error = %q, want %q", structured.Error, err.Error())
+	}
+
+	// Without structured_errors the detail is left for humans
+	c.structuredErrors = false
+	if got := c.errorDetail("Could not create HTTP check", err, nil); got != "Could not create HTTP check" {
+		t.Errorf("detail = %q, want it unchanged", got)
+	}
+}
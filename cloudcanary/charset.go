@@ -0,0 +1,53 @@
+package cloudcanary
+
+import (
+	"fmt"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/htmlindex"
+	"golang.org/x/text/encoding/unicode"
+)
+
+// lookupCharset returns the encoding for a charset label, accepting the
+// names and aliases browsers do (e.g. latin1, utf-16le, sjis)
+func lookupCharset(name string) (encoding.Encoding, error) {
+	enc, err := htmlindex.Get(strings.TrimSpace(name))
+	if err != nil {
+		return nil, fmt.Errorf("unsupported charset %q", name)
+	}
+	return enc, nil
+}
+
+// responseEncoding returns the encoding a response body is in: the configured
+// charset when set, otherwise the charset parameter of the Content-Type
+// header. A nil encoding means the body is treated as UTF-8.
+func responseEncoding(configured types.String, header http.Header) (encoding.Encoding, error) {
+	if !configured.IsNull() {
+		return lookupCharset(configured.ValueString())
+	}
+
+	_, params, err := mime.ParseMediaType(header.Get("Content-Type"))
+	if err != nil || params["charset"] == "" {
+		return nil, nil
+	}
+
+	// An unrecognized charset from the server is not the check's fault; fall back to UTF-8
+	enc, err := lookupCharset(params["charset"])
+	if err != nil {
+		return nil, nil
+	}
+	return enc, nil
+}
+
+// decodeBody converts body from enc to UTF-8. Nil and UTF-8 encodings return
+// the body unchanged.
+func decodeBody(body []byte, enc encoding.Encoding) ([]byte, error) {
+	if enc == nil || enc == unicode.UTF8 {
+		return body, nil
+	}
+	return enc.NewDecoder().Bytes(body)
+}
@@ -0,0 +1,57 @@
+package cloudcanary
+
+import (
+	"crypto/tls"
+	"fmt"
+	"strings"
+)
+
+// lookupCipherSuite returns the cipher suite with the given IANA name, as
+// listed by tls.CipherSuites and tls.InsecureCipherSuites
+func lookupCipherSuite(name string) (*tls.CipherSuite, error) {
+	for _, suites := range [][]*tls.CipherSuite{tls.CipherSuites(), tls.InsecureCipherSuites()} {
+		for _, suite := range suites {
+			if suite.Name == name {
+				return suite, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("unknown cipher suite %q", name)
+}
+
+// allowedCipherSuites resolves the names in allowed_cipher_suites. It also
+// reports whether any of them is a TLS 1.3 suite; Go doesn't let TLS 1.3
+// suites be configured, so when none is allowed TLS 1.3 must be disabled.
+func allowedCipherSuites(names []string) (ids []uint16, tls13 bool, err error) {
+	for _, name := range names {
+		suite, err := lookupCipherSuite(name)
+		if err != nil {
+			return nil, false, err
+		}
+		ids = append(ids, suite.ID)
+		for _, version := range suite.SupportedVersions {
+			if version == tls.VersionTLS13 {
+				tls13 = true
+			}
+		}
+	}
+	return ids, tls13, nil
+}
+
+// cipherSuiteAllowed reports whether the negotiated cipher suite id is one of names
+func cipherSuiteAllowed(id uint16, names []string) bool {
+	negotiated := tls.CipherSuiteName(id)
+	for _, name := range names {
+		if name == negotiated {
+			return true
+		}
+	}
+	return false
+}
+
+// isHandshakeFailure reports whether err is a TLS handshake failure, as when
+// the server supports none of the cipher suites offered
+func isHandshakeFailure(err error) bool {
+	msg := err.Error()
+	return strings.Contains(msg, "handshake failure") || strings.Contains(msg, "no cipher suite supported") || strings.Contains(msg, "insufficient security level")
+}
@@ -0,0 +1,105 @@
+package cloudcanary
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// circuitState is the state of a circuitBreaker
+type circuitState int
+
+const (
+	circuitClosed circuitState = iota
+	circuitOpen
+	circuitHalfOpen
+)
+
+// circuitBreaker stops calling the API after too many consecutive failures.
+// Once the cooldown has passed a single half-open probe call is let through;
+// its outcome either closes the circuit again or restarts the cooldown.
+type circuitBreaker struct {
+	mu        sync.Mutex
+	threshold int
+	cooldown  time.Duration
+	state     circuitState
+	failures  int
+	openedAt  time.Time
+}
+
+// newCircuitBreaker returns a breaker that opens after threshold consecutive
+// failures. A threshold of zero disables the breaker.
+func newCircuitBreaker(threshold int, cooldown time.Duration) *circuitBreaker {
+	if threshold <= 0 {
+		return nil
+	}
+	return &circuitBreaker{
+		threshold: threshold,
+		cooldown:  cooldown,
+	}
+}
+
+// allow returns an error if calls are currently being short-circuited
+func (b *circuitBreaker) allow() error {
+	if b == nil {
+		return nil
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case circuitOpen:
+		remaining := b.cooldown - time.Since(b.openedAt)
+		if remaining > 0 {
+			return fmt.Errorf("circuit open: CloudCanary API calls are suspended for %s after %d consecutive failures", remaining.Round(time.Second), b.failures)
+		}
+		// Cooldown is over, let this call through as the probe
+		b.state = circuitHalfOpen
+		return nil
+	case circuitHalfOpen:
+		return fmt.Errorf("circuit open: waiting for a probe call to the CloudCanary API to complete after %d consecutive failures", b.failures)
+	default:
+		return nil
+	}
+}
+
+// record updates the breaker with the outcome of a call that allow let through.
+// Only transient failures count against the API. Any other outcome, including
+// a terminal error such as a 404, shows the API answering and closes the
+// circuit.
+func (b *circuitBreaker) record(err error) {
+	if b == nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !isTransientFailure(err) {
+		b.state = circuitClosed
+		b.failures = 0
+		return
+	}
+
+	b.failures++
+	if b.state == circuitHalfOpen || b.failures >= b.threshold {
+		b.state = circuitOpen
+		b.openedAt = time.Now()
+	}
+}
+
+// isTransientFailure reports whether err means the API is failing rather than
+// rejecting the call: a transport error or response status that isRetryable
+// classifies as transient.
+func isTransientFailure(err error) bool {
+	if err == nil {
+		return false
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return isRetryable(nil, apiErr.StatusCode)
+	}
+	return isRetryable(err, 0)
+}
@@ -0,0 +1,100 @@
+package cloudcanary
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestCircuitBreakerCountsOnlyTransientFailures(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		wantOpen bool
+	}{
+		{name: "server error", err: &APIError{StatusCode: http.StatusBadGateway}, wantOpen: true},
+		{name: "rate limited", err: &APIError{StatusCode: http.StatusTooManyRequests}, wantOpen: true},
+		{name: "timeout", err: timeoutError{timeout: true}, wantOpen: true},
+		{name: "deadline exceeded", err: fmt.Errorf("reading check: %w", context.DeadlineExceeded), wantOpen: true},
+		{name: "not found", err: &APIError{StatusCode: http.StatusNotFound}},
+		{name: "bad request", err: &APIError{StatusCode: http.StatusBadRequest}},
+		{name: "canceled", err: context.Canceled},
+		{name: "local error", err: fmt.Errorf("check ID is required")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := newCircuitBreaker(3, time.Minute)
+			for i := 0; i < 3; i++ {
+				if err := b.allow(); err != nil {
+					t.Fatalf("call %d short-circuited: %s", i+1, err)
+				}
+				b.record(tt.err)
+			}
+			if got := b.allow() != nil; got != tt.wantOpen {
+				t.Errorf("open after three failures = %t, want %t", got, tt.wantOpen)
+			}
+		})
+	}
+}
+
+func TestCircuitBreakerTerminalErrorResetsFailures(t *testing.T) {
+	b := newCircuitBreaker(3, time.Minute)
+	serverError := &APIError{StatusCode: http.StatusInternalServerError}
+
+	b.record(serverError)
+	b.record(serverError)
+	// A 404 shows the API answering, so the failures aren't consecutive
+	b.record(&APIError{StatusCode: http.StatusNotFound})
+	b.record(serverError)
+	b.record(serverError)
+	if err := b.allow(); err != nil {
+		t.Fatalf("circuit opened after a terminal error broke the run of failures: %s", err)
+	}
+
+	b.record(serverError)
+	if err := b.allow(); err == nil || !strings.Contains(err.Error(), "circuit open") {
+		t.Errorf("allow after three consecutive server errors = %v, want circuit open", err)
+	}
+}
+
+func TestCircuitBreakerHalfOpenProbeTerminalError(t *testing.T) {
+	b := newCircuitBreaker(1, time.Millisecond)
+	b.record(&APIError{StatusCode: http.StatusServiceUnavailable})
+	time.Sleep(5 * time.Millisecond)
+
+	if err := b.allow(); err != nil {
+		t.Fatalf("probe call short-circuited after the cooldown: %s", err)
+	}
+	b.record(&APIError{StatusCode: http.StatusNotFound})
+	if err := b.allow(); err != nil {
+		t.Errorf("circuit still open after the probe call got an answer: %s", err)
+	}
+}
+
+func TestCircuitBreakerSkipsInvalidCalls(t *testing.T) {
+	ctx := context.Background()
+	c := newTestClient()
+	c.breaker = newCircuitBreaker(2, time.Minute)
+
+	for i := 0; i < 5; i++ {
+		if _, err := c.readHTTPCheck(ctx, ""); err == nil {
+			t.Fatal("readHTTPCheck with no ID succeeded")
+		}
+		if err := c.createAPICheck(ctx, &APICheck{Name: types.StringNull()}); err == nil {
+			t.Fatal("createAPICheck with no name succeeded")
+		}
+		if _, err := c.getCheckResults(ctx, "hc-test", 10, 0); err == nil {
+			t.Fatal("getCheckResults with a zero sample rate succeeded")
+		}
+	}
+
+	if _, err := c.readHTTPCheck(ctx, "hc-test"); err != nil {
+		t.Errorf("readHTTPCheck after invalid calls: %s", err)
+	}
+}
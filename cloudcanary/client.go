@@ -3,8 +3,15 @@ package cloudcanary
 import (
 	"context"
 	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"io"
+	"net"
 	"net/http"
+	"strings"
+	"sync"
+	"syscall"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
@@ -12,263 +19,1688 @@ import (
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
-// cloudCanaryClient provides a client for interacting with the CloudCanary API
+// cloudCanaryClient provides a client for interacting with the CloudCanary API.
+// A single client is shared by every resource and data source of a provider
+// instance, and Terraform calls them concurrently. Its fields are set in
+// Configure and only read afterwards; state that changes per call, such as the
+// circuit breaker's counters, must guard itself. Reconfiguring the provider
+// builds a new client rather than modifying this one.
 type cloudCanaryClient struct {
-	apiKey     string
-	baseURL    string
-	httpClient *http.Client
+	apiKey  string
+	baseURL string
+	// organizationID scopes every API call when set
+	organizationID     string
+	httpClient         *http.Client
+	enforceUniqueNames bool
+	breaker            *circuitBreaker
+	providerVersion    string
+	maxAllowedLimit    int
+	// allowedEnvironments are the values a check's environment may take
+	allowedEnvironments []string
+	// duplicateHeaders is the policy for header names the API repeats
+	duplicateHeaders string
+	// autoTags are sent with every check the provider creates, unless
+	// auto_tag is false. Checks have no tags attribute, so they are never
+	// read back into state and can't show up in a plan.
+	autoTags map[string]string
+	// keepAlive holds the probe transports of checks with assert_connection_reused
+	keepAlive transportPool
+	// tcpConns holds the open connections of TCP checks with send_keepalive
+	tcpConns connPool
+	// structuredErrors makes check errors carry a JSON detail, see errorDetail
+	structuredErrors bool
+	// triggeredRuns holds the on-demand runs made for each check and trigger
+	triggeredRuns runCache
+	// tokens holds the tokens API checks fetched from token_refresh_url
+	tokens tokenCache
+	// resultLabels holds the result_labels of each check, echoed on its results
+	resultLabels labelStore
+	// bodylessChecks holds the HTTP checks with store_response_body = false
+	bodylessChecks checkSet
+	// apiInfo holds the API's description of itself once read
+	apiInfo apiInfoCache
+	// retryDelay is how long send waits before retrying a failed API call
+	retryDelay time.Duration
+}
+
+// organizationHeader names the organization an API call is scoped to
+const organizationHeader = "X-CloudCanary-Organization"
+
+// newRequest builds an authenticated request for the API endpoint at path,
+// relative to the base URL, scoped to the configured organization if any
+func (c *cloudCanaryClient) newRequest(ctx context.Context, method, path string, body io.Reader) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, strings.TrimSuffix(c.baseURL, "/")+"/"+strings.TrimPrefix(path, "/"), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	req.Header.Set("User-Agent", "terraform-provider-cloudcanary/"+c.providerVersion)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.organizationID != "" {
+		req.Header.Set(organizationHeader, c.organizationID)
+	}
+	return req, nil
+}
+
+// maxAPICallAttempts is how many times send tries an API call that keeps
+// failing in a way worth retrying
+const maxAPICallAttempts = 3
+
+// send makes an API call and discards the response body. Every call is built
+// with newRequest, so it carries the API key and organization scope. Calls
+// that fail in a way worth retrying, as isRetryable decides, are tried again
+// after retryDelay, doubling the wait each time.
+func (c *cloudCanaryClient) send(ctx context.Context, method, path string) error {
+	delay := c.retryDelay
+	for attempt := 1; ; attempt++ {
+		status, err := c.sendOnce(ctx, method, path)
+		if err == nil {
+			return nil
+		}
+
+		// Once the API has answered, only the status says whether to retry
+		transportErr := err
+		if status != 0 {
+			transportErr = nil
+		}
+		if attempt >= maxAPICallAttempts || !isRetryable(transportErr, status) {
+			return err
+		}
+
+		tflog.Debug(ctx, "Retrying API call", map[string]any{
+			"method":  method,
+			"path":    path,
+			"attempt": attempt,
+			"error":   err.Error(),
+		})
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+		delay *= 2
+	}
+}
+
+// sendOnce makes a single attempt at an API call. It returns the status of
+// the response, or 0 if there was none.
+func (c *cloudCanaryClient) sendOnce(ctx context.Context, method, path string) (int, error) {
+	req, err := c.newRequest(ctx, method, path, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, newAPIError(resp)
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	return resp.StatusCode, nil
+}
+
+// isRetryable reports whether a failed API call is worth retrying. err is
+// the transport error, if any, and status the HTTP status of the response
+// otherwise. Connection resets, timeouts, 429 and 5xx responses are
+// transient; other 4xx responses will fail the same way every time.
+func isRetryable(err error, status int) bool {
+	if err != nil {
+		if errors.Is(err, context.Canceled) {
+			return false
+		}
+		if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, syscall.ECONNRESET) {
+			return true
+		}
+		var netErr net.Error
+		return errors.As(err, &netErr) && netErr.Timeout()
+	}
+
+	switch {
+	case status == http.StatusTooManyRequests:
+		return true
+	case status >= 500:
+		return true
+	default:
+		return false
+	}
 }
 
 // verifyAuth verifies that the API key is valid
-func (c *cloudCanaryClient) verifyAuth(ctx context.Context) error {
-	// In a real provider, this would make an actual API call
-	// For demo purposes, we'll simulate a successful authentication
+func (c *cloudCanaryClient) verifyAuth(ctx context.Context) (err error) {
 	if c.apiKey == "" {
 		return fmt.Errorf("API key is required")
 	}
+
+	if err := c.breaker.allow(); err != nil {
+		return err
+	}
+	defer func() { c.breaker.record(err) }()
+
+	// In a real provider, this would make an actual API call
+	// For demo purposes, we'll simulate a successful authentication
 	tflog.Debug(ctx, "Successfully authenticated with CloudCanary API")
 	return nil
 }
 
+// apiInfoCache remembers the API info for the life of the client. The zero value is ready to use.
+type apiInfoCache struct {
+	mu   sync.Mutex
+	info *APIInfo
+}
+
+// getAPIInfo returns the version of the CloudCanary API and the check types
+// it supports. The API only describes itself once per client; later calls
+// return the same info.
+func (c *cloudCanaryClient) getAPIInfo(ctx context.Context) (*APIInfo, error) {
+	c.apiInfo.mu.Lock()
+	defer c.apiInfo.mu.Unlock()
+	if c.apiInfo.info != nil {
+		return c.apiInfo.info, nil
+	}
+
+	info, err := c.fetchAPIInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	c.apiInfo.info = info
+	return info, nil
+}
+
+// ensureCheckTypeEnabled returns an error if the API doesn't list checkType
+// among the check types the account may create
+func (c *cloudCanaryClient) ensureCheckTypeEnabled(ctx context.Context, checkType string) error {
+	info, err := c.getAPIInfo(ctx)
+	if err != nil {
+		return fmt.Errorf("could not read API info: %w", err)
+	}
+
+	for _, supported := range info.CheckTypes {
+		if supported == checkType {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%s checks are not enabled for your account (enabled check types: %s)", checkType, strings.Join(info.CheckTypes, ", "))
+}
+
+// fetchAPIInfo asks the API to describe itself
+func (c *cloudCanaryClient) fetchAPIInfo(ctx context.Context) (_ *APIInfo, err error) {
+	if err := c.breaker.allow(); err != nil {
+		return nil, err
+	}
+	defer func() { c.breaker.record(err) }()
+
+	// For demo purposes, we'll simulate the API describing itself
+	info := &APIInfo{
+		APIVersion: "1.0.0",
+		CheckTypes: []string{"http", "api", "tcp", "websocket"},
+	}
+
+	tflog.Debug(ctx, "Retrieved API info", map[string]any{
+		"api_version": info.APIVersion,
+	})
+
+	return info, nil
+}
+
+// getQuota returns how many checks the account may have and where they may run
+func (c *cloudCanaryClient) getQuota(ctx context.Context) (_ *Quota, err error) {
+	if err := c.breaker.allow(); err != nil {
+		return nil, err
+	}
+	defer func() { c.breaker.record(err) }()
+
+	// For demo purposes, we'll simulate a plan allowing 50 checks, with the
+	// six checks of the simulated account in use
+	quota := &Quota{
+		ChecksUsed:       6,
+		ChecksLimit:      50,
+		RegionsAvailable: []string{"us-east-1", "us-west-2", "eu-west-1", "eu-central-1", "ap-southeast-1"},
+	}
+
+	tflog.Debug(ctx, "Retrieved quota", map[string]any{
+		"checks_used":  quota.ChecksUsed,
+		"checks_limit": quota.ChecksLimit,
+	})
+
+	return quota, nil
+}
+
+// findCheckByName looks up a check of any type by its exact name, returning
+// its ID or an empty string if no check has that name
+func (c *cloudCanaryClient) findCheckByName(ctx context.Context, name string) (_ string, err error) {
+	if name == "" {
+		return "", fmt.Errorf("check name is required")
+	}
+
+	if err := c.breaker.allow(); err != nil {
+		return "", err
+	}
+	defer func() { c.breaker.record(err) }()
+
+	// For demo purposes, we'll simulate a search that finds no existing checks
+
+	tflog.Debug(ctx, "Searched for check by name", map[string]any{
+		"name": name,
+	})
+
+	return "", nil
+}
+
+// ensureUniqueName returns an error if enforce_unique_names is enabled and a
+// check with the given name already exists
+func (c *cloudCanaryClient) ensureUniqueName(ctx context.Context, name string) error {
+	if !c.enforceUniqueNames {
+		return nil
+	}
+
+	id, err := c.findCheckByName(ctx, name)
+	if err != nil {
+		return fmt.Errorf("could not search for existing checks: %w", err)
+	}
+	if id != "" {
+		return fmt.Errorf("a check named %q already exists (ID %s)", name, id)
+	}
+
+	return nil
+}
+
+// ensureAllowedEnvironment returns an error if environment is set to a value
+// outside the provider's allowed_environments
+func (c *cloudCanaryClient) ensureAllowedEnvironment(environment types.String) error {
+	if environment.IsNull() || environment.IsUnknown() {
+		return nil
+	}
+
+	for _, allowed := range c.allowedEnvironments {
+		if environment.ValueString() == allowed {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("environment %q is not one of the allowed environments (%s)", environment.ValueString(), strings.Join(c.allowedEnvironments, ", "))
+}
+
+// listChecks returns a summary of every check in the account
+func (c *cloudCanaryClient) listChecks(ctx context.Context) (_ []CheckSummary, err error) {
+	if err := c.breaker.allow(); err != nil {
+		return nil, err
+	}
+	defer func() { c.breaker.record(err) }()
+
+	// For demo purposes, we'll simulate a small account with checks of each type
+	samples := []struct {
+		prefix, name, checkType, environment, lastResult string
+		tags                                             map[string]string
+	}{
+		{"hc", "Website", "http", "prod", "SUCCESS", map[string]string{autoTagManagedBy: "terraform"}},
+		{"hc", "Marketing site", "http", "prod", "SUCCESS", nil},
+		{"ac", "Orders API", "api", "prod", "SUCCESS", map[string]string{autoTagManagedBy: "terraform", "team": "orders"}},
+		{"ac", "Search API", "api", "staging", "DEGRADED", map[string]string{"team": "search"}},
+		{"tc", "Redis", "tcp", "staging", "FAILURE", map[string]string{autoTagManagedBy: "terraform", "team": "platform"}},
+		{"wc", "Live updates", "websocket", "dev", "SUCCESS", map[string]string{"team": "platform"}},
+	}
+
+	checks := make([]CheckSummary, 0, len(samples))
+	for _, sample := range samples {
+		hash := sha256.Sum256([]byte(sample.name))
+		checks = append(checks, CheckSummary{
+			ID:          fmt.Sprintf("%s-%x", sample.prefix, hash[:8]),
+			Name:        sample.name,
+			Type:        sample.checkType,
+			Environment: sample.environment,
+			LastResult:  sample.lastResult,
+			Tags:        sample.tags,
+		})
+	}
+
+	tflog.Debug(ctx, "Listed checks", map[string]any{
+		"check_count": len(checks),
+	})
+
+	return checks, nil
+}
+
 // createHTTPCheck creates a new HTTP check
-func (c *cloudCanaryClient) createHTTPCheck(ctx context.Context, check *HTTPCheck) error {
-	// For demo purposes, we'll simulate creating a check
+func (c *cloudCanaryClient) createHTTPCheck(ctx context.Context, check *HTTPCheck) (err error) {
 	if check.Name.IsNull() || check.Name.ValueString() == "" {
 		return fmt.Errorf("check name is required")
 	}
-	
+
+	if err := c.breaker.allow(); err != nil {
+		return err
+	}
+	defer func() { c.breaker.record(err) }()
+
+	// For demo purposes, we'll simulate creating a check
+
 	// Generate a deterministic ID based on the check's properties
 	hash := sha256.Sum256([]byte(fmt.Sprintf("%s-%s-%d", check.Name.ValueString(), check.URL.ValueString(), time.Now().UnixNano())))
 	check.ID = types.StringValue(fmt.Sprintf("hc-%x", hash[:8]))
-	
+
+	now := time.Now().Format(time.RFC3339)
+	check.CreatedAt = types.StringValue(now)
+	check.UpdatedAt = types.StringValue(now)
+
+	c.resultLabels.set(check.ID.ValueString(), check.ResultLabels)
+	c.bodylessChecks.set(check.ID.ValueString(), check.StoreResponseBody.Equal(types.BoolValue(false)))
+
 	tflog.Debug(ctx, "Created HTTP check", map[string]any{
 		"id":   check.ID.ValueString(),
 		"name": check.Name.ValueString(),
 		"url":  check.URL.ValueString(),
+		"tags": c.autoTags,
 	})
-	
+
 	// In a real provider, we would make an HTTP request to the API
 	return nil
 }
 
 // readHTTPCheck reads an HTTP check by ID
-func (c *cloudCanaryClient) readHTTPCheck(ctx context.Context, id string) (*HTTPCheck, error) {
-	// For demo purposes, we'll simulate reading a check
-	// In a real provider, we would make an HTTP request to the API
-	
-	// Emulate an API call failure if the ID is empty
+func (c *cloudCanaryClient) readHTTPCheck(ctx context.Context, id string) (_ *HTTPCheck, err error) {
 	if id == "" {
 		return nil, fmt.Errorf("check ID is required")
 	}
-	
+
+	if err := c.breaker.allow(); err != nil {
+		return nil, err
+	}
+	defer func() { c.breaker.record(err) }()
+
+	// For demo purposes, we'll simulate reading a check
+	// In a real provider, we would make an HTTP request to the API
+
 	// For this demo, just return a dummy check with the provided ID
 	// In a real provider, we would parse the API response
 	check := &HTTPCheck{
-		ID:               types.StringValue(id),
-		Name:             types.StringValue("Retrieved check " + id),
-		URL:              types.StringValue("https://example.com"),
-		Method:           types.StringValue("GET"),
-		ExpectedStatus:   types.Int64Value(200),
-		Interval:         types.Int64Value(60),
-		Timeout:          types.Int64Value(5),
-		FollowRedirects:  types.BoolValue(true),
-		Regions:          types.ListValueMust(types.StringType, []attr.Value{
+		ID:              types.StringValue(id),
+		Name:            types.StringValue("Retrieved check " + id),
+		URL:             types.StringValue("https://example.com"),
+		Method:          types.StringValue("GET"),
+		ExpectedStatus:  types.Int64Value(200),
+		Interval:        types.Int64Value(60),
+		Timeout:         types.Int64Value(5),
+		FollowRedirects: types.BoolValue(true),
+		Regions: types.ListValueMust(types.StringType, []attr.Value{
 			types.StringValue("us-east-1"),
 			types.StringValue("eu-west-1"),
 		}),
-		Retries:          types.Int64Value(2),
-		Headers:          types.MapValueMust(types.StringType, map[string]attr.Value{
-			"User-Agent": types.StringValue("CloudCanary"),
-		}),
+		Retries: types.Int64Value(2),
+		Headers: headersValue([]apiHeader{
+			{Name: "User-Agent", Value: "CloudCanary"},
+		}, c.duplicateHeaders),
 		// Important: Keep null values as null rather than empty values
-		Body:             types.StringNull(),
-		ExpectedResponse: types.StringNull(),
-		LastResult:       types.StringValue("SUCCESS"),
-		LastCheckTime:    types.StringValue(time.Now().Format(time.RFC3339)),
+		Body:              types.StringNull(),
+		ExpectedResponse:  types.StringNull(),
+		BasicAuthUsername: types.StringNull(),
+		// Important: Sensitive fields should remain null in mock data
+		BasicAuthPassword: types.StringNull(),
+		LastResult:        types.StringValue("SUCCESS"),
+		LastCheckTime:     types.StringValue(time.Now().Format(time.RFC3339)),
+		NextCheckTime:     types.StringNull(),
+		// Simulate a certificate renewed well ahead of expiry
+		TLSCertificateExpiry: types.StringValue(time.Now().UTC().AddDate(0, 0, 90).Truncate(24 * time.Hour).Format(time.RFC3339)),
+		// The mock doesn't persist creation times, so leave created_at to state
+		CreatedAt: types.StringNull(),
+		UpdatedAt: types.StringValue(time.Now().Format(time.RFC3339)),
 	}
-	
+
+	check.ResultLabels = c.resultLabels.get(id)
+
 	tflog.Debug(ctx, "Read HTTP check", map[string]any{
 		"id":   check.ID.ValueString(),
 		"name": check.Name.ValueString(),
 	})
-	
+
 	return check, nil
 }
 
 // updateHTTPCheck updates an existing HTTP check
-func (c *cloudCanaryClient) updateHTTPCheck(ctx context.Context, check *HTTPCheck) error {
-	// For demo purposes, we'll simulate updating a check
-	// In a real provider, we would make an HTTP request to the API
-	
-	// Emulate an API call failure if the ID is empty
+func (c *cloudCanaryClient) updateHTTPCheck(ctx context.Context, check *HTTPCheck) (err error) {
 	if check.ID.IsNull() || check.ID.ValueString() == "" {
 		return fmt.Errorf("check ID is required")
 	}
-	
+
+	if err := c.breaker.allow(); err != nil {
+		return err
+	}
+	defer func() { c.breaker.record(err) }()
+
+	// For demo purposes, we'll simulate updating a check
+	// In a real provider, we would make an HTTP request to the API
+
+	check.UpdatedAt = types.StringValue(time.Now().Format(time.RFC3339))
+
+	c.resultLabels.set(check.ID.ValueString(), check.ResultLabels)
+	c.bodylessChecks.set(check.ID.ValueString(), check.StoreResponseBody.Equal(types.BoolValue(false)))
+
 	tflog.Debug(ctx, "Updated HTTP check", map[string]any{
 		"id":   check.ID.ValueString(),
 		"name": check.Name.ValueString(),
 		"url":  check.URL.ValueString(),
 	})
-	
+
 	return nil
 }
 
 // deleteHTTPCheck deletes an HTTP check by ID
-func (c *cloudCanaryClient) deleteHTTPCheck(ctx context.Context, id string) error {
-	// For demo purposes, we'll simulate deleting a check
-	// In a real provider, we would make an HTTP request to the API
-	
-	// Emulate an API call failure if the ID is empty
+func (c *cloudCanaryClient) deleteHTTPCheck(ctx context.Context, id string) (err error) {
 	if id == "" {
 		return fmt.Errorf("check ID is required")
 	}
-	
+
+	if err := c.breaker.allow(); err != nil {
+		return err
+	}
+	defer func() { c.breaker.record(err) }()
+
+	// For demo purposes, we'll simulate deleting a check
+	// In a real provider, we would make an HTTP request to the API
+
+	c.keepAlive.drop(id)
+	c.resultLabels.delete(id)
+	c.bodylessChecks.set(id, false)
+
 	tflog.Debug(ctx, "Deleted HTTP check", map[string]any{
 		"id": id,
 	})
-	
+
 	return nil
 }
 
 // createAPICheck creates a new API check
-func (c *cloudCanaryClient) createAPICheck(ctx context.Context, check *APICheck) error {
-	// For demo purposes, we'll simulate creating an API check
+func (c *cloudCanaryClient) createAPICheck(ctx context.Context, check *APICheck) (err error) {
 	if check.Name.IsNull() || check.Name.ValueString() == "" {
 		return fmt.Errorf("check name is required")
 	}
-	
+
+	if err := c.breaker.allow(); err != nil {
+		return err
+	}
+	defer func() { c.breaker.record(err) }()
+
+	// For demo purposes, we'll simulate creating an API check
+
 	// Generate a deterministic ID based on the check's properties
 	hash := sha256.Sum256([]byte(fmt.Sprintf("%s-%s-%d", check.Name.ValueString(), check.Endpoint.ValueString(), time.Now().UnixNano())))
 	check.ID = types.StringValue(fmt.Sprintf("ac-%x", hash[:8]))
-	
+
+	now := time.Now().Format(time.RFC3339)
+	check.CreatedAt = types.StringValue(now)
+	check.UpdatedAt = types.StringValue(now)
+
+	c.resultLabels.set(check.ID.ValueString(), check.ResultLabels)
+
 	tflog.Debug(ctx, "Created API check", map[string]any{
 		"id":       check.ID.ValueString(),
 		"name":     check.Name.ValueString(),
 		"endpoint": check.Endpoint.ValueString(),
+		"tags":     c.autoTags,
 	})
-	
+
 	return nil
 }
 
 // readAPICheck reads an API check by ID
-func (c *cloudCanaryClient) readAPICheck(ctx context.Context, id string) (*APICheck, error) {
-	// For demo purposes, we'll simulate reading a check
-	
-	// Emulate an API call failure if the ID is empty
+func (c *cloudCanaryClient) readAPICheck(ctx context.Context, id string) (_ *APICheck, err error) {
 	if id == "" {
 		return nil, fmt.Errorf("check ID is required")
 	}
-	
+
+	if err := c.breaker.allow(); err != nil {
+		return nil, err
+	}
+	defer func() { c.breaker.record(err) }()
+
+	// For demo purposes, we'll simulate reading a check
+
 	// For this demo, just return a dummy check with the provided ID
 	check := &APICheck{
-		ID:               types.StringValue(id),
-		Name:             types.StringValue("Retrieved API check " + id),
-		Endpoint:         types.StringValue("https://api.example.com/v1/status"),
-		Method:           types.StringValue("POST"),
-		Headers:          types.MapValueMust(types.StringType, map[string]attr.Value{
-			"Content-Type": types.StringValue("application/json"),
-		}),
+		ID:       types.StringValue(id),
+		Name:     types.StringValue("Retrieved API check " + id),
+		Endpoint: types.StringValue("https://api.example.com/v1/status"),
+		Method:   types.StringValue("POST"),
+		Headers: headersValue([]apiHeader{
+			{Name: "Content-Type", Value: "application/json"},
+		}, c.duplicateHeaders),
 		// Important: Keep null values as null
-		Body:             types.StringNull(),
-		ExpectedStatus:   types.Int64Value(200),
+		Body:           types.StringNull(),
+		ExpectedStatus: types.Int64Value(200),
 		ResponseValidation: types.ListValueMust(types.StringType, []attr.Value{
 			types.StringValue("$.status == 'up'"),
 			types.StringValue("$.version != null"),
 		}),
-		Interval:         types.Int64Value(300),
-		Timeout:          types.Int64Value(10),
-		AuthType:         types.StringValue("bearer"),
+		Interval: types.Int64Value(300),
+		Timeout:  types.Int64Value(10),
+		AuthType: types.StringValue("bearer"),
 		// Important: Sensitive fields should remain null in mock data
-		AuthValue:        types.StringNull(),
-		LastResult:       types.StringValue("SUCCESS"),
-		LastCheckTime:    types.StringValue(time.Now().Format(time.RFC3339)),
+		AuthValue:     types.StringNull(),
+		LastResult:    types.StringValue("SUCCESS"),
+		LastCheckTime: types.StringValue(time.Now().Format(time.RFC3339)),
+		NextCheckTime: types.StringNull(),
+		// The mock doesn't persist creation times, so leave created_at to state
+		CreatedAt: types.StringNull(),
+		UpdatedAt: types.StringValue(time.Now().Format(time.RFC3339)),
 	}
-	
+
+	check.ResultLabels = c.resultLabels.get(id)
+
 	tflog.Debug(ctx, "Read API check", map[string]any{
 		"id":   check.ID.ValueString(),
 		"name": check.Name.ValueString(),
 	})
-	
+
 	return check, nil
 }
 
 // updateAPICheck updates an existing API check
-func (c *cloudCanaryClient) updateAPICheck(ctx context.Context, check *APICheck) error {
-	// For demo purposes, we'll simulate updating a check
-	
-	// Emulate an API call failure if the ID is empty
+func (c *cloudCanaryClient) updateAPICheck(ctx context.Context, check *APICheck) (err error) {
 	if check.ID.IsNull() || check.ID.ValueString() == "" {
 		return fmt.Errorf("check ID is required")
 	}
-	
+
+	if err := c.breaker.allow(); err != nil {
+		return err
+	}
+	defer func() { c.breaker.record(err) }()
+
+	// For demo purposes, we'll simulate updating a check
+
+	check.UpdatedAt = types.StringValue(time.Now().Format(time.RFC3339))
+
+	c.resultLabels.set(check.ID.ValueString(), check.ResultLabels)
+
 	tflog.Debug(ctx, "Updated API check", map[string]any{
 		"id":       check.ID.ValueString(),
 		"name":     check.Name.ValueString(),
 		"endpoint": check.Endpoint.ValueString(),
 	})
-	
+
 	return nil
 }
 
 // deleteAPICheck deletes an API check by ID
-func (c *cloudCanaryClient) deleteAPICheck(ctx context.Context, id string) error {
-	// For demo purposes, we'll simulate deleting a check
-	
-	// Emulate an API call failure if the ID is empty
+func (c *cloudCanaryClient) deleteAPICheck(ctx context.Context, id string) (err error) {
 	if id == "" {
 		return fmt.Errorf("check ID is required")
 	}
-	
+
+	if err := c.breaker.allow(); err != nil {
+		return err
+	}
+	defer func() { c.breaker.record(err) }()
+
+	// For demo purposes, we'll simulate deleting a check
+
+	c.resultLabels.delete(id)
+
 	tflog.Debug(ctx, "Deleted API check", map[string]any{
 		"id": id,
 	})
-	
+
+	return nil
+}
+
+// createTCPCheck creates a new TCP check
+func (c *cloudCanaryClient) createTCPCheck(ctx context.Context, check *TCPCheck) (err error) {
+	if check.Name.IsNull() || check.Name.ValueString() == "" {
+		return fmt.Errorf("check name is required")
+	}
+
+	if err := c.breaker.allow(); err != nil {
+		return err
+	}
+	defer func() { c.breaker.record(err) }()
+
+	// For demo purposes, we'll simulate creating a TCP check
+
+	// Generate a deterministic ID based on the check's properties
+	hash := sha256.Sum256([]byte(fmt.Sprintf("%s-%s-%d-%d", check.Name.ValueString(), check.Host.ValueString(), check.Port.ValueInt64(), time.Now().UnixNano())))
+	check.ID = types.StringValue(fmt.Sprintf("tc-%x", hash[:8]))
+
+	now := time.Now().Format(time.RFC3339)
+	check.CreatedAt = types.StringValue(now)
+	check.UpdatedAt = types.StringValue(now)
+
+	c.resultLabels.set(check.ID.ValueString(), check.ResultLabels)
+
+	tflog.Debug(ctx, "Created TCP check", map[string]any{
+		"id":   check.ID.ValueString(),
+		"name": check.Name.ValueString(),
+		"host": check.Host.ValueString(),
+		"port": check.Port.ValueInt64(),
+		"tags": c.autoTags,
+	})
+
 	return nil
 }
 
-// getCheckResults retrieves the results for a check by ID
-func (c *cloudCanaryClient) getCheckResults(ctx context.Context, id string, limit int) ([]CheckResult, error) {
-	// For demo purposes, we'll simulate retrieving check results
-	
-	// Emulate an API call failure if the ID is empty
+// readTCPCheck reads a TCP check by ID
+func (c *cloudCanaryClient) readTCPCheck(ctx context.Context, id string) (_ *TCPCheck, err error) {
 	if id == "" {
 		return nil, fmt.Errorf("check ID is required")
 	}
-	
-	// Generate sample results
-	results := make([]CheckResult, 0, limit)
-	for i := 0; i < limit; i++ {
-		// Alternate between success and failure for demonstration
-		status := "SUCCESS"
-		responseTime := 100 + (i * 10)
-		message := "Check completed successfully"
-		
-		if i%3 == 0 {
-			status = "FAILURE"
-			responseTime = 500 + (i * 20)
-			message = "Timeout waiting for response"
-		}
-		
-		results = append(results, CheckResult{
-			ID:           types.StringValue(fmt.Sprintf("res-%s-%d", id, i)),
-			CheckID:      types.StringValue(id),
-			Status:       types.StringValue(status),
-			ResponseTime: types.Int64Value(int64(responseTime)),
-			Message:      types.StringValue(message),
-			Timestamp:    types.StringValue(time.Now().Add(-time.Duration(i) * time.Hour).Format(time.RFC3339)),
-			// Keep optional fields as null, not empty values
-			Region:        types.StringNull(),
-			ResponseBody:  types.StringNull(),
-			ResponseCode:  types.Int64Null(),
-			FailureReason: types.StringNull(),
-		})
+
+	if err := c.breaker.allow(); err != nil {
+		return nil, err
 	}
-	
-	tflog.Debug(ctx, "Retrieved check results", map[string]any{
-		"check_id":     id,
-		"result_count": len(results),
+	defer func() { c.breaker.record(err) }()
+
+	// For demo purposes, we'll simulate reading a check
+
+	// For this demo, just return a dummy check with the provided ID
+	check := &TCPCheck{
+		ID:       types.StringValue(id),
+		Name:     types.StringValue("Retrieved TCP check " + id),
+		Host:     types.StringValue("example.com"),
+		Port:     types.Int64Value(443),
+		Interval: types.Int64Value(60),
+		Timeout:  types.Int64Value(5),
+		Regions: types.ListValueMust(types.StringType, []attr.Value{
+			types.StringValue("us-east-1"),
+		}),
+		// Important: Keep null values as null
+		SendPayload:     types.StringNull(),
+		ExpectedPayload: types.StringNull(),
+		PayloadEncoding: types.StringNull(),
+		LastResult:      types.StringValue("SUCCESS"),
+		LastCheckTime:   types.StringValue(time.Now().Format(time.RFC3339)),
+		NextCheckTime:   types.StringNull(),
+		// The mock doesn't persist creation times, so leave created_at to state
+		CreatedAt: types.StringNull(),
+		UpdatedAt: types.StringValue(time.Now().Format(time.RFC3339)),
+	}
+
+	check.ResultLabels = c.resultLabels.get(id)
+
+	tflog.Debug(ctx, "Read TCP check", map[string]any{
+		"id":   check.ID.ValueString(),
+		"name": check.Name.ValueString(),
 	})
-	
-	return results, nil
-}
\ No newline at end of file
+
+	return check, nil
+}
+
+// updateTCPCheck updates an existing TCP check
+func (c *cloudCanaryClient) updateTCPCheck(ctx context.Context, check *TCPCheck) (err error) {
+	if check.ID.IsNull() || check.ID.ValueString() == "" {
+		return fmt.Errorf("check ID is required")
+	}
+
+	if err := c.breaker.allow(); err != nil {
+		return err
+	}
+	defer func() { c.breaker.record(err) }()
+
+	// For demo purposes, we'll simulate updating a check
+
+	check.UpdatedAt = types.StringValue(time.Now().Format(time.RFC3339))
+
+	c.resultLabels.set(check.ID.ValueString(), check.ResultLabels)
+
+	tflog.Debug(ctx, "Updated TCP check", map[string]any{
+		"id":   check.ID.ValueString(),
+		"name": check.Name.ValueString(),
+		"host": check.Host.ValueString(),
+		"port": check.Port.ValueInt64(),
+	})
+
+	return nil
+}
+
+// deleteTCPCheck deletes a TCP check by ID
+func (c *cloudCanaryClient) deleteTCPCheck(ctx context.Context, id string) (err error) {
+	if id == "" {
+		return fmt.Errorf("check ID is required")
+	}
+
+	if err := c.breaker.allow(); err != nil {
+		return err
+	}
+	defer func() { c.breaker.record(err) }()
+
+	// For demo purposes, we'll simulate deleting a check
+
+	c.tcpConns.drop(id)
+	c.resultLabels.delete(id)
+
+	tflog.Debug(ctx, "Deleted TCP check", map[string]any{
+		"id": id,
+	})
+
+	return nil
+}
+
+// createWebSocketCheck creates a new WebSocket check
+func (c *cloudCanaryClient) createWebSocketCheck(ctx context.Context, check *WebSocketCheck) (err error) {
+	if check.Name.IsNull() || check.Name.ValueString() == "" {
+		return fmt.Errorf("check name is required")
+	}
+
+	if err := c.breaker.allow(); err != nil {
+		return err
+	}
+	defer func() { c.breaker.record(err) }()
+
+	// For demo purposes, we'll simulate creating a WebSocket check
+
+	// Generate a deterministic ID based on the check's properties
+	hash := sha256.Sum256([]byte(fmt.Sprintf("%s-%s-%d", check.Name.ValueString(), check.URL.ValueString(), time.Now().UnixNano())))
+	check.ID = types.StringValue(fmt.Sprintf("wc-%x", hash[:8]))
+
+	now := time.Now().Format(time.RFC3339)
+	check.CreatedAt = types.StringValue(now)
+	check.UpdatedAt = types.StringValue(now)
+
+	c.resultLabels.set(check.ID.ValueString(), check.ResultLabels)
+
+	tflog.Debug(ctx, "Created WebSocket check", map[string]any{
+		"id":   check.ID.ValueString(),
+		"name": check.Name.ValueString(),
+		"url":  check.URL.ValueString(),
+		"tags": c.autoTags,
+	})
+
+	return nil
+}
+
+// readWebSocketCheck reads a WebSocket check by ID
+func (c *cloudCanaryClient) readWebSocketCheck(ctx context.Context, id string) (_ *WebSocketCheck, err error) {
+	if id == "" {
+		return nil, fmt.Errorf("check ID is required")
+	}
+
+	if err := c.breaker.allow(); err != nil {
+		return nil, err
+	}
+	defer func() { c.breaker.record(err) }()
+
+	// For demo purposes, we'll simulate reading a check
+
+	// For this demo, just return a dummy check with the provided ID
+	check := &WebSocketCheck{
+		ID:       types.StringValue(id),
+		Name:     types.StringValue("Retrieved WebSocket check " + id),
+		URL:      types.StringValue("wss://example.com/socket"),
+		Interval: types.Int64Value(60),
+		Timeout:  types.Int64Value(10),
+		Regions: types.ListValueMust(types.StringType, []attr.Value{
+			types.StringValue("us-east-1"),
+		}),
+		// Important: Keep null values as null
+		Subprotocol:        types.StringNull(),
+		SendMessage:        types.StringNull(),
+		ExpectedMessage:    types.StringNull(),
+		LastResult:         types.StringValue("SUCCESS"),
+		LastCheckTime:      types.StringValue(time.Now().Format(time.RFC3339)),
+		NextCheckTime:      types.StringNull(),
+		LastConnectLatency: types.Int64Value(85),
+		// The mock doesn't persist creation times, so leave created_at to state
+		CreatedAt: types.StringNull(),
+		UpdatedAt: types.StringValue(time.Now().Format(time.RFC3339)),
+	}
+
+	check.ResultLabels = c.resultLabels.get(id)
+
+	tflog.Debug(ctx, "Read WebSocket check", map[string]any{
+		"id":   check.ID.ValueString(),
+		"name": check.Name.ValueString(),
+	})
+
+	return check, nil
+}
+
+// updateWebSocketCheck updates an existing WebSocket check
+func (c *cloudCanaryClient) updateWebSocketCheck(ctx context.Context, check *WebSocketCheck) (err error) {
+	if check.ID.IsNull() || check.ID.ValueString() == "" {
+		return fmt.Errorf("check ID is required")
+	}
+
+	if err := c.breaker.allow(); err != nil {
+		return err
+	}
+	defer func() { c.breaker.record(err) }()
+
+	// For demo purposes, we'll simulate updating a check
+
+	check.UpdatedAt = types.StringValue(time.Now().Format(time.RFC3339))
+
+	c.resultLabels.set(check.ID.ValueString(), check.ResultLabels)
+
+	tflog.Debug(ctx, "Updated WebSocket check", map[string]any{
+		"id":   check.ID.ValueString(),
+		"name": check.Name.ValueString(),
+		"url":  check.URL.ValueString(),
+	})
+
+	return nil
+}
+
+// deleteWebSocketCheck deletes a WebSocket check by ID
+func (c *cloudCanaryClient) deleteWebSocketCheck(ctx context.Context, id string) (err error) {
+	if id == "" {
+		return fmt.Errorf("check ID is required")
+	}
+
+	if err := c.breaker.allow(); err != nil {
+		return err
+	}
+	defer func() { c.breaker.record(err) }()
+
+	// For demo purposes, we'll simulate deleting a check
+
+	c.resultLabels.delete(id)
+
+	tflog.Debug(ctx, "Deleted WebSocket check", map[string]any{
+		"id": id,
+	})
+
+	return nil
+}
+
+// createCompositeCheck creates a new composite check
+func (c *cloudCanaryClient) createCompositeCheck(ctx context.Context, check *CompositeCheck) (err error) {
+	if check.Name.IsNull() || check.Name.ValueString() == "" {
+		return fmt.Errorf("check name is required")
+	}
+
+	if len(check.MemberCheckIDs.Elements()) == 0 {
+		return fmt.Errorf("at least one member check is required")
+	}
+
+	if err := c.breaker.allow(); err != nil {
+		return err
+	}
+	defer func() { c.breaker.record(err) }()
+
+	// For demo purposes, we'll simulate creating a composite check
+
+	// Generate a deterministic ID based on the check's properties
+	hash := sha256.Sum256([]byte(fmt.Sprintf("%s-%d", check.Name.ValueString(), time.Now().UnixNano())))
+	check.ID = types.StringValue(fmt.Sprintf("cc-%x", hash[:8]))
+	check.Status = types.StringValue(mockCompositeStatus(check))
+
+	now := time.Now().Format(time.RFC3339)
+	check.CreatedAt = types.StringValue(now)
+	check.UpdatedAt = types.StringValue(now)
+
+	tflog.Debug(ctx, "Created composite check", map[string]any{
+		"id":           check.ID.ValueString(),
+		"name":         check.Name.ValueString(),
+		"member_count": len(check.MemberCheckIDs.Elements()),
+		"tags":         c.autoTags,
+	})
+
+	return nil
+}
+
+// readCompositeCheck reads a composite check by ID
+func (c *cloudCanaryClient) readCompositeCheck(ctx context.Context, id string) (_ *CompositeCheck, err error) {
+	if id == "" {
+		return nil, fmt.Errorf("check ID is required")
+	}
+
+	if err := c.breaker.allow(); err != nil {
+		return nil, err
+	}
+	defer func() { c.breaker.record(err) }()
+
+	// For demo purposes, we'll simulate reading a check
+
+	// For this demo, just return a dummy check with the provided ID
+	check := &CompositeCheck{
+		ID:   types.StringValue(id),
+		Name: types.StringValue("Retrieved composite check " + id),
+		// Important: Keep null values as null
+		MemberCheckIDs: types.ListNull(types.StringType),
+		Aggregation:    types.StringNull(),
+		Status:         types.StringValue("SUCCESS"),
+		// The mock doesn't persist creation times, so leave created_at to state
+		CreatedAt: types.StringNull(),
+		UpdatedAt: types.StringValue(time.Now().Format(time.RFC3339)),
+	}
+
+	tflog.Debug(ctx, "Read composite check", map[string]any{
+		"id":   check.ID.ValueString(),
+		"name": check.Name.ValueString(),
+	})
+
+	return check, nil
+}
+
+// updateCompositeCheck updates an existing composite check
+func (c *cloudCanaryClient) updateCompositeCheck(ctx context.Context, check *CompositeCheck) (err error) {
+	if check.ID.IsNull() || check.ID.ValueString() == "" {
+		return fmt.Errorf("check ID is required")
+	}
+
+	if err := c.breaker.allow(); err != nil {
+		return err
+	}
+	defer func() { c.breaker.record(err) }()
+
+	// For demo purposes, we'll simulate updating a check
+
+	// Membership or aggregation may have changed, so the status is re-derived
+	check.Status = types.StringValue(mockCompositeStatus(check))
+	check.UpdatedAt = types.StringValue(time.Now().Format(time.RFC3339))
+
+	tflog.Debug(ctx, "Updated composite check", map[string]any{
+		"id":           check.ID.ValueString(),
+		"name":         check.Name.ValueString(),
+		"member_count": len(check.MemberCheckIDs.Elements()),
+	})
+
+	return nil
+}
+
+// deleteCompositeCheck deletes a composite check by ID
+func (c *cloudCanaryClient) deleteCompositeCheck(ctx context.Context, id string) (err error) {
+	if id == "" {
+		return fmt.Errorf("check ID is required")
+	}
+
+	if err := c.breaker.allow(); err != nil {
+		return err
+	}
+	defer func() { c.breaker.record(err) }()
+
+	// For demo purposes, we'll simulate deleting a check
+
+	tflog.Debug(ctx, "Deleted composite check", map[string]any{
+		"id": id,
+	})
+
+	return nil
+}
+
+// mockCompositeStatus simulates the API deriving a composite check's status.
+// Each member's last result is made up from its ID, so a given set of members
+// always aggregates to the same status.
+func mockCompositeStatus(check *CompositeCheck) string {
+	members := listStrings(check.MemberCheckIDs)
+	statuses := make([]string, 0, len(members))
+	for _, id := range members {
+		hash := sha256.Sum256([]byte(id))
+		status := "SUCCESS"
+		if hash[0]%4 == 0 {
+			status = "FAILURE"
+		}
+		statuses = append(statuses, status)
+	}
+
+	aggregation := "all"
+	if !check.Aggregation.IsNull() {
+		aggregation = check.Aggregation.ValueString()
+	}
+
+	return aggregateStatus(aggregation, statuses)
+}
+
+// resultsPageSize is the most results the API returns for one request. It
+// covers the 90 days of hourly results the stats data sources ask for.
+const resultsPageSize = 2500
+
+// getCheckResults retrieves the most recent results for a check by ID. Only
+// every sampleRate-th result is returned, so the limit results span
+// limit*sampleRate runs; a sampleRate of 1 returns every result. Results past
+// the first page aren't fetched yet, so at most resultsPageSize are returned,
+// and fewer when the check has fewer.
+func (c *cloudCanaryClient) getCheckResults(ctx context.Context, id string, limit, sampleRate int) (_ []CheckResult, err error) {
+	if id == "" {
+		return nil, fmt.Errorf("check ID is required")
+	}
+
+	if sampleRate < 1 {
+		return nil, fmt.Errorf("sample rate must be at least 1, got %d", sampleRate)
+	}
+
+	if err := c.breaker.allow(); err != nil {
+		return nil, err
+	}
+	defer func() { c.breaker.record(err) }()
+
+	// For demo purposes, we'll simulate retrieving check results
+
+	// Until pagination lands, a limit past one page only gets the first page
+	if limit > resultsPageSize {
+		tflog.Warn(ctx, "Check results limit exceeds one API page, returning only the first page", map[string]any{
+			"check_id":  id,
+			"limit":     limit,
+			"page_size": resultsPageSize,
+		})
+	}
+
+	// For demo purposes, the API answers with one page of sample results,
+	// skipping the runs left out by sampling
+	pageSize := limit
+	if pageSize > resultsPageSize {
+		pageSize = resultsPageSize
+	}
+	labels := c.resultLabels.get(id)
+	results := make([]CheckResult, 0, pageSize)
+	for i := 0; len(results) < pageSize; i += sampleRate {
+		// Alternate between success and failure for demonstration
+		status := "SUCCESS"
+		responseTime := 100 + (i * 10)
+		message := "Check completed successfully"
+
+		if i%3 == 0 {
+			status = "FAILURE"
+			responseTime = 500 + (i * 20)
+			message = "Timeout waiting for response"
+		}
+
+		// API checks respond with a small JSON document when healthy, HTTP
+		// checks with a small page
+		responseBody := types.StringNull()
+		responseSize := types.Int64Null()
+		bodySHA256 := types.StringNull()
+		timings := mockPhaseTimings(0)
+		if status == "SUCCESS" {
+			timings = mockPhaseTimings(int64(responseTime))
+			responseSize = types.Int64Value(int64(2048 + i))
+			switch {
+			case strings.HasPrefix(id, "ac-"):
+				responseBody = types.StringValue(`{"status":"up","version":"1.4.2"}`)
+			case strings.HasPrefix(id, "hc-"):
+				responseBody = types.StringValue("<!doctype html><title>OK</title>")
+			}
+			if !responseBody.IsNull() {
+				responseSize = types.Int64Value(int64(len(responseBody.ValueString())))
+			}
+
+			// Healthy checks serve the same content every run
+			sum := sha256.Sum256([]byte(id))
+			if !responseBody.IsNull() {
+				sum = sha256.Sum256([]byte(responseBody.ValueString()))
+			}
+			bodySHA256 = types.StringValue(hex.EncodeToString(sum[:]))
+		}
+
+		results = append(results, CheckResult{
+			ID:           types.StringValue(fmt.Sprintf("res-%s-%d", id, i)),
+			CheckID:      types.StringValue(id),
+			Status:       types.StringValue(status),
+			ResponseTime: types.Int64Value(int64(responseTime)),
+			Message:      types.StringValue(message),
+			Timestamp:    types.StringValue(time.Now().Add(-time.Duration(i) * time.Hour).Format(time.RFC3339)),
+			// Keep optional fields as null, not empty values
+			Region:        types.StringNull(),
+			ResponseBody:  responseBody,
+			ResponseCode:  types.Int64Null(),
+			NotModified:   types.BoolNull(),
+			ResponseSize:  responseSize,
+			BodySHA256:    bodySHA256,
+			DNSTime:       timings[0],
+			ConnectTime:   timings[1],
+			TLSTime:       timings[2],
+			TTFB:          timings[3],
+			DownloadTime:  timings[4],
+			FailureReason: types.StringNull(),
+			Labels:        labels,
+		})
+	}
+
+	// Never hand out bodies for checks that opted out of storing them
+	if c.bodylessChecks.has(id) {
+		for i := range results {
+			results[i].ResponseBody = types.StringNull()
+		}
+	}
+
+	tflog.Debug(ctx, "Retrieved check results", map[string]any{
+		"check_id":     id,
+		"result_count": len(results),
+		"sample_rate":  sampleRate,
+	})
+
+	return results, nil
+}
+
+// mockPhaseTimings splits a simulated response time into DNS, connect, TLS,
+// time to first byte and download phases. A zero total yields null phases,
+// as for a request that timed out.
+func mockPhaseTimings(total int64) [5]types.Int64 {
+	if total == 0 {
+		return [5]types.Int64{types.Int64Null(), types.Int64Null(), types.Int64Null(), types.Int64Null(), types.Int64Null()}
+	}
+
+	dns, connect, tls := total/10, total/10, total/5
+	ttfb := total * 4 / 5
+	return [5]types.Int64{
+		types.Int64Value(dns),
+		types.Int64Value(connect),
+		types.Int64Value(tls),
+		types.Int64Value(ttfb),
+		types.Int64Value(total - ttfb),
+	}
+}
+
+// checkRunbookURL returns the runbook_url of a check of any type, which the
+// ID prefix gives away
+func (c *cloudCanaryClient) checkRunbookURL(ctx context.Context, id string) (types.String, error) {
+	switch {
+	case strings.HasPrefix(id, "hc-"):
+		check, err := c.readHTTPCheck(ctx, id)
+		if err != nil {
+			return types.StringNull(), err
+		}
+		return check.RunbookURL, nil
+	case strings.HasPrefix(id, "ac-"):
+		check, err := c.readAPICheck(ctx, id)
+		if err != nil {
+			return types.StringNull(), err
+		}
+		return check.RunbookURL, nil
+	case strings.HasPrefix(id, "tc-"):
+		check, err := c.readTCPCheck(ctx, id)
+		if err != nil {
+			return types.StringNull(), err
+		}
+		return check.RunbookURL, nil
+	case strings.HasPrefix(id, "wc-"):
+		check, err := c.readWebSocketCheck(ctx, id)
+		if err != nil {
+			return types.StringNull(), err
+		}
+		return check.RunbookURL, nil
+	}
+	return types.StringNull(), nil
+}
+
+// readCheck reads an HTTP, API, TCP or WebSocket check with the client method
+// for its type, which the ID prefix gives away, returning a pointer to its model
+func (c *cloudCanaryClient) readCheck(ctx context.Context, id string) (any, error) {
+	switch {
+	case strings.HasPrefix(id, "hc-"):
+		return c.readHTTPCheck(ctx, id)
+	case strings.HasPrefix(id, "ac-"):
+		return c.readAPICheck(ctx, id)
+	case strings.HasPrefix(id, "tc-"):
+		return c.readTCPCheck(ctx, id)
+	default:
+		return c.readWebSocketCheck(ctx, id)
+	}
+}
+
+// checkSnapshot is a check's configuration as recorded by a change to it
+type checkSnapshot struct {
+	// check points to the check's model, as returned by readCheck
+	check      any
+	recordedAt time.Time
+}
+
+// mockCheckAge is how long ago the mock API pretends every check was created
+const mockCheckAge = 30 * 24 * time.Hour
+
+// getCheckConfigAt returns the configuration a check had at the given time:
+// the latest snapshot recorded at or before it. It returns nil when there is
+// none, as when the check didn't exist yet.
+func (c *cloudCanaryClient) getCheckConfigAt(ctx context.Context, id string, at time.Time) (*checkSnapshot, error) {
+	// For demo purposes, we'll treat every check as created mockCheckAge ago
+	// and never changed since, so its current configuration is its only snapshot
+	createdAt := time.Now().UTC().Add(-mockCheckAge).Truncate(time.Hour)
+	if at.Before(createdAt) {
+		tflog.Debug(ctx, "No check configuration snapshot found", map[string]any{
+			"id": id,
+			"at": at.Format(time.RFC3339),
+		})
+		return nil, nil
+	}
+
+	check, err := c.readCheck(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	tflog.Debug(ctx, "Retrieved check configuration snapshot", map[string]any{
+		"id":          id,
+		"at":          at.Format(time.RFC3339),
+		"recorded_at": createdAt.Format(time.RFC3339),
+	})
+
+	return &checkSnapshot{check: check, recordedAt: createdAt}, nil
+}
+
+// getCheckResultsSince retrieves the results recorded after cursor, newest
+// first, along with the cursor to pass on the next call. An empty cursor
+// returns the most recent results.
+func (c *cloudCanaryClient) getCheckResultsSince(ctx context.Context, id string, cursor string) ([]CheckResult, string, error) {
+	// For demo purposes, we'll simulate a cursor by encoding the timestamp of
+	// the newest result returned
+
+	// Emulate an API call failure if the ID is empty
+	if id == "" {
+		return nil, "", fmt.Errorf("check ID is required")
+	}
+
+	var since time.Time
+	if cursor != "" {
+		var err error
+		since, err = time.Parse(time.RFC3339Nano, cursor)
+		if err != nil {
+			return nil, "", fmt.Errorf("invalid cursor %q", cursor)
+		}
+	}
+
+	recent, err := c.getCheckResults(ctx, id, 10, 1)
+	if err != nil {
+		return nil, "", err
+	}
+
+	results := make([]CheckResult, 0, len(recent))
+	nextCursor := cursor
+	for _, result := range recent {
+		timestamp, err := time.Parse(time.RFC3339, result.Timestamp.ValueString())
+		if err != nil {
+			return nil, "", fmt.Errorf("invalid result timestamp %q: %w", result.Timestamp.ValueString(), err)
+		}
+		if !timestamp.After(since) {
+			continue
+		}
+
+		// Results are newest first, so the first one kept moves the cursor
+		if len(results) == 0 {
+			nextCursor = timestamp.UTC().Format(time.RFC3339Nano)
+		}
+		results = append(results, result)
+	}
+
+	tflog.Debug(ctx, "Retrieved check results since cursor", map[string]any{
+		"check_id":     id,
+		"cursor":       cursor,
+		"result_count": len(results),
+	})
+
+	return results, nextCursor, nil
+}
+
+// getCheckUptime returns the percentage of successful results for a check over the given window
+func (c *cloudCanaryClient) getCheckUptime(ctx context.Context, id string, window time.Duration) (float64, error) {
+	// For demo purposes, we'll derive uptime from the simulated hourly results
+	results, err := c.getCheckResults(ctx, id, int(window/time.Hour), 1)
+	if err != nil {
+		return 0, err
+	}
+	if len(results) == 0 {
+		return 100, nil
+	}
+
+	successes := 0
+	for _, result := range results {
+		if result.Status.ValueString() == "SUCCESS" {
+			successes++
+		}
+	}
+
+	return float64(successes) / float64(len(results)) * 100, nil
+}
+
+// getCheckStats returns statistics for a check over the last periodDays days,
+// grouped into buckets by groupBy (none, hour, day)
+func (c *cloudCanaryClient) getCheckStats(ctx context.Context, id string, periodDays int, groupBy string) (*CheckStats, error) {
+	// For demo purposes, we'll aggregate the simulated hourly results
+	bucket, ok := statsGroupings[groupBy]
+	if !ok {
+		return nil, fmt.Errorf("unsupported group_by %q", groupBy)
+	}
+
+	results, err := c.getCheckResults(ctx, id, periodDays*24, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	stats, err := summarizeResults(results, bucket)
+	if err != nil {
+		return nil, err
+	}
+
+	// A check that keeps changing status is flapping rather than up or down
+	transitions, err := countTransitions(results, time.Now().Add(-flapWindow))
+	if err != nil {
+		return nil, err
+	}
+	stats.FlapCount1h = types.Int64Value(int64(transitions))
+	stats.IsFlapping = types.BoolValue(transitions >= flapThreshold)
+
+	// Error rates over the burn rate windows, for SLO alerting
+	now := time.Now()
+	stats.ErrorRate1h, err = errorRate(results, now.Add(-burnRateShortWindow))
+	if err != nil {
+		return nil, err
+	}
+	stats.ErrorRate6h, err = errorRate(results, now.Add(-burnRateLongWindow))
+	if err != nil {
+		return nil, err
+	}
+
+	tflog.Debug(ctx, "Retrieved check stats", map[string]any{
+		"check_id":     id,
+		"group_by":     groupBy,
+		"bucket_count": len(stats.Buckets),
+	})
+
+	return stats, nil
+}
+
+// getLatestResult returns the most recent result of a check, or nil if it has none
+func (c *cloudCanaryClient) getLatestResult(ctx context.Context, checkID string) (*CheckResult, error) {
+	results, err := c.getCheckResults(ctx, checkID, 1, 1)
+	if err != nil {
+		return nil, err
+	}
+	if len(results) == 0 {
+		return nil, nil
+	}
+
+	return &results[0], nil
+}
+
+// getIncidents returns the incidents of a check over the incident lookback window, newest first
+func (c *cloudCanaryClient) getIncidents(ctx context.Context, checkID string) ([]Incident, error) {
+	// For demo purposes, we'll derive incidents from the simulated hourly results
+	results, err := c.getCheckResults(ctx, checkID, int(incidentLookback/time.Hour), 1)
+	if err != nil {
+		return nil, err
+	}
+
+	incidents, err := incidentsFromResults(checkID, results, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	// Point responders at the check's runbook
+	runbookURL, err := c.checkRunbookURL(ctx, checkID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range incidents {
+		incidents[i].RunbookURL = runbookURL
+	}
+
+	tflog.Debug(ctx, "Retrieved incidents", map[string]any{
+		"check_id":       checkID,
+		"incident_count": len(incidents),
+	})
+
+	return incidents, nil
+}
+
+// deleteChecksByTag deletes every check tagged key, with the given value unless
+// value is empty, returning the IDs of the checks deleted. A failed delete
+// stops the cleanup and returns the checks already deleted with the error.
+func (c *cloudCanaryClient) deleteChecksByTag(ctx context.Context, key, value string) ([]string, error) {
+	if key == "" {
+		return nil, fmt.Errorf("tag key is required")
+	}
+
+	checks, err := c.listChecks(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	deleted := []string{}
+	for _, check := range checks {
+		tagValue, ok := check.Tags[key]
+		if !ok || (value != "" && tagValue != value) {
+			continue
+		}
+
+		// Delete with the client method for the check's type
+		switch check.Type {
+		case "http":
+			err = c.deleteHTTPCheck(ctx, check.ID)
+		case "api":
+			err = c.deleteAPICheck(ctx, check.ID)
+		case "tcp":
+			err = c.deleteTCPCheck(ctx, check.ID)
+		case "websocket":
+			err = c.deleteWebSocketCheck(ctx, check.ID)
+		default:
+			err = fmt.Errorf("unsupported check type %q", check.Type)
+		}
+		if err != nil {
+			return deleted, fmt.Errorf("could not delete check %s: %w", check.ID, err)
+		}
+		deleted = append(deleted, check.ID)
+	}
+
+	tflog.Debug(ctx, "Deleted checks by tag", map[string]any{
+		"tag_key":       key,
+		"tag_value":     value,
+		"deleted_count": len(deleted),
+	})
+
+	return deleted, nil
+}
+
+// getCheckGroupMembers returns the checks belonging to a check group
+func (c *cloudCanaryClient) getCheckGroupMembers(ctx context.Context, groupID string) ([]CheckSummary, error) {
+	// For demo purposes, every group holds the first few checks in the account
+	if groupID == "" {
+		return nil, fmt.Errorf("group ID is required")
+	}
+
+	checks, err := c.listChecks(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(checks) > 3 {
+		checks = checks[:3]
+	}
+
+	tflog.Debug(ctx, "Retrieved check group members", map[string]any{
+		"group_id":     groupID,
+		"member_count": len(checks),
+	})
+
+	return checks, nil
+}
+
+// getGroupStats returns the average uptime of a check group's members over the
+// last periodDays days, along with the member with the lowest uptime
+func (c *cloudCanaryClient) getGroupStats(ctx context.Context, groupID string, periodDays int) (*GroupStats, error) {
+	members, err := c.getCheckGroupMembers(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &GroupStats{
+		MemberCount:    len(members),
+		Uptime:         types.Float64Null(),
+		WorstCheckID:   types.StringNull(),
+		WorstCheckName: types.StringNull(),
+		WorstUptime:    types.Float64Null(),
+	}
+	if len(members) == 0 {
+		return stats, nil
+	}
+
+	var total float64
+	for _, member := range members {
+		uptime, err := c.getCheckUptime(ctx, member.ID, time.Duration(periodDays)*24*time.Hour)
+		if err != nil {
+			return nil, fmt.Errorf("could not get uptime for check %s: %w", member.ID, err)
+		}
+		total += uptime
+
+		// Ties go to the first member listed
+		if stats.WorstUptime.IsNull() || uptime < stats.WorstUptime.ValueFloat64() {
+			stats.WorstCheckID = types.StringValue(member.ID)
+			stats.WorstCheckName = types.StringValue(member.Name)
+			stats.WorstUptime = types.Float64Value(uptime)
+		}
+	}
+	stats.Uptime = types.Float64Value(total / float64(len(members)))
+
+	tflog.Debug(ctx, "Retrieved group stats", map[string]any{
+		"group_id":     groupID,
+		"member_count": len(members),
+	})
+
+	return stats, nil
+}
+
+// runCheckNow triggers an immediate run of a check outside its schedule. The
+// API runs the check itself, so the run is reported as the check's latest
+// result.
+func (c *cloudCanaryClient) runCheckNow(ctx context.Context, id string) (*CheckResult, error) {
+	// Emulate an API call failure if the ID is empty
+	if id == "" {
+		return nil, fmt.Errorf("check ID is required")
+	}
+
+	result, err := c.getLatestResult(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if result == nil {
+		return nil, fmt.Errorf("check %s has not produced a result yet", id)
+	}
+
+	tflog.Debug(ctx, "Ran check on demand", map[string]any{
+		"check_id": id,
+		"status":   result.Status.ValueString(),
+	})
+
+	return result, nil
+}
+
+// labelStore remembers the result_labels of checks by ID. Labels are the one
+// setting the mock API keeps from create and update, reports back on read and
+// echoes on results. The zero value is ready to use.
+type labelStore struct {
+	mu     sync.Mutex
+	labels map[string]types.Map
+}
+
+// set records the result_labels of a check, forgetting them when labels is null
+func (s *labelStore) set(id string, labels types.Map) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if labels.IsNull() || labels.IsUnknown() {
+		delete(s.labels, id)
+		return
+	}
+	if s.labels == nil {
+		s.labels = make(map[string]types.Map)
+	}
+	s.labels[id] = labels
+}
+
+// get returns the result_labels of a check, or a null map if it has none
+func (s *labelStore) get(id string) types.Map {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if labels, ok := s.labels[id]; ok {
+		return labels
+	}
+	return types.MapNull(types.StringType)
+}
+
+// delete forgets the result_labels of a deleted check
+func (s *labelStore) delete(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.labels, id)
+}
+
+// checkSet holds the IDs of checks with some setting, such as the HTTP checks
+// that opted out of storing response bodies. Like result labels, the mock API
+// learns it from create and update. The zero value is ready to use.
+type checkSet struct {
+	mu  sync.Mutex
+	ids map[string]bool
+}
+
+// set adds id to the set, or removes it when member is false
+func (s *checkSet) set(id string, member bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !member {
+		delete(s.ids, id)
+		return
+	}
+	if s.ids == nil {
+		s.ids = make(map[string]bool)
+	}
+	s.ids[id] = true
+}
+
+// has reports whether id is in the set
+func (s *checkSet) has(id string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.ids[id]
+}
+
+// runCache remembers on-demand runs by check and trigger. The zero value is ready to use.
+type runCache struct {
+	mu   sync.Mutex
+	runs map[string]*triggeredRun
+}
+
+// triggeredRun is an on-demand run for one check and trigger. Its result and
+// err are set before done is closed.
+type triggeredRun struct {
+	done   chan struct{}
+	result *CheckResult
+	err    error
+}
+
+// runCheckForTrigger runs a check on demand once per trigger value. Later
+// calls with the same check and trigger, as when a data source is read both
+// while planning and applying in one process, return the first run's result,
+// waiting for it if it is still going. The lock only guards the map, so runs
+// for other checks and triggers go ahead meanwhile. A failed run is forgotten
+// so the next call tries again.
+func (c *cloudCanaryClient) runCheckForTrigger(ctx context.Context, id, trigger string) (*CheckResult, error) {
+	key := id + "\x00" + trigger
+
+	c.triggeredRuns.mu.Lock()
+	run, ok := c.triggeredRuns.runs[key]
+	if !ok {
+		run = &triggeredRun{done: make(chan struct{})}
+		if c.triggeredRuns.runs == nil {
+			c.triggeredRuns.runs = make(map[string]*triggeredRun)
+		}
+		c.triggeredRuns.runs[key] = run
+	}
+	c.triggeredRuns.mu.Unlock()
+
+	if ok {
+		select {
+		case <-run.done:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		if run.err != nil {
+			return nil, run.err
+		}
+		tflog.Debug(ctx, "Reusing on-demand run for trigger", map[string]any{
+			"check_id": id,
+			"trigger":  trigger,
+		})
+		return run.result, nil
+	}
+
+	run.result, run.err = c.runCheckNow(ctx, id)
+	if run.err != nil {
+		c.triggeredRuns.mu.Lock()
+		delete(c.triggeredRuns.runs, key)
+		c.triggeredRuns.mu.Unlock()
+	}
+	close(run.done)
+	return run.result, run.err
+}
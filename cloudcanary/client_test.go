@@ -0,0 +1,373 @@
+package cloudcanary
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// newTestClient returns a client configured like the provider's, for the mock API
+func newTestClient() *cloudCanaryClient {
+	return &cloudCanaryClient{
+		apiKey:  "test-api-key",
+		baseURL: "https://api.cloudcanary.io/v1",
+		breaker: newCircuitBreaker(5, time.Second),
+	}
+}
+
+// TestClientConcurrentUse shares one client between goroutines the way
+// resources and data sources share the provider's, touching every cache the
+// client keeps. Run with -race to catch unsynchronized access.
+func TestClientConcurrentUse(t *testing.T) {
+	ctx := context.Background()
+	c := newTestClient()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/token" {
+			fmt.Fprint(w, `{"access_token":"concurrent-token"}`)
+			return
+		}
+		fmt.Fprint(w, `{"status":"up"}`)
+	}))
+	defer server.Close()
+
+	host, port := serveTCP(t, func(conn net.Conn) {
+		r := bufio.NewReader(conn)
+		for {
+			if _, err := r.ReadString('\n'); err != nil {
+				return
+			}
+			conn.Write([]byte("+PONG\r\n"))
+		}
+	})
+
+	labels := types.MapValueMust(types.StringType, map[string]attr.Value{"team": types.StringValue("sre")})
+	httpCheck := HTTPCheck{
+		Name:                   types.StringValue("concurrent-http"),
+		URL:                    types.StringValue(server.URL),
+		AssertConnectionReused: types.BoolValue(true),
+		ResultLabels:           labels,
+	}
+	apiCheck := APICheck{
+		Name:                 types.StringValue("concurrent-api"),
+		Endpoint:             types.StringValue(server.URL + "/health"),
+		AuthType:             types.StringValue("token_refresh"),
+		TokenRefreshURL:      types.StringValue(server.URL + "/token"),
+		TokenRefreshJSONPath: types.StringValue("$.access_token"),
+	}
+	tcpCheck := TCPCheck{
+		Name:            types.StringValue("concurrent-tcp"),
+		Host:            types.StringValue(host),
+		Port:            types.Int64Value(port),
+		SendPayload:     types.StringValue("PING\r\n"),
+		ExpectedPayload: types.StringValue("PONG"),
+		SendKeepalive:   types.BoolValue(true),
+	}
+	if err := c.createHTTPCheck(ctx, &httpCheck); err != nil {
+		t.Fatalf("createHTTPCheck: %s", err)
+	}
+	if err := c.createAPICheck(ctx, &apiCheck); err != nil {
+		t.Fatalf("createAPICheck: %s", err)
+	}
+	if err := c.createTCPCheck(ctx, &tcpCheck); err != nil {
+		t.Fatalf("createTCPCheck: %s", err)
+	}
+	ids := []string{httpCheck.ID.ValueString(), apiCheck.ID.ValueString(), tcpCheck.ID.ValueString()}
+
+	const workers = 16
+	errs := make(chan error, workers*(4*len(ids)+3))
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+
+			if _, err := c.getAPIInfo(ctx); err != nil {
+				errs <- fmt.Errorf("getAPIInfo: %w", err)
+			}
+
+			// Each worker probes with its own copy of the checks, as
+			// resources do with their state
+			httpCheck, apiCheck, tcpCheck := httpCheck, apiCheck, tcpCheck
+			for _, result := range []CheckResult{
+				c.probeHTTPCheck(ctx, &httpCheck),
+				c.probeAPICheck(ctx, &apiCheck),
+				c.probeTCPCheck(ctx, &tcpCheck),
+			} {
+				if result.Status.ValueString() == "FAILURE" {
+					errs <- fmt.Errorf("probe of %s failed: %s", result.CheckID.ValueString(), result.FailureReason.ValueString())
+				}
+			}
+
+			for _, id := range ids {
+				if _, err := c.runCheckNow(ctx, id); err != nil {
+					errs <- fmt.Errorf("runCheckNow(%s): %w", id, err)
+				}
+				if _, err := c.runCheckForTrigger(ctx, id, fmt.Sprint(w%4)); err != nil {
+					errs <- fmt.Errorf("runCheckForTrigger(%s): %w", id, err)
+				}
+				if _, err := c.getCheckResults(ctx, id, 5, 1); err != nil {
+					errs <- fmt.Errorf("getCheckResults(%s): %w", id, err)
+				}
+			}
+
+			// Checks come and go while the others are in use
+			scratch := HTTPCheck{Name: types.StringValue(fmt.Sprintf("scratch-%d", w)), URL: types.StringValue(server.URL)}
+			if err := c.createHTTPCheck(ctx, &scratch); err != nil {
+				errs <- fmt.Errorf("createHTTPCheck: %w", err)
+				return
+			}
+			if err := c.deleteHTTPCheck(ctx, scratch.ID.ValueString()); err != nil {
+				errs <- fmt.Errorf("deleteHTTPCheck: %w", err)
+			}
+		}(w)
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Error(err)
+	}
+	if got := c.resultLabels.get(httpCheck.ID.ValueString()); !got.Equal(labels) {
+		t.Errorf("result labels = %s, want %s", got, labels)
+	}
+}
+
+func TestRunCheckForTriggerDoesNotBlockOtherRuns(t *testing.T) {
+	ctx := context.Background()
+	c := newTestClient()
+
+	// A run for hc-slow and trigger v1 is still going
+	slow := &triggeredRun{done: make(chan struct{})}
+	c.triggeredRuns.runs = map[string]*triggeredRun{"hc-slow\x00v1": slow}
+
+	runCtx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+	if _, err := c.runCheckForTrigger(runCtx, "hc-fast", "v1"); err != nil {
+		t.Errorf("run for another check: %s", err)
+	}
+	if _, err := c.runCheckForTrigger(runCtx, "hc-slow", "v2"); err != nil {
+		t.Errorf("run for another trigger: %s", err)
+	}
+
+	// The same check and trigger wait for the run in progress
+	waitCtx, cancelWait := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancelWait()
+	if _, err := c.runCheckForTrigger(waitCtx, "hc-slow", "v1"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("run for the same trigger error = %v, want it to wait for the run in progress", err)
+	}
+
+	want := &CheckResult{CheckID: types.StringValue("hc-slow")}
+	slow.result = want
+	close(slow.done)
+	if got, err := c.runCheckForTrigger(ctx, "hc-slow", "v1"); err != nil || got != want {
+		t.Errorf("run for the same trigger = %v, %v, want the finished run's result", got, err)
+	}
+
+	// A failed run isn't kept, so the next call tries again
+	if _, err := c.runCheckForTrigger(ctx, "", "v1"); err == nil {
+		t.Fatal("run with no check ID succeeded")
+	}
+	if _, ok := c.triggeredRuns.runs["\x00v1"]; ok {
+		t.Error("failed run kept")
+	}
+}
+
+// recordingAPI serves the API from an httptest server, recording every request
+func recordingAPI(t *testing.T, handler http.HandlerFunc) (*cloudCanaryClient, *[]*http.Request) {
+	t.Helper()
+	var mu sync.Mutex
+	var requests []*http.Request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests = append(requests, r)
+		mu.Unlock()
+		if handler != nil {
+			handler(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	c := newTestClient()
+	c.baseURL = server.URL + "/v1"
+	c.httpClient = server.Client()
+	return c, &requests
+}
+
+func TestAPICallsScopedToOrganization(t *testing.T) {
+	tests := []struct {
+		name           string
+		organizationID string
+	}{
+		{name: "with organization", organizationID: "org-1234"},
+		{name: "without organization", organizationID: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			c, requests := recordingAPI(t, nil)
+			c.organizationID = tt.organizationID
+
+			calls := []struct{ method, path string }{
+				{http.MethodGet, "/auth"},
+				{http.MethodPost, "/checks/http"},
+				{http.MethodDelete, "checks/http/hc-1234"},
+			}
+			for _, call := range calls {
+				if err := c.send(ctx, call.method, call.path); err != nil {
+					t.Fatalf("send %s %s: %s", call.method, call.path, err)
+				}
+			}
+
+			want := []string{
+				"GET /v1/auth",
+				"POST /v1/checks/http",
+				"DELETE /v1/checks/http/hc-1234",
+			}
+			if len(*requests) != len(want) {
+				t.Fatalf("got %d API calls, want %d", len(*requests), len(want))
+			}
+			for i, r := range *requests {
+				if got := r.Method + " " + r.URL.Path; got != want[i] {
+					t.Errorf("call %d = %s, want %s", i, got, want[i])
+				}
+				if got := r.Header.Get(organizationHeader); got != tt.organizationID {
+					t.Errorf("%s %s: %s = %q, want %q", r.Method, r.URL.Path, organizationHeader, got, tt.organizationID)
+				}
+				if got := r.Header.Get("Authorization"); got != "Bearer test-api-key" {
+					t.Errorf("%s %s: Authorization = %q", r.Method, r.URL.Path, got)
+				}
+			}
+		})
+	}
+}
+
+// timeoutError is a net.Error that reports a timeout, as dial and read deadlines do
+type timeoutError struct{ timeout bool }
+
+func (e timeoutError) Error() string   { return "i/o timeout" }
+func (e timeoutError) Timeout() bool   { return e.timeout }
+func (e timeoutError) Temporary() bool { return e.timeout }
+
+func TestIsRetryable(t *testing.T) {
+	reset := &url.Error{Op: "Get", URL: "https://api.cloudcanary.io/v1/checks", Err: &net.OpError{Op: "read", Net: "tcp", Err: os.NewSyscallError("read", syscall.ECONNRESET)}}
+
+	tests := []struct {
+		name   string
+		err    error
+		status int
+		want   bool
+	}{
+		{name: "connection reset", err: reset, want: true},
+		{name: "deadline exceeded", err: context.DeadlineExceeded, want: true},
+		{name: "wrapped deadline exceeded", err: fmt.Errorf("calling API: %w", context.DeadlineExceeded), want: true},
+		{name: "network timeout", err: &url.Error{Op: "Get", URL: "https://api.cloudcanary.io/v1", Err: timeoutError{timeout: true}}, want: true},
+		{name: "network error without timeout", err: timeoutError{timeout: false}, want: false},
+		{name: "canceled", err: context.Canceled, want: false},
+		{name: "connection refused", err: &net.OpError{Op: "dial", Net: "tcp", Err: os.NewSyscallError("connect", syscall.ECONNREFUSED)}, want: false},
+		{name: "other error", err: errors.New("malformed URL"), want: false},
+		{name: "200", status: http.StatusOK, want: false},
+		{name: "400", status: http.StatusBadRequest, want: false},
+		{name: "401", status: http.StatusUnauthorized, want: false},
+		{name: "403", status: http.StatusForbidden, want: false},
+		{name: "404", status: http.StatusNotFound, want: false},
+		{name: "409", status: http.StatusConflict, want: false},
+		{name: "422", status: http.StatusUnprocessableEntity, want: false},
+		{name: "429", status: http.StatusTooManyRequests, want: true},
+		{name: "500", status: http.StatusInternalServerError, want: true},
+		{name: "502", status: http.StatusBadGateway, want: true},
+		{name: "503", status: http.StatusServiceUnavailable, want: true},
+		{name: "504", status: http.StatusGatewayTimeout, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isRetryable(tt.err, tt.status); got != tt.want {
+				t.Errorf("isRetryable(%v, %d) = %t, want %t", tt.err, tt.status, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSendRetries(t *testing.T) {
+	tests := []struct {
+		name      string
+		statuses  []int
+		wantCalls int
+		wantErr   bool
+	}{
+		{name: "success", statuses: []int{200}, wantCalls: 1},
+		{name: "503 then success", statuses: []int{503, 200}, wantCalls: 2},
+		{name: "429 twice then success", statuses: []int{429, 429, 200}, wantCalls: 3},
+		{name: "500 every time", statuses: []int{500, 500, 500, 500}, wantCalls: maxAPICallAttempts, wantErr: true},
+		{name: "404 is not retried", statuses: []int{404, 200}, wantCalls: 1, wantErr: true},
+		{name: "401 is not retried", statuses: []int{401, 200}, wantCalls: 1, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			c, _ := recordingAPI(t, func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.statuses[calls])
+				calls++
+			})
+
+			err := c.send(context.Background(), http.MethodGet, "/checks")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("send error = %v, want error: %t", err, tt.wantErr)
+			}
+			if calls != tt.wantCalls {
+				t.Errorf("API called %d times, want %d", calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestSendRetriesTimeout(t *testing.T) {
+	// The first handler is still sleeping when the retry arrives
+	var calls int32
+	c, _ := recordingAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			time.Sleep(200 * time.Millisecond)
+		}
+	})
+	c.httpClient.Timeout = 50 * time.Millisecond
+
+	if err := c.send(context.Background(), http.MethodGet, "/checks"); err != nil {
+		t.Fatalf("send: %s", err)
+	}
+	if got := atomic.LoadInt32(&calls); got != 2 {
+		t.Errorf("API called %d times, want 2", got)
+	}
+}
+
+func TestSendStopsRetryingWhenCanceled(t *testing.T) {
+	c, requests := recordingAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	c.retryDelay = time.Minute
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := c.send(ctx, http.MethodGet, "/checks"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("send error = %v, want the context's deadline", err)
+	}
+	if len(*requests) != 1 {
+		t.Errorf("API called %d times, want 1", len(*requests))
+	}
+}
@@ -0,0 +1,31 @@
+package cloudcanary
+
+import (
+	"reflect"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+)
+
+// httpCheckIDPattern matches the IDs generated for HTTP checks
+var httpCheckIDPattern = regexp.MustCompile(`^hc-[0-9a-f]{16}$`)
+
+// overlayConfigured copies every field of src that is set onto dst, leaving
+// dst's value wherever src is null, unknown or a nil pointer. dst and src
+// must be pointers to the same struct type.
+func overlayConfigured(dst, src any) {
+	d := reflect.ValueOf(dst).Elem()
+	s := reflect.ValueOf(src).Elem()
+
+	for i := 0; i < s.NumField(); i++ {
+		field := s.Field(i)
+		if value, ok := field.Interface().(attr.Value); ok {
+			if value.IsNull() || value.IsUnknown() {
+				continue
+			}
+		} else if field.Kind() == reflect.Pointer && field.IsNil() {
+			continue
+		}
+		d.Field(i).Set(field)
+	}
+}
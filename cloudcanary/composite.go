@@ -0,0 +1,32 @@
+package cloudcanary
+
+// compositeAggregations are the supported aggregation values of a composite check
+var compositeAggregations = []string{"all", "any", "majority"}
+
+// aggregateStatus derives a composite check's status from its members' last
+// results the way the API does: SUCCESS when all, any or a strict majority of
+// the members succeeded, FAILURE otherwise. Members that haven't produced a
+// result count as not succeeding.
+func aggregateStatus(aggregation string, memberStatuses []string) string {
+	passed := 0
+	for _, status := range memberStatuses {
+		if status == "SUCCESS" {
+			passed++
+		}
+	}
+
+	var ok bool
+	switch aggregation {
+	case "any":
+		ok = passed > 0
+	case "majority":
+		ok = passed*2 > len(memberStatuses)
+	default:
+		ok = len(memberStatuses) > 0 && passed == len(memberStatuses)
+	}
+
+	if ok {
+		return "SUCCESS"
+	}
+	return "FAILURE"
+}
@@ -0,0 +1,43 @@
+package cloudcanary
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// credentialsFile is the JSON document referenced by the credentials_file provider attribute
+type credentialsFile struct {
+	APIKey         string `json:"api_key"`
+	BaseURL        string `json:"base_url"`
+	OrganizationID string `json:"organization_id"`
+}
+
+// loadCredentialsFile reads and parses a credentials file. A leading ~/ is
+// expanded to the user's home directory.
+func loadCredentialsFile(path string) (*credentialsFile, error) {
+	if strings.HasPrefix(path, "~/") {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil, fmt.Errorf("could not expand ~ in %s: %w", path, err)
+		}
+		path = filepath.Join(home, path[2:])
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var creds credentialsFile
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&creds); err != nil {
+		return nil, fmt.Errorf("could not parse %s: %w", path, err)
+	}
+
+	return &creds, nil
+}
@@ -0,0 +1,105 @@
+package cloudcanary
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// apiInfoDataSource implements a data source describing the CloudCanary API and provider
+type apiInfoDataSource struct {
+	client *cloudCanaryClient
+}
+
+// Ensure the implementation satisfies the expected interfaces
+var _ datasource.DataSource = &apiInfoDataSource{}
+
+// NewAPIInfoDataSource creates a new API info data source
+func NewAPIInfoDataSource() datasource.DataSource {
+	return &apiInfoDataSource{}
+}
+
+// Metadata returns the data source type name
+func (d *apiInfoDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_api_info"
+}
+
+// Schema defines the schema for the data source
+func (d *apiInfoDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Retrieves version information about the CloudCanary API and this provider, for enabling features conditionally.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "Unique identifier for this data source instance.",
+			},
+			"api_version": schema.StringAttribute{
+				Computed:    true,
+				Description: "Version of the CloudCanary API.",
+			},
+			"provider_version": schema.StringAttribute{
+				Computed:    true,
+				Description: "Version of this provider (\"dev\" for local builds).",
+			},
+			"check_types": schema.ListAttribute{
+				ElementType: types.StringType,
+				Computed:    true,
+				Description: "Check types the API supports (e.g. http, api, tcp).",
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source
+func (d *apiInfoDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*cloudCanaryClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *cloudCanaryClient, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+// Read refreshes the Terraform state with the latest data
+func (d *apiInfoDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config APIInfoDataModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Call API to describe itself
+	info, err := d.client.getAPIInfo(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error retrieving API info",
+			fmt.Sprintf("Could not retrieve CloudCanary API info: %s", err),
+		)
+		return
+	}
+
+	config.ID = types.StringValue(fmt.Sprintf("api-info-%s", info.APIVersion))
+	config.APIVersion = types.StringValue(info.APIVersion)
+	config.ProviderVersion = types.StringValue(d.client.providerVersion)
+	config.CheckTypes, diags = types.ListValueFrom(ctx, types.StringType, info.CheckTypes)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set state
+	diags = resp.State.Set(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+}
@@ -0,0 +1,267 @@
+package cloudcanary
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	resourceschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// checkDriftDataSource implements a data source returning the configuration the API holds for a check
+type checkDriftDataSource struct {
+	client *cloudCanaryClient
+}
+
+// Ensure the implementation satisfies the expected interfaces
+var _ datasource.DataSource = &checkDriftDataSource{}
+
+// NewCheckDriftDataSource creates a new check drift data source
+func NewCheckDriftDataSource() datasource.DataSource {
+	return &checkDriftDataSource{}
+}
+
+// Metadata returns the data source type name
+func (d *checkDriftDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_check_drift"
+}
+
+// Schema defines the schema for the data source
+func (d *checkDriftDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Retrieves the configuration CloudCanary holds for a check, to compare against the Terraform configuration and spot changes made outside Terraform.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Required:    true,
+				Description: "The ID of the check.",
+				Validators: []validator.String{
+					validCheckID(),
+				},
+			},
+			"resource_type": schema.StringAttribute{
+				Computed:    true,
+				Description: "The resource type that manages the check, e.g. cloudcanary_http_check.",
+			},
+			"config": schema.MapAttribute{
+				ElementType: types.StringType,
+				Computed:    true,
+				Description: "The check's configurable attributes as the API holds them, keyed by attribute name. Strings are given as is and other values JSON encoded. Unset and sensitive attributes are left out.",
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source
+func (d *checkDriftDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*cloudCanaryClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *cloudCanaryClient, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+// Read refreshes the Terraform state with the latest data
+func (d *checkDriftDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config CheckDriftDataModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id := config.ID.ValueString()
+	check, err := d.client.readCheck(ctx, id)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading check",
+			fmt.Sprintf("Could not read check ID %s: %s", id, err),
+		)
+		return
+	}
+
+	config.ResourceType, config.Config, diags = checkConfigValues(ctx, id, check)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set state
+	diags = resp.State.Set(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+}
+
+// checkResource returns the resource that manages the check with the given ID,
+// along with its type name, which the ID prefix gives away
+func checkResource(id string) (resource.Resource, string) {
+	switch {
+	case strings.HasPrefix(id, "hc-"):
+		return NewHTTPCheckResource(), "cloudcanary_http_check"
+	case strings.HasPrefix(id, "ac-"):
+		return NewAPICheckResource(), "cloudcanary_api_check"
+	case strings.HasPrefix(id, "tc-"):
+		return NewTCPCheckResource(), "cloudcanary_tcp_check"
+	default:
+		return NewWebSocketCheckResource(), "cloudcanary_websocket_check"
+	}
+}
+
+// checkConfigValues returns the resource type managing a check read by
+// readCheck and its configurable attributes as a map of strings
+func checkConfigValues(ctx context.Context, id string, check any) (types.String, types.Map, diag.Diagnostics) {
+	res, resourceType := checkResource(id)
+	var schemaResp resource.SchemaResponse
+	res.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	values, diags := configuredValues(ctx, schemaResp.Schema, check)
+	if diags.HasError() {
+		return types.StringNull(), types.MapNull(types.StringType), diags
+	}
+
+	config, mapDiags := types.MapValueFrom(ctx, types.StringType, values)
+	diags.Append(mapDiags...)
+	return types.StringValue(resourceType), config, diags
+}
+
+// configuredValues returns the attributes of check, a pointer to a resource
+// model matching s, that can be set in configuration. Null, sensitive and
+// purely computed attributes are skipped, as are timeouts, which never reach
+// the API.
+func configuredValues(ctx context.Context, s resourceschema.Schema, check any) (map[string]string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	attrTypes := s.Type().(attr.TypeWithAttributeTypes).AttributeTypes()
+
+	values := make(map[string]string)
+	model := reflect.ValueOf(check).Elem()
+	for i := 0; i < model.NumField(); i++ {
+		name := model.Type().Field(i).Tag.Get("tfsdk")
+		if name == "id" || name == "timeouts" {
+			continue
+		}
+		if a, ok := s.Attributes[name]; ok && (a.IsSensitive() || (a.IsComputed() && !a.IsOptional())) {
+			continue
+		}
+
+		// Lists and maps the API leaves unset may lack an element type, so
+		// skip nulls before converting
+		field := model.Field(i)
+		if field.Kind() == reflect.Ptr && field.IsNil() {
+			continue
+		}
+		if v, ok := field.Interface().(attr.Value); ok && (v.IsNull() || v.IsUnknown()) {
+			continue
+		}
+
+		var value attr.Value
+		diags.Append(tfsdk.ValueFrom(ctx, field.Interface(), attrTypes[name], &value)...)
+		if diags.HasError() {
+			return nil, diags
+		}
+
+		if str, ok := value.(types.String); ok {
+			values[name] = str.ValueString()
+			continue
+		}
+		encoded, err := attrValueJSON(ctx, value)
+		if err != nil {
+			diags.AddError(
+				"Error encoding check configuration",
+				fmt.Sprintf("Could not encode attribute %s: %s", name, err),
+			)
+			return nil, diags
+		}
+		values[name] = encoded
+	}
+	return values, diags
+}
+
+// attrValueJSON encodes an attribute value as JSON
+func attrValueJSON(ctx context.Context, value attr.Value) (string, error) {
+	tfValue, err := value.ToTerraformValue(ctx)
+	if err != nil {
+		return "", err
+	}
+	decoded, err := terraformValueToGo(tfValue)
+	if err != nil {
+		return "", err
+	}
+	encoded, err := json.Marshal(decoded)
+	if err != nil {
+		return "", err
+	}
+	return string(encoded), nil
+}
+
+// terraformValueToGo converts a Terraform value into the Go value
+// encoding/json renders the same way, with nil for null values
+func terraformValueToGo(v tftypes.Value) (any, error) {
+	if v.IsNull() || !v.IsKnown() {
+		return nil, nil
+	}
+
+	switch {
+	case v.Type().Is(tftypes.String):
+		var s string
+		err := v.As(&s)
+		return s, err
+	case v.Type().Is(tftypes.Bool):
+		var b bool
+		err := v.As(&b)
+		return b, err
+	case v.Type().Is(tftypes.Number):
+		var n big.Float
+		if err := v.As(&n); err != nil {
+			return nil, err
+		}
+		return json.Number(n.Text('g', -1)), nil
+	case v.Type().Is(tftypes.List{}), v.Type().Is(tftypes.Set{}), v.Type().Is(tftypes.Tuple{}):
+		var elems []tftypes.Value
+		if err := v.As(&elems); err != nil {
+			return nil, err
+		}
+		out := make([]any, 0, len(elems))
+		for _, elem := range elems {
+			decoded, err := terraformValueToGo(elem)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, decoded)
+		}
+		return out, nil
+	case v.Type().Is(tftypes.Map{}), v.Type().Is(tftypes.Object{}):
+		var elems map[string]tftypes.Value
+		if err := v.As(&elems); err != nil {
+			return nil, err
+		}
+		out := make(map[string]any, len(elems))
+		for key, elem := range elems {
+			decoded, err := terraformValueToGo(elem)
+			if err != nil {
+				return nil, err
+			}
+			out[key] = decoded
+		}
+		return out, nil
+	default:
+		return nil, fmt.Errorf("unsupported type %s", v.Type())
+	}
+}
@@ -0,0 +1,137 @@
+package cloudcanary
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// checkHistoryDataSource implements a data source returning a check's configuration at a point in time
+type checkHistoryDataSource struct {
+	client *cloudCanaryClient
+}
+
+// Ensure the implementation satisfies the expected interfaces
+var _ datasource.DataSource = &checkHistoryDataSource{}
+
+// NewCheckHistoryDataSource creates a new check history data source
+func NewCheckHistoryDataSource() datasource.DataSource {
+	return &checkHistoryDataSource{}
+}
+
+// Metadata returns the data source type name
+func (d *checkHistoryDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_check_history"
+}
+
+// Schema defines the schema for the data source
+func (d *checkHistoryDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Retrieves the configuration a check had at a point in time, such as the start of an incident.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Required:    true,
+				Description: "The ID of the check.",
+				Validators: []validator.String{
+					validCheckID(),
+				},
+			},
+			"at": schema.StringAttribute{
+				Required:    true,
+				Description: "The point in time (RFC3339 format) to return the configuration for.",
+				Validators: []validator.String{
+					validTimestamp(),
+				},
+			},
+			"recorded_at": schema.StringAttribute{
+				Computed:    true,
+				Description: "When the returned configuration was recorded: the last change to the check at or before at.",
+			},
+			"resource_type": schema.StringAttribute{
+				Computed:    true,
+				Description: "The resource type that manages the check, e.g. cloudcanary_http_check.",
+			},
+			"config": schema.MapAttribute{
+				ElementType: types.StringType,
+				Computed:    true,
+				Description: "The check's configurable attributes as they were at the time, keyed by attribute name. Strings are given as is and other values JSON encoded. Unset and sensitive attributes are left out.",
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source
+func (d *checkHistoryDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*cloudCanaryClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *cloudCanaryClient, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+// Read refreshes the Terraform state with the latest data
+func (d *checkHistoryDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config CheckHistoryDataModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The validator has already checked the format
+	id := config.ID.ValueString()
+	at, err := time.Parse(time.RFC3339, config.At.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("at"),
+			"Invalid Timestamp",
+			fmt.Sprintf("Could not parse at: %s", err),
+		)
+		return
+	}
+
+	snapshot, err := d.client.getCheckConfigAt(ctx, id, at)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading check history",
+			fmt.Sprintf("Could not read the configuration of check ID %s at %s: %s", id, config.At.ValueString(), err),
+		)
+		return
+	}
+
+	// Returning an empty config would read as a check with nothing set
+	if snapshot == nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("at"),
+			"No Configuration Recorded",
+			fmt.Sprintf("Check ID %s has no configuration recorded at or before %s. The check may not have existed yet.", id, config.At.ValueString()),
+		)
+		return
+	}
+
+	config.RecordedAt = types.StringValue(snapshot.recordedAt.Format(time.RFC3339))
+	config.ResourceType, config.Config, diags = checkConfigValues(ctx, id, snapshot.check)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set state
+	diags = resp.State.Set(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+}
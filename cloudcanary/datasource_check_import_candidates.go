@@ -0,0 +1,144 @@
+package cloudcanary
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// checkResourceTypes maps the check types reported by listChecks to the resource that manages them
+var checkResourceTypes = map[string]string{
+	"http":      "cloudcanary_http_check",
+	"api":       "cloudcanary_api_check",
+	"tcp":       "cloudcanary_tcp_check",
+	"websocket": "cloudcanary_websocket_check",
+}
+
+// checkImportCandidatesDataSource implements a data source listing checks that aren't managed by Terraform yet
+type checkImportCandidatesDataSource struct {
+	client *cloudCanaryClient
+}
+
+// Ensure the implementation satisfies the expected interfaces
+var _ datasource.DataSource = &checkImportCandidatesDataSource{}
+
+// NewCheckImportCandidatesDataSource creates a new check import candidates data source
+func NewCheckImportCandidatesDataSource() datasource.DataSource {
+	return &checkImportCandidatesDataSource{}
+}
+
+// Metadata returns the data source type name
+func (d *checkImportCandidatesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_check_import_candidates"
+}
+
+// Schema defines the schema for the data source
+func (d *checkImportCandidatesDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the checks in the account that aren't managed by this configuration yet, with the resource type to import each one as.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "Unique identifier for this data source instance.",
+			},
+			"managed_ids": schema.ListAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "IDs of the checks already in state, which are left out of the candidates. Data sources can't read state, so pass the IDs of your check resources here.",
+				Validators: []validator.List{
+					listvalidator.ValueStringsAre(validCheckID()),
+				},
+			},
+			"candidates": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "The checks that could be imported.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:    true,
+							Description: "The ID of the check, to use as the import ID.",
+						},
+						"name": schema.StringAttribute{
+							Computed:    true,
+							Description: "The name of the check.",
+						},
+						"resource_type": schema.StringAttribute{
+							Computed:    true,
+							Description: "The resource type to import the check as, e.g. cloudcanary_http_check.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source
+func (d *checkImportCandidatesDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*cloudCanaryClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *cloudCanaryClient, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+// Read refreshes the Terraform state with the latest data
+func (d *checkImportCandidatesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config CheckImportCandidatesDataModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Call API to list every check
+	checks, err := d.client.listChecks(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error listing checks",
+			fmt.Sprintf("Could not list checks: %s", err),
+		)
+		return
+	}
+
+	managed := make(map[string]bool)
+	for _, id := range listStrings(config.ManagedIDs) {
+		managed[id] = true
+	}
+
+	candidates := make([]CheckImportCandidate, 0, len(checks))
+	for _, check := range checks {
+		resourceType, ok := checkResourceTypes[check.Type]
+		if managed[check.ID] || !ok {
+			continue
+		}
+		candidates = append(candidates, CheckImportCandidate{
+			ID:           types.StringValue(check.ID),
+			Name:         types.StringValue(check.Name),
+			ResourceType: types.StringValue(resourceType),
+		})
+	}
+
+	// Generate a unique ID for this data source instance
+	config.ID = types.StringValue(fmt.Sprintf("import-candidates-%d", time.Now().Unix()))
+	config.Candidates = candidates
+
+	// Set state
+	diags = resp.State.Set(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+}
@@ -2,11 +2,17 @@ package cloudcanary
 
 import (
 	"context"
+	"encoding/csv"
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -43,7 +49,14 @@ func (d *checkResultsDataSource) Schema(_ context.Context, _ datasource.SchemaRe
 			},
 			"limit": schema.Int64Attribute{
 				Optional:    true,
-				Description: "Maximum number of results to return.",
+				Description: "Maximum number of results to return. Values above the provider's max_allowed_limit are clamped to it.",
+			},
+			"sample_rate": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Return only one of every N results, e.g. 6 for one result per six runs. Useful for plotting high-frequency checks without pulling every result. Defaults to 1.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
 			},
 			"start_time": schema.StringAttribute{
 				Optional:    true,
@@ -57,50 +70,17 @@ func (d *checkResultsDataSource) Schema(_ context.Context, _ datasource.SchemaRe
 				Computed:    true,
 				Description: "The check results.",
 				NestedObject: schema.NestedAttributeObject{
-					Attributes: map[string]schema.Attribute{
-						"id": schema.StringAttribute{
-							Computed:    true,
-							Description: "Unique identifier for this result.",
-						},
-						"check_id": schema.StringAttribute{
-							Computed:    true,
-							Description: "The ID of the check this result belongs to.",
-						},
-						"status": schema.StringAttribute{
-							Computed:    true,
-							Description: "The status of the check (SUCCESS, FAILURE).",
-						},
-						"response_time": schema.Int64Attribute{
-							Computed:    true,
-							Description: "Response time in milliseconds.",
-						},
-						"message": schema.StringAttribute{
-							Computed:    true,
-							Description: "Message associated with the result.",
-						},
-						"timestamp": schema.StringAttribute{
-							Computed:    true,
-							Description: "When the check was executed.",
-						},
-						"region": schema.StringAttribute{
-							Computed:    true,
-							Description: "Region where the check was executed.",
-						},
-						"response_body": schema.StringAttribute{
-							Computed:    true,
-							Description: "Response body (if available).",
-						},
-						"response_code": schema.Int64Attribute{
-							Computed:    true,
-							Description: "HTTP response code.",
-						},
-						"failure_reason": schema.StringAttribute{
-							Computed:    true,
-							Description: "Reason for failure (if failed).",
-						},
-					},
+					Attributes: checkResultAttributes(),
 				},
 			},
+			"render_csv": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Whether to also render the results as CSV in the csv attribute, e.g. for writing out with local_file. Defaults to false.",
+			},
+			"csv": schema.StringAttribute{
+				Computed:    true,
+				Description: "The results as CSV with a header row, one row per result. Null unless render_csv is true.",
+			},
 		},
 	}
 }
@@ -138,8 +118,34 @@ func (d *checkResultsDataSource) Read(ctx context.Context, req datasource.ReadRe
 		limit = int(config.Limit.ValueInt64())
 	}
 
+	// Refuse enormous fetches; warn rather than fail so existing configurations keep working
+	if maxLimit := d.client.maxAllowedLimit; maxLimit > 0 && limit > maxLimit {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("limit"),
+			"Limit Exceeds Maximum",
+			fmt.Sprintf("The limit of %d exceeds the provider's max_allowed_limit of %d, so only %d results will be fetched. "+
+				"Narrow the query with start_time and end_time, or raise max_allowed_limit in the provider configuration.", limit, maxLimit, maxLimit),
+		)
+		limit = maxLimit
+	}
+
+	// getCheckResults doesn't page yet, so only the first page comes back
+	if limit > resultsPageSize {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("limit"),
+			"Limit Exceeds Page Size",
+			fmt.Sprintf("The limit of %d exceeds the %d results the API returns per request, and results are not paginated yet, so at most %d results will be returned. "+
+				"Narrow the query with start_time and end_time to see older results.", limit, resultsPageSize, resultsPageSize),
+		)
+	}
+
+	sampleRate := 1
+	if !config.SampleRate.IsNull() {
+		sampleRate = int(config.SampleRate.ValueInt64())
+	}
+
 	// Call API to get check results
-	results, err := d.client.getCheckResults(ctx, config.CheckID.ValueString(), limit)
+	results, err := d.client.getCheckResults(ctx, config.CheckID.ValueString(), limit, sampleRate)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error retrieving check results",
@@ -154,7 +160,174 @@ func (d *checkResultsDataSource) Read(ctx context.Context, req datasource.ReadRe
 	// Set the results
 	config.Results = results
 
+	// Only pay for rendering when asked to
+	config.CSV = types.StringNull()
+	if config.RenderCSV.ValueBool() {
+		rendered, err := renderResultsCSV(results)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error rendering check results",
+				fmt.Sprintf("Could not render results for check ID %s as CSV: %s", config.CheckID.ValueString(), err),
+			)
+			return
+		}
+		config.CSV = types.StringValue(rendered)
+	}
+
 	// Set state
 	diags = resp.State.Set(ctx, &config)
 	resp.Diagnostics.Append(diags...)
-}
\ No newline at end of file
+}
+
+// checkResultAttributes returns the schema of a single check result, shared by the result data sources
+func checkResultAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"id": schema.StringAttribute{
+			Computed:    true,
+			Description: "Unique identifier for this result.",
+		},
+		"check_id": schema.StringAttribute{
+			Computed:    true,
+			Description: "The ID of the check this result belongs to.",
+		},
+		"status": schema.StringAttribute{
+			Computed:    true,
+			Description: "The status of the check (SUCCESS, FAILURE).",
+		},
+		"response_time": schema.Int64Attribute{
+			Computed:    true,
+			Description: "Response time in milliseconds.",
+		},
+		"message": schema.StringAttribute{
+			Computed:    true,
+			Description: "Message associated with the result.",
+		},
+		"timestamp": schema.StringAttribute{
+			Computed:    true,
+			Description: "When the check was executed.",
+		},
+		"region": schema.StringAttribute{
+			Computed:    true,
+			Description: "Region where the check was executed.",
+		},
+		"response_body": schema.StringAttribute{
+			Computed:    true,
+			Description: "Response body (if available).",
+		},
+		"response_code": schema.Int64Attribute{
+			Computed:    true,
+			Description: "HTTP response code.",
+		},
+		"not_modified": schema.BoolAttribute{
+			Computed:    true,
+			Description: "Whether the response was a 304 Not Modified (if available).",
+		},
+		"response_size": schema.Int64Attribute{
+			Computed:    true,
+			Description: "Response body size in bytes (if available).",
+		},
+		"body_sha256": schema.StringAttribute{
+			Computed:    true,
+			Description: "SHA-256 of the whole response body, hex encoded (if available).",
+		},
+		"dns_time": schema.Int64Attribute{
+			Computed:    true,
+			Description: "Time spent resolving DNS in milliseconds (if available).",
+		},
+		"connect_time": schema.Int64Attribute{
+			Computed:    true,
+			Description: "Time spent establishing the TCP connection in milliseconds (if available).",
+		},
+		"tls_time": schema.Int64Attribute{
+			Computed:    true,
+			Description: "Time spent on the TLS handshake in milliseconds (if available).",
+		},
+		"ttfb": schema.Int64Attribute{
+			Computed:    true,
+			Description: "Time from the start of the request to the first response byte in milliseconds (if available).",
+		},
+		"download_time": schema.Int64Attribute{
+			Computed:    true,
+			Description: "Time spent reading the response body in milliseconds (if available).",
+		},
+		"failure_reason": schema.StringAttribute{
+			Computed:    true,
+			Description: "Reason for failure (if failed).",
+		},
+		"labels": schema.MapAttribute{
+			ElementType: types.StringType,
+			Computed:    true,
+			Description: "The result_labels of the check, for grouping results by team, service and the like (if set).",
+		},
+		"url_results": schema.ListNestedAttribute{
+			Computed:    true,
+			Description: "Outcome for each URL of an HTTP check with additional_urls, in the order configured (if available).",
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: map[string]schema.Attribute{
+					"url": schema.StringAttribute{
+						Computed:    true,
+						Description: "The URL probed.",
+					},
+					"status": schema.StringAttribute{
+						Computed:    true,
+						Description: "The status for this URL (SUCCESS, DEGRADED, FAILURE).",
+					},
+					"response_code": schema.Int64Attribute{
+						Computed:    true,
+						Description: "HTTP response code (if a response was received).",
+					},
+					"response_time": schema.Int64Attribute{
+						Computed:    true,
+						Description: "Response time in milliseconds.",
+					},
+					"failure_reason": schema.StringAttribute{
+						Computed:    true,
+						Description: "Reason for failure (if failed).",
+					},
+				},
+			},
+		},
+	}
+}
+
+// resultsCSVHeader names the columns written by renderResultsCSV
+var resultsCSVHeader = []string{"id", "check_id", "timestamp", "status", "response_time", "response_code", "response_size", "region", "message", "failure_reason"}
+
+// renderResultsCSV renders results as CSV with a header row. Fields containing
+// commas, quotes or newlines are quoted, and null values are left empty.
+func renderResultsCSV(results []CheckResult) (string, error) {
+	var b strings.Builder
+	w := csv.NewWriter(&b)
+	if err := w.Write(resultsCSVHeader); err != nil {
+		return "", err
+	}
+
+	for _, result := range results {
+		record := []string{
+			result.ID.ValueString(),
+			result.CheckID.ValueString(),
+			result.Timestamp.ValueString(),
+			result.Status.ValueString(),
+			csvInt64(result.ResponseTime),
+			csvInt64(result.ResponseCode),
+			csvInt64(result.ResponseSize),
+			result.Region.ValueString(),
+			result.Message.ValueString(),
+			result.FailureReason.ValueString(),
+		}
+		if err := w.Write(record); err != nil {
+			return "", err
+		}
+	}
+
+	w.Flush()
+	return b.String(), w.Error()
+}
+
+// csvInt64 formats an integer CSV field, leaving it empty when the value is null
+func csvInt64(v types.Int64) string {
+	if v.IsNull() || v.IsUnknown() {
+		return ""
+	}
+	return strconv.FormatInt(v.ValueInt64(), 10)
+}
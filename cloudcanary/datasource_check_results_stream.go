@@ -0,0 +1,117 @@
+package cloudcanary
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// checkResultsStreamDataSource implements a CloudCanary data source that returns
+// only the results recorded since a cursor
+type checkResultsStreamDataSource struct {
+	client *cloudCanaryClient
+}
+
+// Ensure the implementation satisfies the expected interfaces
+var _ datasource.DataSource = &checkResultsStreamDataSource{}
+
+// NewCheckResultsStreamDataSource creates a new check results stream data source
+func NewCheckResultsStreamDataSource() datasource.DataSource {
+	return &checkResultsStreamDataSource{}
+}
+
+// Metadata returns the data source type name
+func (d *checkResultsStreamDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_check_results_stream"
+}
+
+// Schema defines the schema for the data source
+func (d *checkResultsStreamDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Retrieves the results for a specific check recorded since a cursor, for incremental polling.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "Unique identifier for this data source instance.",
+			},
+			"check_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The ID of the check to retrieve results for.",
+				Validators: []validator.String{
+					validCheckID(),
+				},
+			},
+			"since": schema.StringAttribute{
+				Optional:    true,
+				Description: "Cursor from a previous next_cursor. Only results newer than the cursor are returned. Omit to start from the most recent results.",
+			},
+			"next_cursor": schema.StringAttribute{
+				Computed:    true,
+				Description: "Cursor to pass as since on the next poll. Unchanged from since when there are no new results.",
+			},
+			"results": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "The check results newer than the cursor, newest first.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: checkResultAttributes(),
+				},
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source
+func (d *checkResultsStreamDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*cloudCanaryClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *cloudCanaryClient, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+// Read retrieves the results recorded since the configured cursor
+func (d *checkResultsStreamDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config CheckResultsStreamDataModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Call API to get the results since the cursor
+	results, nextCursor, err := d.client.getCheckResultsSince(ctx, config.CheckID.ValueString(), config.Since.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error retrieving check results",
+			fmt.Sprintf("Could not retrieve results for check ID %s: %s", config.CheckID.ValueString(), err),
+		)
+		return
+	}
+
+	// Generate a unique ID for this data source instance
+	config.ID = types.StringValue(fmt.Sprintf("results-stream-%s-%d", config.CheckID.ValueString(), time.Now().Unix()))
+
+	config.Results = results
+	config.NextCursor = types.StringNull()
+	if nextCursor != "" {
+		config.NextCursor = types.StringValue(nextCursor)
+	}
+
+	// Set state
+	diags = resp.State.Set(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+}
@@ -0,0 +1,214 @@
+package cloudcanary
+
+import (
+	"context"
+	"encoding/csv"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestGetCheckResultsDoesNotPad(t *testing.T) {
+	tests := []struct {
+		name       string
+		limit      int
+		sampleRate int
+		want       int
+	}{
+		{name: "one", limit: 1, sampleRate: 1, want: 1},
+		{name: "default", limit: 10, sampleRate: 1, want: 10},
+		{name: "sampled", limit: 10, sampleRate: 5, want: 10},
+		{name: "one page", limit: resultsPageSize, sampleRate: 1, want: resultsPageSize},
+		{name: "just past one page", limit: resultsPageSize + 1, sampleRate: 1, want: resultsPageSize},
+		{name: "several pages", limit: 4 * resultsPageSize, sampleRate: 1, want: resultsPageSize},
+		{name: "zero", limit: 0, sampleRate: 1, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := newTestClient().getCheckResults(context.Background(), "hc-test", tt.limit, tt.sampleRate)
+			if err != nil {
+				t.Fatalf("getCheckResults: %s", err)
+			}
+			if len(results) != tt.want {
+				t.Fatalf("got %d results, want %d", len(results), tt.want)
+			}
+
+			// Padding would show up as repeated results
+			seen := make(map[string]bool, len(results))
+			for _, result := range results {
+				id := result.ID.ValueString()
+				if seen[id] {
+					t.Fatalf("result %s returned twice", id)
+				}
+				seen[id] = true
+			}
+		})
+	}
+}
+
+// readCheckResults reads the cloudcanary_check_results data source with the given configuration
+// resultBodies counts the results that carry a response body
+func resultBodies(results []CheckResult) int {
+	bodies := 0
+	for _, result := range results {
+		if !result.ResponseBody.IsNull() {
+			bodies++
+		}
+	}
+	return bodies
+}
+
+func TestGetCheckResultsStoreResponseBody(t *testing.T) {
+	tests := []struct {
+		name              string
+		storeResponseBody types.Bool
+		wantBody          bool
+	}{
+		{name: "unset", storeResponseBody: types.BoolNull(), wantBody: true},
+		{name: "true", storeResponseBody: types.BoolValue(true), wantBody: true},
+		{name: "false", storeResponseBody: types.BoolValue(false), wantBody: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			c := newTestClient()
+			check := HTTPCheck{
+				Name:              types.StringValue(t.Name()),
+				URL:               types.StringValue("https://example.com"),
+				StoreResponseBody: tt.storeResponseBody,
+			}
+			if err := c.createHTTPCheck(ctx, &check); err != nil {
+				t.Fatalf("createHTTPCheck: %s", err)
+			}
+
+			results, err := c.getCheckResults(ctx, check.ID.ValueString(), 10, 1)
+			if err != nil {
+				t.Fatalf("getCheckResults: %s", err)
+			}
+			if bodies := resultBodies(results); (bodies > 0) != tt.wantBody {
+				t.Errorf("%d of %d results have a response body, want bodies: %t", bodies, len(results), tt.wantBody)
+			}
+		})
+	}
+}
+
+func TestGetCheckResultsStoreResponseBodyUpdated(t *testing.T) {
+	ctx := context.Background()
+	c := newTestClient()
+	check := HTTPCheck{
+		Name: types.StringValue(t.Name()),
+		URL:  types.StringValue("https://example.com"),
+	}
+	if err := c.createHTTPCheck(ctx, &check); err != nil {
+		t.Fatalf("createHTTPCheck: %s", err)
+	}
+
+	for _, step := range []struct {
+		storeResponseBody types.Bool
+		wantBody          bool
+	}{
+		{storeResponseBody: types.BoolValue(false), wantBody: false},
+		{storeResponseBody: types.BoolNull(), wantBody: true},
+	} {
+		check.StoreResponseBody = step.storeResponseBody
+		if err := c.updateHTTPCheck(ctx, &check); err != nil {
+			t.Fatalf("updateHTTPCheck: %s", err)
+		}
+
+		results, err := c.getCheckResults(ctx, check.ID.ValueString(), 10, 1)
+		if err != nil {
+			t.Fatalf("getCheckResults: %s", err)
+		}
+		if bodies := resultBodies(results); (bodies > 0) != step.wantBody {
+			t.Errorf("store_response_body = %s: %d of %d results have a response body, want bodies: %t", step.storeResponseBody, bodies, len(results), step.wantBody)
+		}
+	}
+}
+
+func readCheckResults(t *testing.T, c *cloudCanaryClient, attrs map[string]tftypes.Value) (CheckResultsDataModel, datasource.ReadResponse) {
+	t.Helper()
+	ctx := context.Background()
+	d := &checkResultsDataSource{client: c}
+
+	var schemaResp datasource.SchemaResponse
+	d.Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	values := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+	for name, typ := range objectType.AttributeTypes {
+		values[name] = tftypes.NewValue(typ, nil)
+	}
+	for name, value := range attrs {
+		values[name] = value
+	}
+
+	resp := datasource.ReadResponse{State: tfsdk.State{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(objectType, nil),
+	}}
+	d.Read(ctx, datasource.ReadRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, values)}}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Read: %v", resp.Diagnostics)
+	}
+
+	var state CheckResultsDataModel
+	if diags := resp.State.Get(ctx, &state); diags.HasError() {
+		t.Fatalf("reading state: %v", diags)
+	}
+	return state, resp
+}
+
+func TestCheckResultsDataSourceReturnsOnePage(t *testing.T) {
+	state, resp := readCheckResults(t, newTestClient(), map[string]tftypes.Value{
+		"check_id":   tftypes.NewValue(tftypes.String, "hc-test"),
+		"limit":      tftypes.NewValue(tftypes.Number, 3000),
+		"render_csv": tftypes.NewValue(tftypes.Bool, true),
+	})
+
+	if len(state.Results) != resultsPageSize {
+		t.Errorf("state holds %d results, want one page of %d", len(state.Results), resultsPageSize)
+	}
+	warned := false
+	for _, d := range resp.Diagnostics.Warnings() {
+		warned = warned || d.Summary() == "Limit Exceeds Page Size"
+	}
+	if !warned {
+		t.Errorf("no page size warning in %v", resp.Diagnostics)
+	}
+
+	// The CSV holds exactly the results in state, in the same order
+	records, err := csv.NewReader(strings.NewReader(state.CSV.ValueString())).ReadAll()
+	if err != nil {
+		t.Fatalf("parsing csv: %s", err)
+	}
+	if len(records) != len(state.Results)+1 {
+		t.Fatalf("csv has %d rows, want a header and %d results", len(records), len(state.Results))
+	}
+	for i, result := range state.Results {
+		if records[i+1][0] != result.ID.ValueString() || records[i+1][3] != result.Status.ValueString() {
+			t.Fatalf("csv row %d = %v, want result %s with status %s", i+1, records[i+1], result.ID.ValueString(), result.Status.ValueString())
+		}
+	}
+}
+
+func TestCheckResultsDataSourceWithinOnePage(t *testing.T) {
+	state, resp := readCheckResults(t, newTestClient(), map[string]tftypes.Value{
+		"check_id": tftypes.NewValue(tftypes.String, "hc-test"),
+		"limit":    tftypes.NewValue(tftypes.Number, 25),
+	})
+
+	if len(state.Results) != 25 {
+		t.Errorf("state holds %d results, want 25", len(state.Results))
+	}
+	if len(resp.Diagnostics.Warnings()) != 0 {
+		t.Errorf("unexpected warnings: %v", resp.Diagnostics)
+	}
+	if !state.CSV.IsNull() {
+		t.Errorf("csv = %q without render_csv, want null", state.CSV.ValueString())
+	}
+}
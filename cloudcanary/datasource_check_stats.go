@@ -0,0 +1,201 @@
+package cloudcanary
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// checkStatsDataSource implements a CloudCanary check statistics data source
+type checkStatsDataSource struct {
+	client *cloudCanaryClient
+}
+
+// Ensure the implementation satisfies the expected interfaces
+var _ datasource.DataSource = &checkStatsDataSource{}
+
+// NewCheckStatsDataSource creates a new check statistics data source
+func NewCheckStatsDataSource() datasource.DataSource {
+	return &checkStatsDataSource{}
+}
+
+// Metadata returns the data source type name
+func (d *checkStatsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_check_stats"
+}
+
+// Schema defines the schema for the data source
+func (d *checkStatsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Retrieves uptime and response time statistics for a specific check, optionally rolled up by hour or day.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "Unique identifier for this data source instance.",
+			},
+			"check_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The ID of the check to retrieve statistics for.",
+				Validators: []validator.String{
+					validCheckID(),
+				},
+			},
+			"period_days": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Number of days, ending now, to compute statistics over. Defaults to 7.",
+				Validators: []validator.Int64{
+					int64validator.Between(1, 90),
+				},
+			},
+			"group_by": schema.StringAttribute{
+				Optional:    true,
+				Description: "How to roll results up into buckets (none, hour, day). Defaults to none.",
+				Validators: []validator.String{
+					stringvalidator.OneOf("none", "hour", "day"),
+				},
+			},
+			"slo_target": schema.Float64Attribute{
+				Optional:    true,
+				Description: "Target percentage of successful results (e.g. 99.9), below 100. Enables error_budget_burn_rate_1h and error_budget_burn_rate_6h.",
+				Validators: []validator.Float64{
+					float64validator.Between(0, 100),
+					float64validator.NoneOf(100),
+				},
+			},
+			"uptime": schema.Float64Attribute{
+				Computed:    true,
+				Description: "Percentage of successful results over the period.",
+			},
+			"avg_response_time": schema.Float64Attribute{
+				Computed:    true,
+				Description: "Average response time over the period in milliseconds.",
+			},
+			"p50_response_time": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Median response time over the period in milliseconds.",
+			},
+			"p90_response_time": schema.Int64Attribute{
+				Computed:    true,
+				Description: "90th percentile response time over the period in milliseconds.",
+			},
+			"p95_response_time": schema.Int64Attribute{
+				Computed:    true,
+				Description: "95th percentile response time over the period in milliseconds.",
+			},
+			"p99_response_time": schema.Int64Attribute{
+				Computed:    true,
+				Description: "99th percentile response time over the period in milliseconds. With fewer than 100 results this is the slowest one.",
+			},
+			"error_budget_burn_rate_1h": schema.Float64Attribute{
+				Computed:    true,
+				Description: "How many times faster than sustainable the last hour's failures spend the slo_target error budget. 1 spends exactly the budget. Null without slo_target or results in the last hour.",
+			},
+			"error_budget_burn_rate_6h": schema.Float64Attribute{
+				Computed:    true,
+				Description: "As error_budget_burn_rate_1h, over the last six hours.",
+			},
+			"buckets": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "Statistics per hour or day, oldest first. Empty when group_by is none.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"start": schema.StringAttribute{
+							Computed:    true,
+							Description: "Start of the bucket (RFC3339 format, UTC).",
+						},
+						"result_count": schema.Int64Attribute{
+							Computed:    true,
+							Description: "Number of results in the bucket.",
+						},
+						"uptime": schema.Float64Attribute{
+							Computed:    true,
+							Description: "Percentage of successful results in the bucket.",
+						},
+						"avg_response_time": schema.Float64Attribute{
+							Computed:    true,
+							Description: "Average response time in the bucket in milliseconds.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source
+func (d *checkStatsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*cloudCanaryClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *cloudCanaryClient, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+// Read refreshes the Terraform state with the latest data
+func (d *checkStatsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config CheckStatsDataModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set defaults if not provided
+	periodDays := 7
+	if !config.PeriodDays.IsNull() {
+		periodDays = int(config.PeriodDays.ValueInt64())
+	}
+	groupBy := "none"
+	if !config.GroupBy.IsNull() {
+		groupBy = config.GroupBy.ValueString()
+	}
+
+	// Call API to get check statistics
+	stats, err := d.client.getCheckStats(ctx, config.CheckID.ValueString(), periodDays, groupBy)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error retrieving check stats",
+			fmt.Sprintf("Could not retrieve stats for check ID %s: %s", config.CheckID.ValueString(), err),
+		)
+		return
+	}
+
+	// Generate a unique ID for this data source instance
+	config.ID = types.StringValue(fmt.Sprintf("stats-%s-%d", config.CheckID.ValueString(), time.Now().Unix()))
+
+	config.Uptime = stats.Uptime
+	config.AvgResponseTime = stats.AvgResponseTime
+	config.P50ResponseTime = stats.P50ResponseTime
+	config.P90ResponseTime = stats.P90ResponseTime
+	config.P95ResponseTime = stats.P95ResponseTime
+	config.P99ResponseTime = stats.P99ResponseTime
+	config.BurnRate1h = types.Float64Null()
+	config.BurnRate6h = types.Float64Null()
+	if !config.SLOTarget.IsNull() {
+		target := config.SLOTarget.ValueFloat64()
+		config.BurnRate1h = errorBudgetBurnRate(stats.ErrorRate1h, target)
+		config.BurnRate6h = errorBudgetBurnRate(stats.ErrorRate6h, target)
+	}
+	config.Buckets = stats.Buckets
+
+	// Set state
+	diags = resp.State.Set(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+}
@@ -0,0 +1,136 @@
+package cloudcanary
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// groupStatsDataSource implements a CloudCanary check group statistics data source
+type groupStatsDataSource struct {
+	client *cloudCanaryClient
+}
+
+// Ensure the implementation satisfies the expected interfaces
+var _ datasource.DataSource = &groupStatsDataSource{}
+
+// NewGroupStatsDataSource creates a new check group statistics data source
+func NewGroupStatsDataSource() datasource.DataSource {
+	return &groupStatsDataSource{}
+}
+
+// Metadata returns the data source type name
+func (d *groupStatsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_group_stats"
+}
+
+// Schema defines the schema for the data source
+func (d *groupStatsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Retrieves uptime rolled up across the checks of a check group, and the member performing worst.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "Unique identifier for this data source instance.",
+			},
+			"group_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The ID of the check group to retrieve statistics for.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"period_days": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Number of days, ending now, to compute statistics over. Defaults to 7.",
+				Validators: []validator.Int64{
+					int64validator.Between(1, 90),
+				},
+			},
+			"member_count": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Number of checks in the group.",
+			},
+			"uptime": schema.Float64Attribute{
+				Computed:    true,
+				Description: "Average uptime percentage of the group's checks over the period. Null when the group is empty.",
+			},
+			"worst_check_id": schema.StringAttribute{
+				Computed:    true,
+				Description: "ID of the check with the lowest uptime. Null when the group is empty.",
+			},
+			"worst_check_name": schema.StringAttribute{
+				Computed:    true,
+				Description: "Name of the check with the lowest uptime. Null when the group is empty.",
+			},
+			"worst_uptime": schema.Float64Attribute{
+				Computed:    true,
+				Description: "Uptime percentage of the check with the lowest uptime. Null when the group is empty.",
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source
+func (d *groupStatsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*cloudCanaryClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *cloudCanaryClient, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+// Read refreshes the Terraform state with the latest data
+func (d *groupStatsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config GroupStatsDataModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set default period if not provided
+	periodDays := 7
+	if !config.PeriodDays.IsNull() {
+		periodDays = int(config.PeriodDays.ValueInt64())
+	}
+
+	// Call API to get group statistics
+	stats, err := d.client.getGroupStats(ctx, config.GroupID.ValueString(), periodDays)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error retrieving group stats",
+			fmt.Sprintf("Could not retrieve stats for group ID %s: %s", config.GroupID.ValueString(), err),
+		)
+		return
+	}
+
+	// Generate a unique ID for this data source instance
+	config.ID = types.StringValue(fmt.Sprintf("group-stats-%s-%d", config.GroupID.ValueString(), time.Now().Unix()))
+
+	config.MemberCount = types.Int64Value(int64(stats.MemberCount))
+	config.Uptime = stats.Uptime
+	config.WorstCheckID = stats.WorstCheckID
+	config.WorstCheckName = stats.WorstCheckName
+	config.WorstUptime = stats.WorstUptime
+
+	// Set state
+	diags = resp.State.Set(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+}
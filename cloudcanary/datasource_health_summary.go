@@ -0,0 +1,149 @@
+package cloudcanary
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// statusSeverity ranks check statuses from best to worst
+var statusSeverity = map[string]int{
+	"SUCCESS":  0,
+	"UNKNOWN":  1,
+	"PENDING":  2,
+	"DEGRADED": 3,
+	"FAILURE":  4,
+}
+
+// healthSummaryDataSource implements a data source summarizing the status of all checks
+type healthSummaryDataSource struct {
+	client *cloudCanaryClient
+}
+
+// Ensure the implementation satisfies the expected interfaces
+var _ datasource.DataSource = &healthSummaryDataSource{}
+
+// NewHealthSummaryDataSource creates a new health summary data source
+func NewHealthSummaryDataSource() datasource.DataSource {
+	return &healthSummaryDataSource{}
+}
+
+// Metadata returns the data source type name
+func (d *healthSummaryDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_health_summary"
+}
+
+// Schema defines the schema for the data source
+func (d *healthSummaryDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Summarizes the latest status of every check in the account, optionally limited to one environment.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "Unique identifier for this data source instance.",
+			},
+			"environment": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only summarize checks in this environment. Defaults to every check.",
+			},
+			"total": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Total number of checks.",
+			},
+			"up": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Number of checks whose last result was SUCCESS.",
+			},
+			"down": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Number of checks whose last result was FAILURE.",
+			},
+			"degraded": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Number of checks whose last result was DEGRADED.",
+			},
+			"worst_status": schema.StringAttribute{
+				Computed:    true,
+				Description: "The worst last result across all checks (SUCCESS, UNKNOWN, PENDING, DEGRADED, FAILURE). Null when there are no checks.",
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source
+func (d *healthSummaryDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*cloudCanaryClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *cloudCanaryClient, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+// Read refreshes the Terraform state with the latest data
+func (d *healthSummaryDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config HealthSummaryDataModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Call API to list every check
+	checks, err := d.client.listChecks(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error listing checks",
+			fmt.Sprintf("Could not list checks: %s", err),
+		)
+		return
+	}
+
+	var total, up, down, degraded int64
+	worst := ""
+	for _, check := range checks {
+		if !config.Environment.IsNull() && check.Environment != config.Environment.ValueString() {
+			continue
+		}
+		total++
+
+		switch check.LastResult {
+		case "SUCCESS":
+			up++
+		case "FAILURE":
+			down++
+		case "DEGRADED":
+			degraded++
+		}
+		if worst == "" || statusSeverity[check.LastResult] > statusSeverity[worst] {
+			worst = check.LastResult
+		}
+	}
+
+	// Generate a unique ID for this data source instance
+	config.ID = types.StringValue(fmt.Sprintf("health-summary-%d", time.Now().Unix()))
+	config.Total = types.Int64Value(total)
+	config.Up = types.Int64Value(up)
+	config.Down = types.Int64Value(down)
+	config.Degraded = types.Int64Value(degraded)
+	config.WorstStatus = types.StringNull()
+	if worst != "" {
+		config.WorstStatus = types.StringValue(worst)
+	}
+
+	// Set state
+	diags = resp.State.Set(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+}
@@ -0,0 +1,129 @@
+package cloudcanary
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// incidentsDataSource implements a CloudCanary incident history data source
+type incidentsDataSource struct {
+	client *cloudCanaryClient
+}
+
+// Ensure the implementation satisfies the expected interfaces
+var _ datasource.DataSource = &incidentsDataSource{}
+
+// NewIncidentsDataSource creates a new incidents data source
+func NewIncidentsDataSource() datasource.DataSource {
+	return &incidentsDataSource{}
+}
+
+// Metadata returns the data source type name
+func (d *incidentsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_incidents"
+}
+
+// Schema defines the schema for the data source
+func (d *incidentsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Retrieves the incident history of a specific check over the last 7 days.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "Unique identifier for this data source instance.",
+			},
+			"check_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The ID of the check to retrieve incidents for.",
+				Validators: []validator.String{
+					validCheckID(),
+				},
+			},
+			"incidents": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "The incidents, most recent first.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:    true,
+							Description: "Unique identifier for the incident.",
+						},
+						"started_at": schema.StringAttribute{
+							Computed:    true,
+							Description: "When the check started failing (RFC3339 format, UTC).",
+						},
+						"resolved_at": schema.StringAttribute{
+							Computed:    true,
+							Description: "When the check recovered (RFC3339 format, UTC). Null while the incident is ongoing.",
+						},
+						"duration": schema.Int64Attribute{
+							Computed:    true,
+							Description: "Length of the incident in seconds, up to now if it is ongoing.",
+						},
+						"cause": schema.StringAttribute{
+							Computed:    true,
+							Description: "Why the check failed when the incident started.",
+						},
+						"runbook_url": schema.StringAttribute{
+							Computed:    true,
+							Description: "The check's runbook_url, if set, for responders to follow.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source
+func (d *incidentsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*cloudCanaryClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *cloudCanaryClient, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+// Read refreshes the Terraform state with the latest data
+func (d *incidentsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config IncidentsDataModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Call API to get the incident history
+	incidents, err := d.client.getIncidents(ctx, config.CheckID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error retrieving incidents",
+			fmt.Sprintf("Could not retrieve incidents for check ID %s: %s", config.CheckID.ValueString(), err),
+		)
+		return
+	}
+
+	// Generate a unique ID for this data source instance
+	config.ID = types.StringValue(fmt.Sprintf("incidents-%s-%d", config.CheckID.ValueString(), time.Now().Unix()))
+
+	config.Incidents = incidents
+
+	// Set state
+	diags = resp.State.Set(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+}
@@ -0,0 +1,102 @@
+package cloudcanary
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// latestResultDataSource implements a CloudCanary latest check result data source
+type latestResultDataSource struct {
+	client *cloudCanaryClient
+}
+
+// Ensure the implementation satisfies the expected interfaces
+var _ datasource.DataSource = &latestResultDataSource{}
+
+// NewLatestResultDataSource creates a new latest result data source
+func NewLatestResultDataSource() datasource.DataSource {
+	return &latestResultDataSource{}
+}
+
+// Metadata returns the data source type name
+func (d *latestResultDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_latest_result"
+}
+
+// Schema defines the schema for the data source
+func (d *latestResultDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Retrieves the most recent result of a specific check.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "Unique identifier for this data source instance.",
+			},
+			"check_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The ID of the check to retrieve the latest result for.",
+				Validators: []validator.String{
+					validCheckID(),
+				},
+			},
+			"result": schema.SingleNestedAttribute{
+				Computed:    true,
+				Description: "The most recent result. Null if the check has no results yet.",
+				Attributes:  checkResultAttributes(),
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source
+func (d *latestResultDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*cloudCanaryClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *cloudCanaryClient, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+// Read refreshes the Terraform state with the latest data
+func (d *latestResultDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config LatestResultDataModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Call API to get the most recent result
+	result, err := d.client.getLatestResult(ctx, config.CheckID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error retrieving latest result",
+			fmt.Sprintf("Could not retrieve the latest result for check ID %s: %s", config.CheckID.ValueString(), err),
+		)
+		return
+	}
+
+	// Generate a unique ID for this data source instance
+	config.ID = types.StringValue(fmt.Sprintf("latest-result-%s-%d", config.CheckID.ValueString(), time.Now().Unix()))
+
+	config.Result = result
+
+	// Set state
+	diags = resp.State.Set(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+}
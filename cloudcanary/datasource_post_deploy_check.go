@@ -0,0 +1,125 @@
+package cloudcanary
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// postDeployCheckDataSource implements a data source running a check once per trigger value
+type postDeployCheckDataSource struct {
+	client *cloudCanaryClient
+}
+
+// Ensure the implementation satisfies the expected interfaces
+var _ datasource.DataSource = &postDeployCheckDataSource{}
+
+// NewPostDeployCheckDataSource creates a new post-deploy check data source
+func NewPostDeployCheckDataSource() datasource.DataSource {
+	return &postDeployCheckDataSource{}
+}
+
+// Metadata returns the data source type name
+func (d *postDeployCheckDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_post_deploy_check"
+}
+
+// Schema defines the schema for the data source
+func (d *postDeployCheckDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Runs a check on demand and returns the result, for smoke testing a deploy. The check runs when the data source is read, once per trigger value within a Terraform run.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The ID of the result of the run.",
+			},
+			"check_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The ID of the check to run.",
+				Validators: []validator.String{
+					validCheckID(),
+				},
+			},
+			"trigger": schema.StringAttribute{
+				Required:    true,
+				Description: "Identifies the deploy to test, e.g. a commit SHA or release version. A value only known after apply defers the run until then.",
+			},
+			"status": schema.StringAttribute{
+				Computed:    true,
+				Description: "The status of the run (SUCCESS, DEGRADED, FAILURE).",
+			},
+			"response_time": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Response time in milliseconds.",
+			},
+			"response_code": schema.Int64Attribute{
+				Computed:    true,
+				Description: "HTTP status code of the response, for HTTP and API checks.",
+			},
+			"message": schema.StringAttribute{
+				Computed:    true,
+				Description: "Message associated with the run.",
+			},
+			"failure_reason": schema.StringAttribute{
+				Computed:    true,
+				Description: "Why the run failed. Null unless status is FAILURE.",
+			},
+			"probed_at": schema.StringAttribute{
+				Computed:    true,
+				Description: "When the check ran (RFC3339 format).",
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source
+func (d *postDeployCheckDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*cloudCanaryClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *cloudCanaryClient, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+// Read runs the check, unless it already ran for this trigger, and records the result
+func (d *postDeployCheckDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config PostDeployCheckDataModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	result, err := d.client.runCheckForTrigger(ctx, config.CheckID.ValueString(), config.Trigger.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error running check",
+			fmt.Sprintf("Could not run check ID %s for trigger %q: %s", config.CheckID.ValueString(), config.Trigger.ValueString(), err),
+		)
+		return
+	}
+
+	config.ID = result.ID
+	config.Status = result.Status
+	config.ResponseTime = result.ResponseTime
+	config.ResponseCode = result.ResponseCode
+	config.Message = result.Message
+	config.FailureReason = result.FailureReason
+	config.ProbedAt = result.Timestamp
+
+	// Set state
+	diags = resp.State.Set(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+}
@@ -0,0 +1,153 @@
+package cloudcanary
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// prometheusLabelEscaper escapes label values as the Prometheus text exposition format requires
+var prometheusLabelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// prometheusMetricsDataSource implements a data source rendering check status as Prometheus metrics
+type prometheusMetricsDataSource struct {
+	client *cloudCanaryClient
+}
+
+// Ensure the implementation satisfies the expected interfaces
+var _ datasource.DataSource = &prometheusMetricsDataSource{}
+
+// NewPrometheusMetricsDataSource creates a new Prometheus metrics data source
+func NewPrometheusMetricsDataSource() datasource.DataSource {
+	return &prometheusMetricsDataSource{}
+}
+
+// Metadata returns the data source type name
+func (d *prometheusMetricsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_prometheus_metrics"
+}
+
+// Schema defines the schema for the data source
+func (d *prometheusMetricsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Renders the latest status and response time of every check in the Prometheus text exposition format, for example to write to a file read by node_exporter's textfile collector.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "Unique identifier for this data source instance.",
+			},
+			"environment": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only include checks in this environment. Defaults to every check.",
+			},
+			"metrics": schema.StringAttribute{
+				Computed:    true,
+				Description: "The metrics in the Prometheus text exposition format: cloudcanary_check_up, 1 when a check's last result was SUCCESS and 0 when it was FAILURE or DEGRADED, and cloudcanary_check_response_time_seconds, the response time of its latest result. Checks that haven't run yet have neither. Each sample is labelled with the check's check_id, name, type and environment.",
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source
+func (d *prometheusMetricsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*cloudCanaryClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *cloudCanaryClient, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+// Read refreshes the Terraform state with the latest data
+func (d *prometheusMetricsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config PrometheusMetricsDataModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Call API to list every check
+	checks, err := d.client.listChecks(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error listing checks",
+			fmt.Sprintf("Could not list checks: %s", err),
+		)
+		return
+	}
+
+	// Sort by ID so the output only changes when the checks do
+	sort.Slice(checks, func(i, j int) bool { return checks[i].ID < checks[j].ID })
+
+	var up, responseTime strings.Builder
+	for _, check := range checks {
+		if !config.Environment.IsNull() && check.Environment != config.Environment.ValueString() {
+			continue
+		}
+		labels := prometheusLabels(check)
+
+		// Checks that haven't run yet are neither up nor down
+		switch check.LastResult {
+		case "SUCCESS":
+			fmt.Fprintf(&up, "cloudcanary_check_up%s 1\n", labels)
+		case "FAILURE", "DEGRADED":
+			fmt.Fprintf(&up, "cloudcanary_check_up%s 0\n", labels)
+		}
+
+		result, err := d.client.getLatestResult(ctx, check.ID)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error reading check results",
+				fmt.Sprintf("Could not read the latest result of check ID %s: %s", check.ID, err),
+			)
+			return
+		}
+		if result == nil || result.ResponseTime.IsNull() {
+			continue
+		}
+		seconds := float64(result.ResponseTime.ValueInt64()) / 1000
+		fmt.Fprintf(&responseTime, "cloudcanary_check_response_time_seconds%s %s\n", labels, strconv.FormatFloat(seconds, 'g', -1, 64))
+	}
+
+	var metrics strings.Builder
+	metrics.WriteString("# HELP cloudcanary_check_up Whether the check's last result was SUCCESS.\n")
+	metrics.WriteString("# TYPE cloudcanary_check_up gauge\n")
+	metrics.WriteString(up.String())
+	metrics.WriteString("# HELP cloudcanary_check_response_time_seconds Response time of the check's latest result.\n")
+	metrics.WriteString("# TYPE cloudcanary_check_response_time_seconds gauge\n")
+	metrics.WriteString(responseTime.String())
+
+	// Generate a unique ID for this data source instance
+	config.ID = types.StringValue(fmt.Sprintf("prometheus-metrics-%d", time.Now().Unix()))
+	config.Metrics = types.StringValue(metrics.String())
+
+	// Set state
+	diags = resp.State.Set(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+}
+
+// prometheusLabels returns the label set identifying a check's samples
+func prometheusLabels(check CheckSummary) string {
+	return fmt.Sprintf(`{check_id="%s",name="%s",type="%s",environment="%s"}`,
+		prometheusLabelEscaper.Replace(check.ID),
+		prometheusLabelEscaper.Replace(check.Name),
+		prometheusLabelEscaper.Replace(check.Type),
+		prometheusLabelEscaper.Replace(check.Environment),
+	)
+}
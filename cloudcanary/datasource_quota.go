@@ -0,0 +1,105 @@
+package cloudcanary
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// quotaDataSource implements a data source reporting the account's check quota
+type quotaDataSource struct {
+	client *cloudCanaryClient
+}
+
+// Ensure the implementation satisfies the expected interfaces
+var _ datasource.DataSource = &quotaDataSource{}
+
+// NewQuotaDataSource creates a new quota data source
+func NewQuotaDataSource() datasource.DataSource {
+	return &quotaDataSource{}
+}
+
+// Metadata returns the data source type name
+func (d *quotaDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_quota"
+}
+
+// Schema defines the schema for the data source
+func (d *quotaDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Retrieves the account's check quota, for creating checks conditionally or warning before the limit is reached.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "Unique identifier for this data source instance.",
+			},
+			"checks_used": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Number of checks in the account, of every type.",
+			},
+			"checks_limit": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Number of checks the account's plan allows.",
+			},
+			"regions_available": schema.ListAttribute{
+				ElementType: types.StringType,
+				Computed:    true,
+				Description: "Regions the account's checks may run from.",
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source
+func (d *quotaDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*cloudCanaryClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *cloudCanaryClient, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+// Read refreshes the Terraform state with the latest data
+func (d *quotaDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config QuotaDataModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Call API to get the quota
+	quota, err := d.client.getQuota(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error retrieving quota",
+			fmt.Sprintf("Could not retrieve CloudCanary quota: %s", err),
+		)
+		return
+	}
+
+	config.ID = types.StringValue("quota")
+	config.ChecksUsed = types.Int64Value(int64(quota.ChecksUsed))
+	config.ChecksLimit = types.Int64Value(int64(quota.ChecksLimit))
+	config.RegionsAvailable, diags = types.ListValueFrom(ctx, types.StringType, quota.RegionsAvailable)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set state
+	diags = resp.State.Set(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+}
@@ -0,0 +1,59 @@
+package cloudcanary
+
+import (
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Policies for a header name the API returns more than once, set with the
+// duplicate_headers provider attribute
+const (
+	// duplicateHeadersJoin joins the values with ", ", as HTTP allows for
+	// repeated fields, so no value is lost
+	duplicateHeadersJoin = "join"
+	// duplicateHeadersFirst keeps the first value and drops the rest
+	duplicateHeadersFirst = "first"
+)
+
+// duplicateHeaderPolicies are the values duplicate_headers may take
+var duplicateHeaderPolicies = []string{duplicateHeadersJoin, duplicateHeadersFirst}
+
+// apiHeader is a request header as the API returns it. The API lists headers
+// as name/value pairs, so a name may repeat.
+type apiHeader struct {
+	Name  string
+	Value string
+}
+
+// headersValue converts the headers the API returns into the headers map,
+// applying policy to names that repeat. Names are matched without regard to
+// case, as HTTP does, and a repeated name is keyed by its first spelling.
+// Values keep the order the API gives.
+func headersValue(headers []apiHeader, policy string) types.Map {
+	if headers == nil {
+		return types.MapNull(types.StringType)
+	}
+
+	values := make(map[string]string, len(headers))
+	names := make(map[string]string, len(headers))
+	for _, header := range headers {
+		name, ok := names[strings.ToLower(header.Name)]
+		switch {
+		case !ok:
+			names[strings.ToLower(header.Name)] = header.Name
+			values[header.Name] = header.Value
+		case policy == duplicateHeadersFirst:
+			// Keep the value already seen
+		default:
+			values[name] += ", " + header.Value
+		}
+	}
+
+	elements := make(map[string]attr.Value, len(values))
+	for name, value := range values {
+		elements[name] = types.StringValue(value)
+	}
+	return types.MapValueMust(types.StringType, elements)
+}
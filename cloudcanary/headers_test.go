@@ -0,0 +1,94 @@
+package cloudcanary
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestHeadersValue(t *testing.T) {
+	tests := []struct {
+		name    string
+		headers []apiHeader
+		policy  string
+		want    map[string]string
+	}{
+		{
+			name:    "no duplicates",
+			headers: []apiHeader{{"Accept", "application/json"}, {"X-Trace", "1"}},
+			policy:  duplicateHeadersJoin,
+			want:    map[string]string{"Accept": "application/json", "X-Trace": "1"},
+		},
+		{
+			name:    "join exact duplicates",
+			headers: []apiHeader{{"Accept", "text/html"}, {"Accept", "application/json"}},
+			policy:  duplicateHeadersJoin,
+			want:    map[string]string{"Accept": "text/html, application/json"},
+		},
+		{
+			name:    "join keeps API order over three values",
+			headers: []apiHeader{{"Via", "a"}, {"X-Trace", "1"}, {"Via", "b"}, {"Via", "c"}},
+			policy:  duplicateHeadersJoin,
+			want:    map[string]string{"Via": "a, b, c", "X-Trace": "1"},
+		},
+		{
+			name:    "join case-insensitive duplicates",
+			headers: []apiHeader{{"Accept", "text/html"}, {"accept", "application/json"}, {"ACCEPT", "*/*"}},
+			policy:  duplicateHeadersJoin,
+			want:    map[string]string{"Accept": "text/html, application/json, */*"},
+		},
+		{
+			name:    "empty policy joins",
+			headers: []apiHeader{{"Accept", "text/html"}, {"Accept", "application/json"}},
+			policy:  "",
+			want:    map[string]string{"Accept": "text/html, application/json"},
+		},
+		{
+			name:    "first exact duplicates",
+			headers: []apiHeader{{"Accept", "text/html"}, {"Accept", "application/json"}},
+			policy:  duplicateHeadersFirst,
+			want:    map[string]string{"Accept": "text/html"},
+		},
+		{
+			name:    "first case-insensitive duplicates",
+			headers: []apiHeader{{"x-trace", "1"}, {"X-Trace", "2"}},
+			policy:  duplicateHeadersFirst,
+			want:    map[string]string{"x-trace": "1"},
+		},
+		{
+			name:    "empty values are kept",
+			headers: []apiHeader{{"X-Empty", ""}, {"X-Empty", "set"}},
+			policy:  duplicateHeadersJoin,
+			want:    map[string]string{"X-Empty": ", set"},
+		},
+		{
+			name:    "no headers",
+			headers: []apiHeader{},
+			policy:  duplicateHeadersJoin,
+			want:    map[string]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			elements := make(map[string]attr.Value, len(tt.want))
+			for name, value := range tt.want {
+				elements[name] = types.StringValue(value)
+			}
+			want := types.MapValueMust(types.StringType, elements)
+
+			if got := headersValue(tt.headers, tt.policy); !got.Equal(want) {
+				t.Errorf("headersValue = %s, want %s", got, want)
+			}
+		})
+	}
+}
+
+func TestHeadersValueNull(t *testing.T) {
+	for _, policy := range duplicateHeaderPolicies {
+		if got := headersValue(nil, policy); !got.IsNull() {
+			t.Errorf("headersValue(nil, %q) = %s, want null", policy, got)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package cloudcanary
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// incidentLookback is how far back incident history is reported
+const incidentLookback = 7 * 24 * time.Hour
+
+// checkSeverities are the supported severity values, lowest priority first
+var checkSeverities = []string{"info", "warning", "critical"}
+
+// incidentsFromResults groups consecutive failed results into incidents. An
+// incident starts at its first failure and is resolved by the next success;
+// one still failing at the newest result is open, with a null resolved_at and
+// a duration measured up to now. Results and incidents are newest first.
+func incidentsFromResults(checkID string, results []CheckResult, now time.Time) ([]Incident, error) {
+	incidents := []Incident{}
+	var open *Incident
+	var openedAt time.Time
+
+	// Walk oldest to newest so each incident sees its failures in order
+	for i := len(results) - 1; i >= 0; i-- {
+		result := results[i]
+		timestamp, err := time.Parse(time.RFC3339, result.Timestamp.ValueString())
+		if err != nil {
+			return nil, fmt.Errorf("invalid result timestamp %q: %w", result.Timestamp.ValueString(), err)
+		}
+
+		failed := result.Status.ValueString() == "FAILURE"
+		switch {
+		case failed && open == nil:
+			cause := result.FailureReason
+			if cause.IsNull() {
+				cause = result.Message
+			}
+			openedAt = timestamp
+			open = &Incident{
+				ID:         types.StringValue(fmt.Sprintf("inc-%s-%d", checkID, timestamp.Unix())),
+				StartedAt:  types.StringValue(timestamp.UTC().Format(time.RFC3339)),
+				ResolvedAt: types.StringNull(),
+				Cause:      cause,
+			}
+		case !failed && open != nil:
+			open.ResolvedAt = types.StringValue(timestamp.UTC().Format(time.RFC3339))
+			open.Duration = types.Int64Value(int64(timestamp.Sub(openedAt) / time.Second))
+			incidents = append(incidents, *open)
+			open = nil
+		}
+	}
+	if open != nil {
+		open.Duration = types.Int64Value(int64(now.Sub(openedAt) / time.Second))
+		incidents = append(incidents, *open)
+	}
+
+	// Report the most recent incident first
+	for i, j := 0, len(incidents)-1; i < j; i, j = i+1, j-1 {
+		incidents[i], incidents[j] = incidents[j], incidents[i]
+	}
+
+	return incidents, nil
+}
@@ -0,0 +1,381 @@
+package cloudcanary
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// decodeJSON parses a JSON document, keeping numbers as json.Number so no precision is lost
+func decodeJSON(s string) (any, error) {
+	dec := json.NewDecoder(strings.NewReader(s))
+	dec.UseNumber()
+
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+
+	// Reject trailing data after the first JSON value
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, fmt.Errorf("unexpected data after JSON value")
+	}
+
+	return v, nil
+}
+
+// canonicalJSON returns the compact form of a JSON document with object keys sorted
+func canonicalJSON(s string) (string, error) {
+	v, err := decodeJSON(s)
+	if err != nil {
+		return "", err
+	}
+
+	// encoding/json sorts map keys; disable HTML escaping so the output stays byte-for-byte readable
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return "", err
+	}
+
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
+
+// parseJSONPath splits a simple JSONPath expression such as $.items[*].id into
+// its segments. Object keys, array indexes and the * wildcard are supported.
+func parseJSONPath(p string) ([]string, error) {
+	if !strings.HasPrefix(p, "$") {
+		return nil, fmt.Errorf("path %q must start with $", p)
+	}
+
+	var segments []string
+	rest := p[1:]
+	for rest != "" {
+		switch rest[0] {
+		case '.':
+			end := strings.IndexAny(rest[1:], ".[")
+			if end == -1 {
+				end = len(rest) - 1
+			}
+			key := rest[1 : end+1]
+			if key == "" {
+				return nil, fmt.Errorf("path %q contains an empty key", p)
+			}
+			segments = append(segments, key)
+			rest = rest[end+1:]
+		case '[':
+			end := strings.IndexByte(rest, ']')
+			if end == -1 {
+				return nil, fmt.Errorf("path %q has an unterminated [", p)
+			}
+			index := rest[1:end]
+			if index != "*" {
+				if _, err := strconv.Atoi(index); err != nil {
+					return nil, fmt.Errorf("path %q has an invalid index %q", p, index)
+				}
+			}
+			segments = append(segments, index)
+			rest = rest[end+1:]
+		default:
+			return nil, fmt.Errorf("path %q has unexpected character %q", p, rest[0])
+		}
+	}
+
+	return segments, nil
+}
+
+// removeJSONPath deletes every value matched by the path segments from a decoded JSON document
+func removeJSONPath(v any, segments []string) any {
+	if len(segments) == 0 {
+		return v
+	}
+	seg, last := segments[0], len(segments) == 1
+
+	switch node := v.(type) {
+	case map[string]any:
+		for key, child := range node {
+			if seg != "*" && seg != key {
+				continue
+			}
+			if last {
+				delete(node, key)
+			} else {
+				node[key] = removeJSONPath(child, segments[1:])
+			}
+		}
+	case []any:
+		if seg == "*" {
+			if last {
+				return []any{}
+			}
+			for i, child := range node {
+				node[i] = removeJSONPath(child, segments[1:])
+			}
+			return node
+		}
+		i, err := strconv.Atoi(seg)
+		if err != nil || i < 0 || i >= len(node) {
+			return node
+		}
+		if last {
+			return append(node[:i:i], node[i+1:]...)
+		}
+		node[i] = removeJSONPath(node[i], segments[1:])
+	}
+
+	return v
+}
+
+// jsonEqual reports whether two decoded JSON documents are equal, ignoring
+// object key order and the order of array elements
+func jsonEqual(a, b any) bool {
+	switch av := a.(type) {
+	case map[string]any:
+		bv, ok := b.(map[string]any)
+		if !ok || len(av) != len(bv) {
+			return false
+		}
+		for key, child := range av {
+			other, ok := bv[key]
+			if !ok || !jsonEqual(child, other) {
+				return false
+			}
+		}
+		return true
+	case []any:
+		bv, ok := b.([]any)
+		if !ok || len(av) != len(bv) {
+			return false
+		}
+		// Match each element against a not-yet-used element of the other array
+		used := make([]bool, len(bv))
+		for _, child := range av {
+			found := false
+			for i, other := range bv {
+				if !used[i] && jsonEqual(child, other) {
+					used[i] = true
+					found = true
+					break
+				}
+			}
+			if !found {
+				return false
+			}
+		}
+		return true
+	case json.Number:
+		bv, ok := b.(json.Number)
+		if !ok {
+			return false
+		}
+		if av == bv {
+			return true
+		}
+		af, aerr := av.Float64()
+		bf, berr := bv.Float64()
+		return aerr == nil && berr == nil && af == bf
+	default:
+		return a == b
+	}
+}
+
+// decodeResponseJSON parses a response body as JSON. In strict mode duplicate
+// object keys and trailing data are rejected; otherwise the first JSON value is
+// used, later duplicate keys win, and anything after it is ignored.
+func decodeResponseJSON(body []byte, strict bool) (any, error) {
+	if strict {
+		dec := json.NewDecoder(bytes.NewReader(body))
+		if err := checkJSONTokens(dec); err != nil {
+			return nil, err
+		}
+		return decodeJSON(string(body))
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+
+	return v, nil
+}
+
+// checkJSONTokens walks the next JSON value in the decoder, returning an error
+// if any object contains the same key more than once
+func checkJSONTokens(dec *json.Decoder) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+
+	delim, ok := tok.(json.Delim)
+	if !ok {
+		return nil
+	}
+
+	switch delim {
+	case '{':
+		keys := make(map[string]bool)
+		for dec.More() {
+			tok, err := dec.Token()
+			if err != nil {
+				return err
+			}
+			key, _ := tok.(string)
+			if keys[key] {
+				return fmt.Errorf("duplicate key %q", key)
+			}
+			keys[key] = true
+			if err := checkJSONTokens(dec); err != nil {
+				return err
+			}
+		}
+	case '[':
+		for dec.More() {
+			if err := checkJSONTokens(dec); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Consume the closing delimiter
+	_, err = dec.Token()
+	return err
+}
+
+// compareJSONBody checks that a decoded response body matches the expected
+// document, ignoring any values matched by the given paths
+func compareJSONBody(expected string, got any, ignorePaths []string) error {
+	want, err := decodeJSON(expected)
+	if err != nil {
+		return fmt.Errorf("expected_json_body is not valid JSON: %w", err)
+	}
+
+	for _, p := range ignorePaths {
+		segments, err := parseJSONPath(p)
+		if err != nil {
+			return err
+		}
+		want = removeJSONPath(want, segments)
+		got = removeJSONPath(got, segments)
+	}
+
+	if !jsonEqual(want, got) {
+		return fmt.Errorf("response body does not match expected_json_body")
+	}
+
+	return nil
+}
+
+// lookupJSONPath returns every value in a decoded JSON document matched by the path segments
+func lookupJSONPath(v any, segments []string) []any {
+	if len(segments) == 0 {
+		return []any{v}
+	}
+	seg, rest := segments[0], segments[1:]
+
+	var matches []any
+	switch node := v.(type) {
+	case map[string]any:
+		if seg != "*" {
+			if child, ok := node[seg]; ok {
+				matches = append(matches, lookupJSONPath(child, rest)...)
+			}
+			break
+		}
+		keys := make([]string, 0, len(node))
+		for key := range node {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			matches = append(matches, lookupJSONPath(node[key], rest)...)
+		}
+	case []any:
+		if seg != "*" {
+			if i, err := strconv.Atoi(seg); err == nil && i >= 0 && i < len(node) {
+				matches = append(matches, lookupJSONPath(node[i], rest)...)
+			}
+			break
+		}
+		for _, child := range node {
+			matches = append(matches, lookupJSONPath(child, rest)...)
+		}
+	}
+
+	return matches
+}
+
+// jsonValueEmpty reports whether a decoded JSON value carries nothing: null,
+// false, zero, an empty string, or an empty array or object
+func jsonValueEmpty(v any) bool {
+	switch val := v.(type) {
+	case nil:
+		return true
+	case bool:
+		return !val
+	case json.Number:
+		f, err := val.Float64()
+		return err == nil && f == 0
+	case string:
+		return val == ""
+	case []any:
+		return len(val) == 0
+	case map[string]any:
+		return len(val) == 0
+	default:
+		return false
+	}
+}
+
+// jsonValueString renders a decoded JSON value as a string; strings are returned
+// unquoted and everything else in its compact JSON form
+func jsonValueString(v any) string {
+	switch val := v.(type) {
+	case string:
+		return val
+	case json.Number:
+		return val.String()
+	default:
+		b, err := json.Marshal(val)
+		if err != nil {
+			return ""
+		}
+		return string(b)
+	}
+}
+
+// extractJSONValues evaluates each named JSONPath against a JSON body. Paths
+// with no match are omitted; paths with several matches yield a JSON array.
+func extractJSONValues(body string, paths map[string]string) (map[string]string, error) {
+	doc, err := decodeResponseJSON([]byte(body), false)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make(map[string]string, len(paths))
+	for name, p := range paths {
+		segments, err := parseJSONPath(p)
+		if err != nil {
+			return nil, err
+		}
+
+		switch matches := lookupJSONPath(doc, segments); len(matches) {
+		case 0:
+			continue
+		case 1:
+			values[name] = jsonValueString(matches[0])
+		default:
+			values[name] = jsonValueString(matches)
+		}
+	}
+
+	return values, nil
+}
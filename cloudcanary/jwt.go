@@ -0,0 +1,91 @@
+package cloudcanary
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"time"
+)
+
+// jwtLifetime is how long a JWT minted for a single probe stays valid
+const jwtLifetime = 5 * time.Minute
+
+// jwtAlgorithms are the supported jwt_algorithm values
+var jwtAlgorithms = []string{"HS256", "RS256"}
+
+// jwtReservedClaims are set on every minted token and can't be configured
+var jwtReservedClaims = []string{"iat", "exp"}
+
+// mintJWT returns a compact JWT carrying claims plus iat and exp, signed with
+// secret. HS256 uses secret as the HMAC key; RS256 expects a PEM encoded RSA
+// private key.
+func mintJWT(algorithm, secret string, claims map[string]string, now time.Time) (string, error) {
+	payload := make(map[string]any, len(claims)+len(jwtReservedClaims))
+	for name, value := range claims {
+		payload[name] = value
+	}
+	payload["iat"] = now.Unix()
+	payload["exp"] = now.Add(jwtLifetime).Unix()
+
+	header, err := json.Marshal(map[string]string{"alg": algorithm, "typ": "JWT"})
+	if err != nil {
+		return "", err
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return "", err
+	}
+
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(body)
+
+	var signature []byte
+	switch algorithm {
+	case "HS256":
+		mac := hmac.New(sha256.New, []byte(secret))
+		mac.Write([]byte(signingInput))
+		signature = mac.Sum(nil)
+	case "RS256":
+		key, err := parseRSAPrivateKey(secret)
+		if err != nil {
+			return "", err
+		}
+		digest := sha256.Sum256([]byte(signingInput))
+		signature, err = rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+		if err != nil {
+			return "", err
+		}
+	default:
+		return "", fmt.Errorf("unsupported JWT algorithm %q", algorithm)
+	}
+
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+// parseRSAPrivateKey decodes a PEM encoded PKCS#1 or PKCS#8 RSA private key
+func parseRSAPrivateKey(data string) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode([]byte(data))
+	if block == nil {
+		return nil, fmt.Errorf("RS256 requires a PEM encoded RSA private key")
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse RSA private key: %w", err)
+	}
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("RS256 requires an RSA private key, got %T", parsed)
+	}
+	return key, nil
+}
@@ -0,0 +1,47 @@
+package cloudcanary
+
+import (
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// lastResultUnknown is the last_result of a check that has never produced a
+// result. Once the API schedules a run the check becomes PENDING, and each
+// finished run leaves SUCCESS, DEGRADED or FAILURE.
+const lastResultUnknown = "UNKNOWN"
+
+// lastRun maps the API's view of a check's most recent run to last_result and
+// last_check_time. A check the API reports no result for is UNKNOWN with a
+// null last_check_time, rather than an empty status.
+func lastRun(result, checkTime types.String) (types.String, types.String) {
+	if result.IsNull() || result.IsUnknown() || result.ValueString() == "" {
+		return types.StringValue(lastResultUnknown), types.StringNull()
+	}
+	return result, checkTime
+}
+
+// nextRun returns next_check_time: the time the API says the check runs next
+// or, when it doesn't say, lastCheckTime plus the check's interval. The API's
+// value is preferred as the provider host's clock may be skewed against the
+// scheduler's. A check that hasn't run yet has no time to go by, so stays null.
+// The mock API doesn't report schedules, so its reads leave serverTime null
+// and the time is always derived.
+func nextRun(serverTime, lastCheckTime types.String, interval types.Int64) types.String {
+	if !serverTime.IsNull() && !serverTime.IsUnknown() {
+		return serverTime
+	}
+	if lastCheckTime.IsNull() || lastCheckTime.IsUnknown() {
+		return types.StringNull()
+	}
+
+	last, err := time.Parse(time.RFC3339, lastCheckTime.ValueString())
+	if err != nil {
+		return types.StringNull()
+	}
+	seconds := int64(60)
+	if !interval.IsNull() && !interval.IsUnknown() {
+		seconds = interval.ValueInt64()
+	}
+	return types.StringValue(last.Add(time.Duration(seconds) * time.Second).Format(time.RFC3339))
+}
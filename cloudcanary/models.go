@@ -1,44 +1,332 @@
 package cloudcanary
 
 import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 // HTTPCheck represents an HTTP check configuration
 type HTTPCheck struct {
-	ID               types.String            `tfsdk:"id"`
-	Name             types.String            `tfsdk:"name"`
-	URL              types.String            `tfsdk:"url"`
-	Method           types.String            `tfsdk:"method"`
-	Headers          types.Map               `tfsdk:"headers"`
-	Body             types.String            `tfsdk:"body"`
-	ExpectedStatus   types.Int64             `tfsdk:"expected_status"`
-	ExpectedResponse types.String            `tfsdk:"expected_response"`
-	Interval         types.Int64             `tfsdk:"interval"`
-	Timeout          types.Int64             `tfsdk:"timeout"`
-	FollowRedirects  types.Bool              `tfsdk:"follow_redirects"`
-	Regions          types.List              `tfsdk:"regions"`
-	Retries          types.Int64             `tfsdk:"retries"`
-	LastResult       types.String            `tfsdk:"last_result"`
-	LastCheckTime    types.String            `tfsdk:"last_check_time"`
+	ID                       types.String    `tfsdk:"id"`
+	Name                     types.String    `tfsdk:"name"`
+	Environment              types.String    `tfsdk:"environment"`
+	Description              types.String    `tfsdk:"description"`
+	RunbookURL               types.String    `tfsdk:"runbook_url"`
+	Severity                 types.String    `tfsdk:"severity"`
+	ResultLabels             types.Map       `tfsdk:"result_labels"`
+	SourceCheckID            types.String    `tfsdk:"source_check_id"`
+	URL                      types.String    `tfsdk:"url"`
+	AdditionalURLs           types.List      `tfsdk:"additional_urls"`
+	MatchMode                types.String    `tfsdk:"match_mode"`
+	DNSResolver              types.String    `tfsdk:"dns_resolver"`
+	SourceIP                 types.String    `tfsdk:"source_ip"`
+	TLSServerName            types.String    `tfsdk:"tls_server_name"`
+	TLSExpiryWarningDays     types.Int64     `tfsdk:"tls_expiry_warning_days"`
+	AllowedCipherSuites      types.List      `tfsdk:"allowed_cipher_suites"`
+	Method                   types.String    `tfsdk:"method"`
+	Headers                  types.Map       `tfsdk:"headers"`
+	Cookies                  types.Map       `tfsdk:"cookies"`
+	Accept                   types.String    `tfsdk:"accept"`
+	PreserveHeaderCase       types.Bool      `tfsdk:"preserve_header_case"`
+	IfModifiedSince          types.String    `tfsdk:"if_modified_since"`
+	IfNoneMatch              types.String    `tfsdk:"if_none_match"`
+	Body                     types.String    `tfsdk:"body"`
+	NormalizeJSONBody        types.Bool      `tfsdk:"normalize_json_body"`
+	ExpectContinue           types.Bool      `tfsdk:"expect_continue"`
+	FormFields               types.Map       `tfsdk:"form_fields"`
+	FormFiles                types.Map       `tfsdk:"form_files"`
+	FormFilesSHA256          types.Map       `tfsdk:"form_files_sha256"`
+	ExpectedStatus           types.Int64     `tfsdk:"expected_status"`
+	ExpectedResponse         types.String    `tfsdk:"expected_response"`
+	ResponseCharset          types.String    `tfsdk:"response_charset"`
+	BodySearchLimitBytes     types.Int64     `tfsdk:"body_search_limit_bytes"`
+	ExpectedContentType      types.String    `tfsdk:"expected_content_type"`
+	ExpectedHeaders          types.Map       `tfsdk:"expected_headers"`
+	ExpectedTrailers         types.Map       `tfsdk:"expected_trailers"`
+	ExpectChunked            types.Bool      `tfsdk:"expect_chunked"`
+	MinResponseSize          types.Int64     `tfsdk:"min_response_size"`
+	MaxResponseSize          types.Int64     `tfsdk:"max_response_size"`
+	ExpectedBodySHA256       types.String    `tfsdk:"expected_body_sha256"`
+	StoreResponseBody        types.Bool      `tfsdk:"store_response_body"`
+	Interval                 types.Int64     `tfsdk:"interval"`
+	JitterSeconds            types.Int64     `tfsdk:"jitter_seconds"`
+	WarmupPeriod             types.Int64     `tfsdk:"warmup_period"`
+	Priority                 types.String    `tfsdk:"priority"`
+	Timeout                  types.Int64     `tfsdk:"timeout"`
+	ConnectTimeout           types.Int64     `tfsdk:"connect_timeout"`
+	ReadTimeout              types.Int64     `tfsdk:"read_timeout"`
+	MaxProbeDuration         types.Int64     `tfsdk:"max_probe_duration"`
+	FollowRedirects          types.Bool      `tfsdk:"follow_redirects"`
+	RedirectStatusIsSuccess  types.Bool      `tfsdk:"redirect_status_is_success"`
+	ExpectedRedirectLocation types.String    `tfsdk:"expected_redirect_location"`
+	Regions                  types.List      `tfsdk:"regions"`
+	PrivateLocationIDs       types.List      `tfsdk:"private_location_ids"`
+	RegionQuorum             types.Int64     `tfsdk:"region_quorum"`
+	Retries                  types.Int64     `tfsdk:"retries"`
+	RetryOnEmptyBody         types.Bool      `tfsdk:"retry_on_empty_body"`
+	BasicAuthUsername        types.String    `tfsdk:"basic_auth_username"`
+	BasicAuthPassword        types.String    `tfsdk:"basic_auth_password"`
+	ForwardAuthOnRedirect    types.Bool      `tfsdk:"forward_auth_on_redirect"`
+	ResponseTimeMode         types.String    `tfsdk:"response_time_mode"`
+	AssertConnectionReused   types.Bool      `tfsdk:"assert_connection_reused"`
+	RunIfCheckID             types.String    `tfsdk:"run_if_check_id"`
+	RunIfStatus              types.String    `tfsdk:"run_if_status"`
+	SLATarget                types.Float64   `tfsdk:"sla_target"`
+	BaselineDeviationPercent types.Int64     `tfsdk:"baseline_deviation_percent"`
+	ActiveSchedule           *ActiveSchedule `tfsdk:"active_schedule"`
+	Timeouts                 *Timeouts       `tfsdk:"timeouts"`
+	LastResult               types.String    `tfsdk:"last_result"`
+	LastCheckTime            types.String    `tfsdk:"last_check_time"`
+	NextCheckTime            types.String    `tfsdk:"next_check_time"`
+	IsFlapping               types.Bool      `tfsdk:"is_flapping"`
+	FlapCount1h              types.Int64     `tfsdk:"flap_count_1h"`
+	LastResultDetail         types.Object    `tfsdk:"last_result_detail"`
+	EffectiveConfig          types.Object    `tfsdk:"effective_config"`
+	LastResponseSize         types.Int64     `tfsdk:"last_response_size"`
+	LastBodySHA256           types.String    `tfsdk:"last_body_sha256"`
+	ResponseTimeBaseline     types.Float64   `tfsdk:"response_time_baseline"`
+	TLSCertificateExpiry     types.String    `tfsdk:"tls_certificate_expiry"`
+	SLACompliant             types.Bool      `tfsdk:"sla_compliant"`
+	SLABudgetRemaining       types.Float64   `tfsdk:"sla_budget_remaining"`
+	CreatedAt                types.String    `tfsdk:"created_at"`
+	UpdatedAt                types.String    `tfsdk:"updated_at"`
 }
 
 // APICheck represents an API check configuration
 type APICheck struct {
-	ID                 types.String            `tfsdk:"id"`
-	Name               types.String            `tfsdk:"name"`
-	Endpoint           types.String            `tfsdk:"endpoint"`
-	Method             types.String            `tfsdk:"method"`
-	Headers            types.Map               `tfsdk:"headers"`
-	Body               types.String            `tfsdk:"body"`
-	ExpectedStatus     types.Int64             `tfsdk:"expected_status"`
-	ResponseValidation types.List              `tfsdk:"response_validation"`
-	Interval           types.Int64             `tfsdk:"interval"`
-	Timeout            types.Int64             `tfsdk:"timeout"`
-	AuthType           types.String            `tfsdk:"auth_type"`
-	AuthValue          types.String            `tfsdk:"auth_value"`
-	LastResult         types.String            `tfsdk:"last_result"`
-	LastCheckTime      types.String            `tfsdk:"last_check_time"`
+	ID                   types.String    `tfsdk:"id"`
+	Name                 types.String    `tfsdk:"name"`
+	Environment          types.String    `tfsdk:"environment"`
+	Description          types.String    `tfsdk:"description"`
+	RunbookURL           types.String    `tfsdk:"runbook_url"`
+	Severity             types.String    `tfsdk:"severity"`
+	ResultLabels         types.Map       `tfsdk:"result_labels"`
+	Endpoint             types.String    `tfsdk:"endpoint"`
+	Method               types.String    `tfsdk:"method"`
+	Headers              types.Map       `tfsdk:"headers"`
+	Accept               types.String    `tfsdk:"accept"`
+	Body                 types.String    `tfsdk:"body"`
+	NormalizeJSONBody    types.Bool      `tfsdk:"normalize_json_body"`
+	ExpectedStatus       types.Int64     `tfsdk:"expected_status"`
+	SuccessStatusCodes   types.List      `tfsdk:"success_status_codes"`
+	ResponseValidation   types.List      `tfsdk:"response_validation"`
+	ExpectedContentType  types.String    `tfsdk:"expected_content_type"`
+	ExpectedHeaders      types.Map       `tfsdk:"expected_headers"`
+	ExpectedJSONBody     types.String    `tfsdk:"expected_json_body"`
+	IgnorePaths          types.List      `tfsdk:"ignore_paths"`
+	StrictJSON           types.Bool      `tfsdk:"strict_json"`
+	RequireValidJSON     types.Bool      `tfsdk:"require_valid_json"`
+	FailOnJSONErrorField types.String    `tfsdk:"fail_on_json_error_field"`
+	Extract              types.Map       `tfsdk:"extract"`
+	ExtractedValues      types.Map       `tfsdk:"extracted_values"`
+	Interval             types.Int64     `tfsdk:"interval"`
+	JitterSeconds        types.Int64     `tfsdk:"jitter_seconds"`
+	WarmupPeriod         types.Int64     `tfsdk:"warmup_period"`
+	Priority             types.String    `tfsdk:"priority"`
+	Timeout              types.Int64     `tfsdk:"timeout"`
+	AuthType             types.String    `tfsdk:"auth_type"`
+	AuthValue            types.String    `tfsdk:"auth_value"`
+	JWTSecret            types.String    `tfsdk:"jwt_secret"`
+	JWTClaims            types.Map       `tfsdk:"jwt_claims"`
+	JWTAlgorithm         types.String    `tfsdk:"jwt_algorithm"`
+	AWSAccessKeyID       types.String    `tfsdk:"aws_access_key_id"`
+	AWSSecretAccessKey   types.String    `tfsdk:"aws_secret_access_key"`
+	AWSRegion            types.String    `tfsdk:"aws_region"`
+	AWSService           types.String    `tfsdk:"aws_service"`
+	TokenRefreshURL      types.String    `tfsdk:"token_refresh_url"`
+	TokenRefreshJSONPath types.String    `tfsdk:"token_refresh_json_path"`
+	RunIfCheckID         types.String    `tfsdk:"run_if_check_id"`
+	RunIfStatus          types.String    `tfsdk:"run_if_status"`
+	ActiveSchedule       *ActiveSchedule `tfsdk:"active_schedule"`
+	Timeouts             *Timeouts       `tfsdk:"timeouts"`
+	LastResult           types.String    `tfsdk:"last_result"`
+	LastCheckTime        types.String    `tfsdk:"last_check_time"`
+	NextCheckTime        types.String    `tfsdk:"next_check_time"`
+	IsFlapping           types.Bool      `tfsdk:"is_flapping"`
+	FlapCount1h          types.Int64     `tfsdk:"flap_count_1h"`
+	LastResultDetail     types.Object    `tfsdk:"last_result_detail"`
+	CreatedAt            types.String    `tfsdk:"created_at"`
+	UpdatedAt            types.String    `tfsdk:"updated_at"`
+}
+
+// TCPCheck represents a TCP check configuration
+type TCPCheck struct {
+	ID                 types.String `tfsdk:"id"`
+	Name               types.String `tfsdk:"name"`
+	Environment        types.String `tfsdk:"environment"`
+	Description        types.String `tfsdk:"description"`
+	RunbookURL         types.String `tfsdk:"runbook_url"`
+	Severity           types.String `tfsdk:"severity"`
+	ResultLabels       types.Map    `tfsdk:"result_labels"`
+	Host               types.String `tfsdk:"host"`
+	Port               types.Int64  `tfsdk:"port"`
+	Interval           types.Int64  `tfsdk:"interval"`
+	WarmupPeriod       types.Int64  `tfsdk:"warmup_period"`
+	Timeout            types.Int64  `tfsdk:"timeout"`
+	Regions            types.List   `tfsdk:"regions"`
+	PrivateLocationIDs types.List   `tfsdk:"private_location_ids"`
+	SendPayload        types.String `tfsdk:"send_payload"`
+	ExpectedPayload    types.String `tfsdk:"expected_payload"`
+	PayloadEncoding    types.String `tfsdk:"payload_encoding"`
+	SendKeepalive      types.Bool   `tfsdk:"send_keepalive"`
+	KeepaliveInterval  types.Int64  `tfsdk:"keepalive_interval"`
+	LastResult         types.String `tfsdk:"last_result"`
+	LastCheckTime      types.String `tfsdk:"last_check_time"`
+	NextCheckTime      types.String `tfsdk:"next_check_time"`
+	IsFlapping         types.Bool   `tfsdk:"is_flapping"`
+	FlapCount1h        types.Int64  `tfsdk:"flap_count_1h"`
+	CreatedAt          types.String `tfsdk:"created_at"`
+	UpdatedAt          types.String `tfsdk:"updated_at"`
+}
+
+// WebSocketCheck represents a WebSocket check configuration
+type WebSocketCheck struct {
+	ID                 types.String `tfsdk:"id"`
+	Name               types.String `tfsdk:"name"`
+	Environment        types.String `tfsdk:"environment"`
+	Description        types.String `tfsdk:"description"`
+	RunbookURL         types.String `tfsdk:"runbook_url"`
+	Severity           types.String `tfsdk:"severity"`
+	ResultLabels       types.Map    `tfsdk:"result_labels"`
+	URL                types.String `tfsdk:"url"`
+	Subprotocol        types.String `tfsdk:"subprotocol"`
+	SendMessage        types.String `tfsdk:"send_message"`
+	ExpectedMessage    types.String `tfsdk:"expected_message"`
+	Interval           types.Int64  `tfsdk:"interval"`
+	WarmupPeriod       types.Int64  `tfsdk:"warmup_period"`
+	Timeout            types.Int64  `tfsdk:"timeout"`
+	Regions            types.List   `tfsdk:"regions"`
+	PrivateLocationIDs types.List   `tfsdk:"private_location_ids"`
+	LastResult         types.String `tfsdk:"last_result"`
+	LastCheckTime      types.String `tfsdk:"last_check_time"`
+	NextCheckTime      types.String `tfsdk:"next_check_time"`
+	IsFlapping         types.Bool   `tfsdk:"is_flapping"`
+	FlapCount1h        types.Int64  `tfsdk:"flap_count_1h"`
+	LastConnectLatency types.Int64  `tfsdk:"last_connect_latency"`
+	CreatedAt          types.String `tfsdk:"created_at"`
+	UpdatedAt          types.String `tfsdk:"updated_at"`
+}
+
+// CompositeCheck represents a check whose status aggregates other checks
+type CompositeCheck struct {
+	ID             types.String `tfsdk:"id"`
+	Name           types.String `tfsdk:"name"`
+	MemberCheckIDs types.List   `tfsdk:"member_check_ids"`
+	Aggregation    types.String `tfsdk:"aggregation"`
+	Status         types.String `tfsdk:"status"`
+	CreatedAt      types.String `tfsdk:"created_at"`
+	UpdatedAt      types.String `tfsdk:"updated_at"`
+}
+
+// ActiveSchedule restricts a check to a weekly window
+type ActiveSchedule struct {
+	Timezone   types.String `tfsdk:"timezone"`
+	DaysOfWeek types.List   `tfsdk:"days_of_week"`
+	StartHour  types.Int64  `tfsdk:"start_hour"`
+	EndHour    types.Int64  `tfsdk:"end_hour"`
+}
+
+// Timeouts overrides how long each Terraform operation on a resource may take
+type Timeouts struct {
+	Create types.String `tfsdk:"create"`
+	Read   types.String `tfsdk:"read"`
+	Update types.String `tfsdk:"update"`
+	Delete types.String `tfsdk:"delete"`
+}
+
+// LastResultDetail summarizes the most recent result of a check
+type LastResultDetail struct {
+	Status       types.String `tfsdk:"status"`
+	ResponseTime types.Int64  `tfsdk:"response_time"`
+	ResponseCode types.Int64  `tfsdk:"response_code"`
+	Message      types.String `tfsdk:"message"`
+}
+
+// lastResultDetailAttrTypes are the attribute types of the last_result_detail object
+var lastResultDetailAttrTypes = map[string]attr.Type{
+	"status":        types.StringType,
+	"response_time": types.Int64Type,
+	"response_code": types.Int64Type,
+	"message":       types.StringType,
+}
+
+// newLastResultDetail builds the last_result_detail object from a check result
+func newLastResultDetail(ctx context.Context, result CheckResult) (types.Object, diag.Diagnostics) {
+	return types.ObjectValueFrom(ctx, lastResultDetailAttrTypes, LastResultDetail{
+		Status:       result.Status,
+		ResponseTime: result.ResponseTime,
+		ResponseCode: result.ResponseCode,
+		Message:      result.Message,
+	})
+}
+
+// EffectiveConfig is an HTTP check's configuration with the API defaults applied
+type EffectiveConfig struct {
+	Method               types.String `tfsdk:"method"`
+	ExpectedStatus       types.Int64  `tfsdk:"expected_status"`
+	Interval             types.Int64  `tfsdk:"interval"`
+	Priority             types.String `tfsdk:"priority"`
+	Timeout              types.Int64  `tfsdk:"timeout"`
+	FollowRedirects      types.Bool   `tfsdk:"follow_redirects"`
+	Retries              types.Int64  `tfsdk:"retries"`
+	StoreResponseBody    types.Bool   `tfsdk:"store_response_body"`
+	BodySearchLimitBytes types.Int64  `tfsdk:"body_search_limit_bytes"`
+}
+
+// effectiveConfigAttrTypes are the attribute types of the effective_config object
+var effectiveConfigAttrTypes = map[string]attr.Type{
+	"method":                  types.StringType,
+	"expected_status":         types.Int64Type,
+	"interval":                types.Int64Type,
+	"priority":                types.StringType,
+	"timeout":                 types.Int64Type,
+	"follow_redirects":        types.BoolType,
+	"retries":                 types.Int64Type,
+	"store_response_body":     types.BoolType,
+	"body_search_limit_bytes": types.Int64Type,
+}
+
+// newEffectiveConfig builds the effective_config object from an HTTP check,
+// substituting the API default for each attribute that is not set
+func newEffectiveConfig(ctx context.Context, check *HTTPCheck) (types.Object, diag.Diagnostics) {
+	return types.ObjectValueFrom(ctx, effectiveConfigAttrTypes, EffectiveConfig{
+		Method:               stringOrDefault(check.Method, "GET"),
+		ExpectedStatus:       int64OrDefault(check.ExpectedStatus, 200),
+		Interval:             int64OrDefault(check.Interval, 60),
+		Priority:             stringOrDefault(check.Priority, "normal"),
+		Timeout:              int64OrDefault(check.Timeout, 10),
+		FollowRedirects:      boolOrDefault(check.FollowRedirects, true),
+		Retries:              int64OrDefault(check.Retries, 0),
+		StoreResponseBody:    boolOrDefault(check.StoreResponseBody, true),
+		BodySearchLimitBytes: types.Int64Value(bodySearchLimit(check)),
+	})
+}
+
+// stringOrDefault returns v, or def when v is null or unknown
+func stringOrDefault(v types.String, def string) types.String {
+	if v.IsNull() || v.IsUnknown() {
+		return types.StringValue(def)
+	}
+	return v
+}
+
+// int64OrDefault returns v, or def when v is null or unknown
+func int64OrDefault(v types.Int64, def int64) types.Int64 {
+	if v.IsNull() || v.IsUnknown() {
+		return types.Int64Value(def)
+	}
+	return v
+}
+
+// boolOrDefault returns v, or def when v is null or unknown
+func boolOrDefault(v types.Bool, def bool) types.Bool {
+	if v.IsNull() || v.IsUnknown() {
+		return types.BoolValue(def)
+	}
+	return v
 }
 
 // CheckResult represents the result of a check execution
@@ -52,15 +340,260 @@ type CheckResult struct {
 	Region        types.String `tfsdk:"region"`
 	ResponseBody  types.String `tfsdk:"response_body"`
 	ResponseCode  types.Int64  `tfsdk:"response_code"`
+	NotModified   types.Bool   `tfsdk:"not_modified"`
+	ResponseSize  types.Int64  `tfsdk:"response_size"`
+	BodySHA256    types.String `tfsdk:"body_sha256"`
+	DNSTime       types.Int64  `tfsdk:"dns_time"`
+	ConnectTime   types.Int64  `tfsdk:"connect_time"`
+	TLSTime       types.Int64  `tfsdk:"tls_time"`
+	TTFB          types.Int64  `tfsdk:"ttfb"`
+	DownloadTime  types.Int64  `tfsdk:"download_time"`
+	FailureReason types.String `tfsdk:"failure_reason"`
+	Labels        types.Map    `tfsdk:"labels"`
+	URLResults    []URLResult  `tfsdk:"url_results"`
+}
+
+// URLResult is the outcome for one URL of an HTTP check that probes several
+type URLResult struct {
+	URL           types.String `tfsdk:"url"`
+	Status        types.String `tfsdk:"status"`
+	ResponseCode  types.Int64  `tfsdk:"response_code"`
+	ResponseTime  types.Int64  `tfsdk:"response_time"`
 	FailureReason types.String `tfsdk:"failure_reason"`
 }
 
 // CheckResultsDataModel represents the data source for check results
 type CheckResultsDataModel struct {
-	ID        types.String   `tfsdk:"id"`
-	CheckID   types.String   `tfsdk:"check_id"`
-	Limit     types.Int64    `tfsdk:"limit"`
-	Results   []CheckResult  `tfsdk:"results"`
-	StartTime types.String   `tfsdk:"start_time"`
-	EndTime   types.String   `tfsdk:"end_time"`
-}
\ No newline at end of file
+	ID         types.String  `tfsdk:"id"`
+	CheckID    types.String  `tfsdk:"check_id"`
+	Limit      types.Int64   `tfsdk:"limit"`
+	SampleRate types.Int64   `tfsdk:"sample_rate"`
+	Results    []CheckResult `tfsdk:"results"`
+	StartTime  types.String  `tfsdk:"start_time"`
+	EndTime    types.String  `tfsdk:"end_time"`
+	RenderCSV  types.Bool    `tfsdk:"render_csv"`
+	CSV        types.String  `tfsdk:"csv"`
+}
+
+// CheckResultsStreamDataModel represents the data source for incremental check results
+type CheckResultsStreamDataModel struct {
+	ID         types.String  `tfsdk:"id"`
+	CheckID    types.String  `tfsdk:"check_id"`
+	Since      types.String  `tfsdk:"since"`
+	NextCursor types.String  `tfsdk:"next_cursor"`
+	Results    []CheckResult `tfsdk:"results"`
+}
+
+// CheckStatsDataModel represents the data source for aggregated check statistics
+type CheckStatsDataModel struct {
+	ID              types.String       `tfsdk:"id"`
+	CheckID         types.String       `tfsdk:"check_id"`
+	PeriodDays      types.Int64        `tfsdk:"period_days"`
+	GroupBy         types.String       `tfsdk:"group_by"`
+	SLOTarget       types.Float64      `tfsdk:"slo_target"`
+	Uptime          types.Float64      `tfsdk:"uptime"`
+	AvgResponseTime types.Float64      `tfsdk:"avg_response_time"`
+	P50ResponseTime types.Int64        `tfsdk:"p50_response_time"`
+	P90ResponseTime types.Int64        `tfsdk:"p90_response_time"`
+	P95ResponseTime types.Int64        `tfsdk:"p95_response_time"`
+	P99ResponseTime types.Int64        `tfsdk:"p99_response_time"`
+	BurnRate1h      types.Float64      `tfsdk:"error_budget_burn_rate_1h"`
+	BurnRate6h      types.Float64      `tfsdk:"error_budget_burn_rate_6h"`
+	Buckets         []CheckStatsBucket `tfsdk:"buckets"`
+}
+
+// CheckStats holds statistics aggregated over a check's results
+type CheckStats struct {
+	Uptime          types.Float64
+	AvgResponseTime types.Float64
+	// Response time percentiles by the nearest-rank method
+	P50ResponseTime types.Int64
+	P90ResponseTime types.Int64
+	P95ResponseTime types.Int64
+	P99ResponseTime types.Int64
+	Buckets         []CheckStatsBucket
+	FlapCount1h     types.Int64
+	IsFlapping      types.Bool
+	// ErrorRate1h and ErrorRate6h are the fractions of failed results over
+	// the last hour and six hours, null when there were no results
+	ErrorRate1h types.Float64
+	ErrorRate6h types.Float64
+}
+
+// CheckStatsBucket holds statistics for one hour or day of results
+type CheckStatsBucket struct {
+	Start           types.String  `tfsdk:"start"`
+	ResultCount     types.Int64   `tfsdk:"result_count"`
+	Uptime          types.Float64 `tfsdk:"uptime"`
+	AvgResponseTime types.Float64 `tfsdk:"avg_response_time"`
+}
+
+// CheckDriftDataModel represents the data source for the configuration the API holds for a check
+type CheckDriftDataModel struct {
+	ID           types.String `tfsdk:"id"`
+	ResourceType types.String `tfsdk:"resource_type"`
+	Config       types.Map    `tfsdk:"config"`
+}
+
+// CheckHistoryDataModel represents the data source for a check's configuration at a point in time
+type CheckHistoryDataModel struct {
+	ID           types.String `tfsdk:"id"`
+	At           types.String `tfsdk:"at"`
+	RecordedAt   types.String `tfsdk:"recorded_at"`
+	ResourceType types.String `tfsdk:"resource_type"`
+	Config       types.Map    `tfsdk:"config"`
+}
+
+// LatestResultDataModel represents the data source for the most recent result of a check
+type LatestResultDataModel struct {
+	ID      types.String `tfsdk:"id"`
+	CheckID types.String `tfsdk:"check_id"`
+	Result  *CheckResult `tfsdk:"result"`
+}
+
+// IncidentsDataModel represents the data source for a check's incident history
+type IncidentsDataModel struct {
+	ID        types.String `tfsdk:"id"`
+	CheckID   types.String `tfsdk:"check_id"`
+	Incidents []Incident   `tfsdk:"incidents"`
+}
+
+// Incident is a period during which a check was failing
+type Incident struct {
+	ID         types.String `tfsdk:"id"`
+	StartedAt  types.String `tfsdk:"started_at"`
+	ResolvedAt types.String `tfsdk:"resolved_at"`
+	Duration   types.Int64  `tfsdk:"duration"`
+	Cause      types.String `tfsdk:"cause"`
+	RunbookURL types.String `tfsdk:"runbook_url"`
+}
+
+// GroupStatsDataModel represents the data source for statistics across a group of checks
+type GroupStatsDataModel struct {
+	ID             types.String  `tfsdk:"id"`
+	GroupID        types.String  `tfsdk:"group_id"`
+	PeriodDays     types.Int64   `tfsdk:"period_days"`
+	MemberCount    types.Int64   `tfsdk:"member_count"`
+	Uptime         types.Float64 `tfsdk:"uptime"`
+	WorstCheckID   types.String  `tfsdk:"worst_check_id"`
+	WorstCheckName types.String  `tfsdk:"worst_check_name"`
+	WorstUptime    types.Float64 `tfsdk:"worst_uptime"`
+}
+
+// GroupStats holds statistics aggregated across the members of a check group
+type GroupStats struct {
+	MemberCount    int
+	Uptime         types.Float64
+	WorstCheckID   types.String
+	WorstCheckName types.String
+	WorstUptime    types.Float64
+}
+
+// APIInfo describes the CloudCanary API
+type APIInfo struct {
+	APIVersion string
+	CheckTypes []string
+	// OrganizationRequired is set for accounts whose API calls must name an organization
+	OrganizationRequired bool
+}
+
+// APIInfoDataModel represents the data source for API and provider version information
+type APIInfoDataModel struct {
+	ID              types.String `tfsdk:"id"`
+	APIVersion      types.String `tfsdk:"api_version"`
+	ProviderVersion types.String `tfsdk:"provider_version"`
+	CheckTypes      types.List   `tfsdk:"check_types"`
+}
+
+// Quota describes the limits of the account's plan
+type Quota struct {
+	ChecksUsed       int
+	ChecksLimit      int
+	RegionsAvailable []string
+}
+
+// QuotaDataModel represents the data source for the account's check quota
+type QuotaDataModel struct {
+	ID               types.String `tfsdk:"id"`
+	ChecksUsed       types.Int64  `tfsdk:"checks_used"`
+	ChecksLimit      types.Int64  `tfsdk:"checks_limit"`
+	RegionsAvailable types.List   `tfsdk:"regions_available"`
+}
+
+// CheckSummary is the listing entry for a check of any type
+type CheckSummary struct {
+	ID          string
+	Name        string
+	Type        string
+	Environment string
+	LastResult  string
+	Tags        map[string]string
+}
+
+// CheckImportCandidatesDataModel represents the data source listing checks that could be imported
+type CheckImportCandidatesDataModel struct {
+	ID         types.String           `tfsdk:"id"`
+	ManagedIDs types.List             `tfsdk:"managed_ids"`
+	Candidates []CheckImportCandidate `tfsdk:"candidates"`
+}
+
+// CheckImportCandidate is a check that isn't managed yet, with the resource type to import it as
+type CheckImportCandidate struct {
+	ID           types.String `tfsdk:"id"`
+	Name         types.String `tfsdk:"name"`
+	ResourceType types.String `tfsdk:"resource_type"`
+}
+
+// HealthSummaryDataModel represents the data source for the health of all checks
+type HealthSummaryDataModel struct {
+	ID          types.String `tfsdk:"id"`
+	Environment types.String `tfsdk:"environment"`
+	Total       types.Int64  `tfsdk:"total"`
+	Up          types.Int64  `tfsdk:"up"`
+	Down        types.Int64  `tfsdk:"down"`
+	Degraded    types.Int64  `tfsdk:"degraded"`
+	WorstStatus types.String `tfsdk:"worst_status"`
+}
+
+// PrometheusMetricsDataModel represents the data source rendering check status as Prometheus metrics
+type PrometheusMetricsDataModel struct {
+	ID          types.String `tfsdk:"id"`
+	Environment types.String `tfsdk:"environment"`
+	Metrics     types.String `tfsdk:"metrics"`
+}
+
+// CheckCleanup represents a bulk deletion of the checks carrying a tag
+type CheckCleanup struct {
+	ID              types.String `tfsdk:"id"`
+	TagKey          types.String `tfsdk:"tag_key"`
+	TagValue        types.String `tfsdk:"tag_value"`
+	Confirm         types.Bool   `tfsdk:"confirm"`
+	Trigger         types.String `tfsdk:"trigger"`
+	DeletedCount    types.Int64  `tfsdk:"deleted_count"`
+	DeletedCheckIDs types.List   `tfsdk:"deleted_check_ids"`
+}
+
+// PostDeployCheckDataModel represents the data source running a check once per trigger value
+type PostDeployCheckDataModel struct {
+	ID            types.String `tfsdk:"id"`
+	CheckID       types.String `tfsdk:"check_id"`
+	Trigger       types.String `tfsdk:"trigger"`
+	Status        types.String `tfsdk:"status"`
+	ResponseTime  types.Int64  `tfsdk:"response_time"`
+	ResponseCode  types.Int64  `tfsdk:"response_code"`
+	Message       types.String `tfsdk:"message"`
+	FailureReason types.String `tfsdk:"failure_reason"`
+	ProbedAt      types.String `tfsdk:"probed_at"`
+}
+
+// CheckProbe represents an on-demand run of an existing check
+type CheckProbe struct {
+	ID            types.String `tfsdk:"id"`
+	CheckID       types.String `tfsdk:"check_id"`
+	Trigger       types.String `tfsdk:"trigger"`
+	Status        types.String `tfsdk:"status"`
+	ResponseTime  types.Int64  `tfsdk:"response_time"`
+	Message       types.String `tfsdk:"message"`
+	FailureReason types.String `tfsdk:"failure_reason"`
+	ProbedAt      types.String `tfsdk:"probed_at"`
+}
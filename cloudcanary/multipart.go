@@ -0,0 +1,82 @@
+package cloudcanary
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// newMultipartBody encodes form fields and files as a multipart/form-data body,
+// returning the body and the Content-Type header carrying its boundary
+func newMultipartBody(fields, files map[string]string) (string, string, error) {
+	var buf bytes.Buffer
+	writer := multipart.NewWriter(&buf)
+
+	// Write parts in a stable order so identical inputs produce identical bodies
+	for _, name := range sortedKeys(fields) {
+		if err := writer.WriteField(name, fields[name]); err != nil {
+			return "", "", err
+		}
+	}
+
+	for _, name := range sortedKeys(files) {
+		if err := writeFormFile(writer, name, files[name]); err != nil {
+			return "", "", err
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		return "", "", err
+	}
+
+	return buf.String(), writer.FormDataContentType(), nil
+}
+
+// writeFormFile copies a local file into a new file part of the form
+func writeFormFile(writer *multipart.Writer, name, filePath string) error {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return fmt.Errorf("could not open form file %q: %w", name, err)
+	}
+	defer f.Close()
+
+	part, err := writer.CreateFormFile(name, filepath.Base(filePath))
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(part, f)
+	return err
+}
+
+// hashFile returns the hex encoded SHA-256 digest of a file's contents
+func hashFile(filePath string) (string, error) {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// sortedKeys returns the keys of m in ascending order
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,164 @@
+package cloudcanary
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// normalizeJSONBodyModifier suppresses diffs between JSON bodies that only differ in formatting
+type normalizeJSONBodyModifier struct{}
+
+// normalizeJSONBody returns a plan modifier that keeps the prior body when it is
+// equivalent JSON to the configured body and normalize_json_body is enabled
+func normalizeJSONBody() planmodifier.String {
+	return normalizeJSONBodyModifier{}
+}
+
+// Description returns a plain text description of the modifier's behavior
+func (m normalizeJSONBodyModifier) Description(_ context.Context) string {
+	return "When normalize_json_body is true, JSON bodies that differ only in whitespace or key order do not produce a diff."
+}
+
+// MarkdownDescription returns a markdown formatted description of the modifier's behavior
+func (m normalizeJSONBodyModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+// PlanModifyString compares the canonical forms of the configured and prior bodies
+func (m normalizeJSONBodyModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	// Nothing to compare against on create, or when either side is not yet known
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() || req.StateValue.IsNull() || req.StateValue.IsUnknown() {
+		return
+	}
+
+	var enabled types.Bool
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("normalize_json_body"), &enabled)...)
+	if resp.Diagnostics.HasError() || !enabled.ValueBool() {
+		return
+	}
+
+	// Leave non-JSON bodies untouched
+	configJSON, err := canonicalJSON(req.ConfigValue.ValueString())
+	if err != nil {
+		return
+	}
+	stateJSON, err := canonicalJSON(req.StateValue.ValueString())
+	if err != nil {
+		return
+	}
+
+	// Terraform only lets a provider deviate from a configured value by
+	// returning the prior value unchanged, so keep the state when equivalent
+	if configJSON == stateJSON {
+		resp.PlanValue = req.StateValue
+	}
+}
+
+// ignoreOrderModifier suppresses diffs between lists that hold the same elements in a different order
+type ignoreOrderModifier struct{}
+
+// ignoreOrder returns a plan modifier that keeps the prior list when the
+// configured one only reorders it, for lists such as regions whose order the
+// API ignores
+func ignoreOrder() planmodifier.List {
+	return ignoreOrderModifier{}
+}
+
+// Description returns a plain text description of the modifier's behavior
+func (m ignoreOrderModifier) Description(_ context.Context) string {
+	return "Reordering the list does not produce a diff."
+}
+
+// MarkdownDescription returns a markdown formatted description of the modifier's behavior
+func (m ignoreOrderModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+// PlanModifyList compares the sorted elements of the configured and prior lists
+func (m ignoreOrderModifier) PlanModifyList(_ context.Context, req planmodifier.ListRequest, resp *planmodifier.ListResponse) {
+	// Nothing to compare against on create, or when either side is not yet known
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() || req.StateValue.IsNull() || req.StateValue.IsUnknown() {
+		return
+	}
+
+	// listStrings drops unknown elements, which could still be anything
+	configValues := listStrings(req.ConfigValue)
+	stateValues := listStrings(req.StateValue)
+	if len(configValues) != len(req.ConfigValue.Elements()) || len(configValues) != len(stateValues) {
+		return
+	}
+
+	sort.Strings(configValues)
+	sort.Strings(stateValues)
+	for i := range configValues {
+		if configValues[i] != stateValues[i] {
+			return
+		}
+	}
+
+	// As with JSON bodies, the prior value is the only deviation from the
+	// configuration Terraform accepts, so sorting the plan is not an option
+	resp.PlanValue = req.StateValue
+}
+
+// formFileHashesModifier plans the content hashes of the configured form files
+type formFileHashesModifier struct{}
+
+// hashFormFiles returns a plan modifier that hashes each file in form_files so
+// that changing a file's contents produces a diff even though its path is unchanged
+func hashFormFiles() planmodifier.Map {
+	return formFileHashesModifier{}
+}
+
+// Description returns a plain text description of the modifier's behavior
+func (m formFileHashesModifier) Description(_ context.Context) string {
+	return "Plans the SHA-256 hash of each file in form_files."
+}
+
+// MarkdownDescription returns a markdown formatted description of the modifier's behavior
+func (m formFileHashesModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+// PlanModifyMap reads each configured form file and plans its current hash
+func (m formFileHashesModifier) PlanModifyMap(ctx context.Context, req planmodifier.MapRequest, resp *planmodifier.MapResponse) {
+	// Nothing to plan while the resource is being destroyed
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var files types.Map
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("form_files"), &files)...)
+	if resp.Diagnostics.HasError() || files.IsUnknown() {
+		return
+	}
+
+	if files.IsNull() {
+		resp.PlanValue = types.MapNull(types.StringType)
+		return
+	}
+
+	hashes := make(map[string]attr.Value)
+	for name, filePath := range mapStrings(files) {
+		sum, err := hashFile(filePath)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("form_files").AtMapKey(name),
+				"Error Reading Form File",
+				fmt.Sprintf("Could not hash form file %s: %s", filePath, err),
+			)
+			return
+		}
+		hashes[name] = types.StringValue(sum)
+	}
+
+	planValue, diags := types.MapValue(types.StringType, hashes)
+	resp.Diagnostics.Append(diags...)
+	resp.PlanValue = planValue
+}
@@ -0,0 +1,57 @@
+package cloudcanary
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestIgnoreOrder(t *testing.T) {
+	nullList := types.ListNull(types.StringType)
+	unknownList := types.ListUnknown(types.StringType)
+	partlyUnknown := types.ListValueMust(types.StringType, []attr.Value{types.StringValue("us-east-1"), types.StringUnknown()})
+
+	tests := []struct {
+		name     string
+		config   types.List
+		state    types.List
+		wantPlan string // "state" or "config"
+	}{
+		{name: "reordered", config: stringList("eu-west-1", "us-east-1"), state: stringList("us-east-1", "eu-west-1"), wantPlan: "state"},
+		{name: "reordered with duplicates", config: stringList("b", "a", "a"), state: stringList("a", "b", "a"), wantPlan: "state"},
+		{name: "unchanged", config: stringList("us-east-1", "eu-west-1"), state: stringList("us-east-1", "eu-west-1"), wantPlan: "state"},
+		{name: "element replaced", config: stringList("us-east-1", "ap-southeast-1"), state: stringList("us-east-1", "eu-west-1"), wantPlan: "config"},
+		{name: "element added", config: stringList("us-east-1", "eu-west-1", "us-west-2"), state: stringList("eu-west-1", "us-east-1"), wantPlan: "config"},
+		{name: "element removed", config: stringList("us-east-1"), state: stringList("us-east-1", "eu-west-1"), wantPlan: "config"},
+		{name: "duplicate counts differ", config: stringList("a", "a", "b"), state: stringList("a", "b", "b"), wantPlan: "config"},
+		{name: "emptied", config: stringList(), state: stringList("us-east-1"), wantPlan: "config"},
+		{name: "both empty", config: stringList(), state: stringList(), wantPlan: "state"},
+		{name: "null config", config: nullList, state: stringList("us-east-1"), wantPlan: "config"},
+		{name: "unknown config", config: unknownList, state: stringList("us-east-1"), wantPlan: "config"},
+		{name: "unknown element", config: partlyUnknown, state: stringList("us-east-1", "eu-west-1"), wantPlan: "config"},
+		{name: "create", config: stringList("us-east-1", "eu-west-1"), state: nullList, wantPlan: "config"},
+		{name: "unknown state", config: stringList("us-east-1"), state: unknownList, wantPlan: "config"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := planmodifier.ListRequest{ConfigValue: tt.config, StateValue: tt.state, PlanValue: tt.config}
+			resp := planmodifier.ListResponse{PlanValue: req.PlanValue}
+			ignoreOrder().PlanModifyList(context.Background(), req, &resp)
+
+			want := tt.config
+			if tt.wantPlan == "state" {
+				want = tt.state
+			}
+			if !resp.PlanValue.Equal(want) {
+				t.Errorf("planned %s, want the %s value %s", resp.PlanValue, tt.wantPlan, want)
+			}
+			if resp.Diagnostics.HasError() {
+				t.Errorf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+		})
+	}
+}
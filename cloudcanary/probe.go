@@ -0,0 +1,1425 @@
+package cloudcanary
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"net/http/httptrace"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// maxProbeBodyBytes caps how much of a response body a probe captures
+const maxProbeBodyBytes = 1 << 20
+
+// probeResponse holds the parts of an HTTP response that check assertions are evaluated against
+type probeResponse struct {
+	statusCode   int
+	header       http.Header
+	body         []byte
+	size         int64
+	responseTime time.Duration
+	// finalHopTime is the part of responseTime spent on the last request of a redirect chain
+	finalHopTime time.Duration
+	phases       probePhases
+	// certExpiry is when the server's leaf certificate expires; zero without TLS
+	certExpiry time.Time
+	// bodySHA256 is the hex SHA-256 of the whole body; empty when the probe stopped reading early
+	bodySHA256 string
+	// cipherSuite is the negotiated TLS cipher suite; zero without TLS
+	cipherSuite uint16
+	// connReused is whether the first request went over a kept-alive connection
+	connReused bool
+	// streamed is whether the body was sent chunked, or without a length over HTTP/2
+	streamed bool
+	// trailer holds the trailers sent after the body; nil when the probe
+	// stopped reading before the end of the body
+	trailer http.Header
+}
+
+// probePhases breaks a probe's response time down by phase. Phases repeated
+// across redirects are summed; ttfb is measured from the start of the probe
+// to the first byte of the final response.
+type probePhases struct {
+	dns      time.Duration
+	connect  time.Duration
+	tls      time.Duration
+	ttfb     time.Duration
+	download time.Duration
+}
+
+// phaseTracer records phase timings from httptrace callbacks, which may
+// arrive concurrently when several addresses are dialed
+type phaseTracer struct {
+	mu           sync.Mutex
+	start        time.Time
+	dnsStart     time.Time
+	connectStart time.Time
+	tlsStart     time.Time
+	hopStart     time.Time
+	phases       probePhases
+	gotConn      bool
+	connReused   bool
+}
+
+// clientTrace returns the httptrace hooks that feed the tracer
+func (t *phaseTracer) clientTrace() *httptrace.ClientTrace {
+	record := func(from *time.Time, into *time.Duration) {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+		if !from.IsZero() {
+			*into += time.Since(*from)
+			*from = time.Time{}
+		}
+	}
+	mark := func(at *time.Time) {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+		*at = time.Now()
+	}
+
+	return &httptrace.ClientTrace{
+		// Every request of a redirect chain starts by getting a connection
+		GetConn: func(string) { mark(&t.hopStart) },
+		// Only the connection of the first request says whether keep-alive works
+		GotConn: func(info httptrace.GotConnInfo) {
+			t.mu.Lock()
+			defer t.mu.Unlock()
+			if !t.gotConn {
+				t.gotConn = true
+				t.connReused = info.Reused
+			}
+		},
+		DNSStart:          func(httptrace.DNSStartInfo) { mark(&t.dnsStart) },
+		DNSDone:           func(httptrace.DNSDoneInfo) { record(&t.dnsStart, &t.phases.dns) },
+		ConnectStart:      func(string, string) { mark(&t.connectStart) },
+		ConnectDone:       func(string, string, error) { record(&t.connectStart, &t.phases.connect) },
+		TLSHandshakeStart: func() { mark(&t.tlsStart) },
+		TLSHandshakeDone:  func(tls.ConnectionState, error) { record(&t.tlsStart, &t.phases.tls) },
+		GotFirstResponseByte: func() {
+			t.mu.Lock()
+			defer t.mu.Unlock()
+			t.phases.ttfb = time.Since(t.start)
+		},
+	}
+}
+
+// maxAdditionalURLs caps how many additional_urls an HTTP check probes alongside its url
+const maxAdditionalURLs = 20
+
+// probeHTTPCheck executes an HTTP check from the provider host and evaluates
+// its assertions. With additional_urls, every URL is probed in turn and the
+// statuses are combined according to match_mode.
+func (c *cloudCanaryClient) probeHTTPCheck(ctx context.Context, check *HTTPCheck) CheckResult {
+	additional := listStrings(check.AdditionalURLs)
+	if len(additional) == 0 {
+		return c.probeHTTPURL(ctx, check)
+	}
+
+	urls := append([]string{check.URL.ValueString()}, additional...)
+	breakdown := make([]URLResult, 0, len(urls))
+	statuses := make([]string, 0, len(urls))
+	var failures []string
+	var degraded bool
+	var result CheckResult
+	for i, u := range urls {
+		target := *check
+		target.URL = types.StringValue(u)
+		urlResult := c.probeHTTPURL(ctx, &target)
+
+		// The url's own result carries the timings and body
+		if i == 0 {
+			result = urlResult
+		}
+		breakdown = append(breakdown, URLResult{
+			URL:           target.URL,
+			Status:        urlResult.Status,
+			ResponseCode:  urlResult.ResponseCode,
+			ResponseTime:  urlResult.ResponseTime,
+			FailureReason: urlResult.FailureReason,
+		})
+
+		// A degraded URL still answered as expected, so it counts as passing
+		status := urlResult.Status.ValueString()
+		if status == "DEGRADED" {
+			degraded = true
+			status = "SUCCESS"
+		}
+		statuses = append(statuses, status)
+		if !urlResult.FailureReason.IsNull() {
+			failures = append(failures, fmt.Sprintf("%s: %s", u, urlResult.FailureReason.ValueString()))
+		}
+	}
+
+	matchMode := "all"
+	if !check.MatchMode.IsNull() {
+		matchMode = check.MatchMode.ValueString()
+	}
+
+	result.URLResults = breakdown
+	status := aggregateStatus(matchMode, statuses)
+	if status == "SUCCESS" && degraded {
+		status = "DEGRADED"
+	}
+	result.Status = types.StringValue(status)
+	result.Message = types.StringValue(fmt.Sprintf("%d of %d URLs passed", len(urls)-len(failures), len(urls)))
+	result.FailureReason = types.StringNull()
+	if status == "FAILURE" {
+		result.FailureReason = types.StringValue(strings.Join(failures, "; "))
+	}
+
+	return result
+}
+
+// probeHTTPURL probes the url of an HTTP check, ignoring additional_urls
+func (c *cloudCanaryClient) probeHTTPURL(ctx context.Context, check *HTTPCheck) CheckResult {
+	checkID := check.ID.ValueString()
+
+	// Apply the same defaults the API would use
+	method := "GET"
+	if !check.Method.IsNull() {
+		method = check.Method.ValueString()
+	}
+	timeout := 10 * time.Second
+	if !check.Timeout.IsNull() {
+		timeout = time.Duration(check.Timeout.ValueInt64()) * time.Second
+	}
+
+	// max_probe_duration caps every attempt and body read together, so a
+	// server trickling its response can't hold the probe open
+	parentCtx := ctx
+	if !check.MaxProbeDuration.IsNull() {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(check.MaxProbeDuration.ValueInt64())*time.Second)
+		defer cancel()
+	}
+
+	// Form fields and files replace the body with a multipart/form-data encoding
+	body := check.Body
+	var formContentType string
+	if !check.FormFields.IsNull() || !check.FormFiles.IsNull() {
+		form, contentType, err := newMultipartBody(mapStrings(check.FormFields), mapStrings(check.FormFiles))
+		if err != nil {
+			return newProbeResult(checkID, nil, fmt.Sprintf("could not build form body: %s", err))
+		}
+		body = types.StringValue(form)
+		formContentType = contentType
+	}
+
+	// When looking for a keyword, stop reading as soon as it is found or the search limit is hit
+	var readBody bodyReader
+	if !check.ExpectedResponse.IsNull() {
+		readBody = readUntilMatch([]byte(check.ExpectedResponse.ValueString()), bodySearchLimit(check))
+	}
+
+	// Size bounds, body hashes and trailers need the whole body read, not just the
+	// captured part, as does returning the connection to the keep-alive pool.
+	// Past max_response_size there is no need to keep reading.
+	if !check.MinResponseSize.IsNull() || !check.MaxResponseSize.IsNull() || !check.ExpectedBodySHA256.IsNull() || !check.ExpectedTrailers.IsNull() || check.AssertConnectionReused.ValueBool() {
+		limit := int64(-1)
+		if !check.MaxResponseSize.IsNull() {
+			limit = check.MaxResponseSize.ValueInt64() + 1
+		}
+		readBody = measureBody(readBody, limit)
+	}
+
+	// Flaky proxies sometimes answer with an empty 2xx body; when content is
+	// expected, such responses may be retried up to retries times
+	attempts := 1
+	if check.RetryOnEmptyBody.ValueBool() && !check.ExpectedResponse.IsNull() {
+		attempts += int(check.Retries.ValueInt64())
+	}
+
+	httpClient, err := newHTTPProbeClient(check, timeout)
+	if err != nil {
+		return newProbeResult(checkID, nil, fmt.Sprintf("could not configure TLS: %s", err))
+	}
+
+	// Keep the transport, and its idle connections, for the next probe
+	keptAlive := false
+	if check.AssertConnectionReused.ValueBool() {
+		httpClient.Transport, keptAlive = c.keepAlive.transport(checkID, check.URL.ValueString(), keepAliveKey(check), httpClient.Transport)
+	} else {
+		// Nothing reuses this probe's transport, so don't leave its connection idle
+		c.keepAlive.drop(checkID)
+		defer closeIdleConnections(httpClient.Transport)
+	}
+	var resp *probeResponse
+	for attempt := 1; ; attempt++ {
+		// The request body is consumed by each attempt, so build a fresh request
+		req, err := newHTTPCheckRequest(ctx, check, method, body, formContentType)
+		if err != nil {
+			return newProbeResult(checkID, nil, fmt.Sprintf("could not build request: %s", err))
+		}
+
+		resp, err = c.doProbe(req, httpClient, readBody)
+		if err != nil {
+			reason := err.Error()
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) && parentCtx.Err() == nil {
+				reason = fmt.Sprintf("probe exceeded max duration of %ds: %s", check.MaxProbeDuration.ValueInt64(), err)
+			} else if !check.AllowedCipherSuites.IsNull() && isHandshakeFailure(err) {
+				reason = fmt.Sprintf("TLS handshake failed, the server may support none of allowed_cipher_suites: %s", err)
+			}
+			return newProbeResult(checkID, nil, reason)
+		}
+		emptySuccess := resp.statusCode/100 == 2 && len(resp.body) == 0
+		if !emptySuccess || attempt >= attempts {
+			break
+		}
+
+		tflog.Debug(ctx, "Retrying HTTP check after empty response body", map[string]any{
+			"id":      checkID,
+			"attempt": attempt,
+		})
+	}
+
+	// Report only the last hop of a redirect chain when asked to
+	if check.ResponseTimeMode.ValueString() == "final" {
+		resp.responseTime = resp.finalHopTime
+	}
+
+	result := newProbeResult(checkID, resp, evaluateHTTPCheck(check, resp))
+	if warning := certificateExpiryWarning(check, resp, time.Now()); warning != "" && result.Status.ValueString() == "SUCCESS" {
+		result.Status = types.StringValue("DEGRADED")
+		result.Message = types.StringValue(warning)
+	}
+
+	// The first probe has no earlier connection it could have reused
+	if keptAlive && !resp.connReused && result.Status.ValueString() == "SUCCESS" {
+		result.Status = types.StringValue("DEGRADED")
+		result.Message = types.StringValue("connection was not reused from the previous probe; the server may have closed it or not support keep-alive")
+	}
+	if !check.StoreResponseBody.IsNull() && !check.StoreResponseBody.ValueBool() {
+		result.ResponseBody = types.StringNull()
+	}
+
+	tflog.Debug(ctx, "Probed HTTP check", map[string]any{
+		"id":     checkID,
+		"status": result.Status.ValueString(),
+	})
+
+	return result
+}
+
+// newHTTPCheckRequest builds the request sent by an HTTP check probe. A
+// non-empty formContentType replaces any configured Content-Type header.
+func newHTTPCheckRequest(ctx context.Context, check *HTTPCheck, method string, body types.String, formContentType string) (*http.Request, error) {
+	req, err := newProbeRequest(ctx, method, check.URL.ValueString(), check.Headers, body, check.PreserveHeaderCase.ValueBool())
+	if err != nil {
+		return nil, err
+	}
+	if formContentType != "" {
+		// Drop any configured Content-Type, whatever its casing, so the boundary wins
+		for name := range req.Header {
+			if strings.EqualFold(name, "Content-Type") {
+				delete(req.Header, name)
+			}
+		}
+		req.Header.Set("Content-Type", formContentType)
+	}
+
+	// Conditional headers exercise the server's cache validation
+	if !check.IfModifiedSince.IsNull() {
+		req.Header.Set("If-Modified-Since", check.IfModifiedSince.ValueString())
+	}
+	if !check.IfNoneMatch.IsNull() {
+		req.Header.Set("If-None-Match", check.IfNoneMatch.ValueString())
+	}
+
+	setAccept(req, check.Accept)
+
+	if check.ExpectContinue.ValueBool() && req.Body != nil {
+		req.Header.Set("Expect", "100-continue")
+	}
+
+	// Sort the names so the Cookie header is the same on every probe
+	cookies := mapStrings(check.Cookies)
+	names := make([]string, 0, len(cookies))
+	for name := range cookies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		req.AddCookie(&http.Cookie{Name: name, Value: cookies[name]})
+	}
+
+	if !check.BasicAuthUsername.IsNull() {
+		req.SetBasicAuth(check.BasicAuthUsername.ValueString(), check.BasicAuthPassword.ValueString())
+	}
+
+	return req, nil
+}
+
+// expectContinueTimeout is how long a probe waits for 100 Continue before sending the body anyway
+const expectContinueTimeout = time.Second
+
+// maxProbeRedirects matches the redirect limit of Go's default HTTP client
+const maxProbeRedirects = 10
+
+// newHTTPProbeClient returns the HTTP client used to probe an HTTP check, applying its redirect policy
+func newHTTPProbeClient(check *HTTPCheck, timeout time.Duration) (*http.Client, error) {
+	followRedirects := check.FollowRedirects.IsNull() || check.FollowRedirects.ValueBool()
+	forwardAuth := check.ForwardAuthOnRedirect.ValueBool()
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if check.ExpectContinue.ValueBool() {
+		transport.ExpectContinueTimeout = expectContinueTimeout
+	}
+	if !check.DNSResolver.IsNull() || !check.SourceIP.IsNull() || !check.ConnectTimeout.IsNull() {
+		dialer := newProbeDialer(check.DNSResolver.ValueString(), check.SourceIP.ValueString())
+		if !check.ConnectTimeout.IsNull() {
+			dialer.Timeout = time.Duration(check.ConnectTimeout.ValueInt64()) * time.Second
+		}
+		transport.DialContext = dialer.DialContext
+	}
+	if !check.ReadTimeout.IsNull() {
+		transport.ResponseHeaderTimeout = time.Duration(check.ReadTimeout.ValueInt64()) * time.Second
+	}
+	tlsConfig, err := newProbeTLSConfig(check)
+	if err != nil {
+		return nil, err
+	}
+	if tlsConfig != nil {
+		transport.TLSClientConfig = tlsConfig
+	}
+
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if !followRedirects {
+				return http.ErrUseLastResponse
+			}
+			if len(via) >= maxProbeRedirects {
+				return fmt.Errorf("stopped after %d redirects", maxProbeRedirects)
+			}
+
+			// net/http strips Authorization when redirecting to another host;
+			// restore it from the original request only when explicitly allowed
+			if forwardAuth {
+				if auth := via[0].Header.Get("Authorization"); auth != "" {
+					req.Header.Set("Authorization", auth)
+				}
+			}
+
+			return nil
+		},
+	}, nil
+}
+
+// transportPool keeps one transport per check URL across probes so that
+// keep-alive connections outlive a single probe. The zero value is ready to use.
+type transportPool struct {
+	mu sync.Mutex
+	// transports holds the kept transports by check ID, then URL
+	transports map[string]map[string]keptTransport
+}
+
+// keptTransport is a transport kept for a check URL, along with the key of
+// the settings it was built with
+type keptTransport struct {
+	key       string
+	transport http.RoundTripper
+}
+
+// transport returns the transport kept for the check URL, keeping fresh for
+// later probes when there is none yet. A transport built with other settings
+// is replaced and its idle connections closed. It reports whether the
+// transport was kept from an earlier probe.
+func (p *transportPool) transport(checkID, url, key string, fresh http.RoundTripper) (http.RoundTripper, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	kept, ok := p.transports[checkID][url]
+	if ok && kept.key == key {
+		return kept.transport, true
+	}
+	if ok {
+		closeIdleConnections(kept.transport)
+	}
+	if p.transports == nil {
+		p.transports = make(map[string]map[string]keptTransport)
+	}
+	if p.transports[checkID] == nil {
+		p.transports[checkID] = make(map[string]keptTransport)
+	}
+	p.transports[checkID][url] = keptTransport{key: key, transport: fresh}
+	return fresh, false
+}
+
+// drop forgets the transports kept for a check and closes their idle
+// connections, once the check is deleted or stops asserting connection reuse
+func (p *transportPool) drop(checkID string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, kept := range p.transports[checkID] {
+		closeIdleConnections(kept.transport)
+	}
+	delete(p.transports, checkID)
+}
+
+// closeIdleConnections closes the idle connections of transports that keep them
+func closeIdleConnections(rt http.RoundTripper) {
+	if t, ok := rt.(interface{ CloseIdleConnections() }); ok {
+		t.CloseIdleConnections()
+	}
+}
+
+// connPool keeps one open connection per TCP check across probes, for checks
+// with send_keepalive. The zero value is ready to use.
+type connPool struct {
+	mu    sync.Mutex
+	conns map[string]keptConn
+}
+
+// keptConn is a connection kept for a check, along with the key of the
+// settings it was opened with
+type keptConn struct {
+	key  string
+	conn net.Conn
+}
+
+// take removes and returns the connection kept for a check, if it was opened
+// with the same settings. One opened with other settings is closed instead.
+func (p *connPool) take(checkID, key string) net.Conn {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	kept, ok := p.conns[checkID]
+	if !ok {
+		return nil
+	}
+	delete(p.conns, checkID)
+	if kept.key != key {
+		kept.conn.Close()
+		return nil
+	}
+	return kept.conn
+}
+
+// put keeps conn for the check's next probe. A connection a concurrent probe
+// kept in the meantime is closed.
+func (p *connPool) put(checkID, key string, conn net.Conn) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if kept, ok := p.conns[checkID]; ok && kept.conn != conn {
+		kept.conn.Close()
+	}
+	if p.conns == nil {
+		p.conns = make(map[string]keptConn)
+	}
+	p.conns[checkID] = keptConn{key: key, conn: conn}
+}
+
+// drop closes and forgets the connection kept for a check, once the check is
+// deleted or stops sending keepalives
+func (p *connPool) drop(checkID string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if kept, ok := p.conns[checkID]; ok {
+		kept.conn.Close()
+		delete(p.conns, checkID)
+	}
+}
+
+// tcpKeepAliveKey identifies the settings a TCP check's kept connection was opened with
+func tcpKeepAliveKey(check *TCPCheck, address string) string {
+	return strings.Join([]string{address, check.KeepaliveInterval.String()}, "\x00")
+}
+
+// connClosed reports whether the peer has closed conn, or it was otherwise
+// broken, since it was last used. Data already waiting on the connection is
+// taken as a sign it is alive and discarded.
+func connClosed(conn net.Conn) error {
+	_ = conn.SetReadDeadline(time.Now().Add(10 * time.Millisecond))
+	defer conn.SetReadDeadline(time.Time{})
+
+	buf := make([]byte, 512)
+	_, err := conn.Read(buf)
+	var netErr net.Error
+	if err == nil || (errors.As(err, &netErr) && netErr.Timeout()) {
+		return nil
+	}
+	return err
+}
+
+// keepAliveKey identifies the settings a check URL's kept transport was built
+// with. It covers every setting newHTTPProbeClient builds the transport from,
+// so a changed setting starts a fresh transport rather than reusing one built
+// for the old value.
+func keepAliveKey(check *HTTPCheck) string {
+	return strings.Join([]string{
+		check.DNSResolver.ValueString(),
+		check.SourceIP.ValueString(),
+		check.TLSServerName.ValueString(),
+		strings.Join(listStrings(check.AllowedCipherSuites), ","),
+		strconv.FormatBool(check.ExpectContinue.ValueBool()),
+		check.ConnectTimeout.String(),
+		check.ReadTimeout.String(),
+	}, "\x00")
+}
+
+// newProbeTLSConfig returns the TLS settings of an HTTP check probe, or nil
+// when the check leaves them at their defaults
+func newProbeTLSConfig(check *HTTPCheck) (*tls.Config, error) {
+	if check.TLSServerName.IsNull() && check.AllowedCipherSuites.IsNull() {
+		return nil, nil
+	}
+
+	config := &tls.Config{ServerName: check.TLSServerName.ValueString()}
+	if names := listStrings(check.AllowedCipherSuites); len(names) > 0 {
+		ids, tls13, err := allowedCipherSuites(names)
+		if err != nil {
+			return nil, err
+		}
+		config.CipherSuites = ids
+		if !tls13 {
+			config.MaxVersion = tls.VersionTLS12
+		}
+	}
+	return config, nil
+}
+
+// newProbeDialer returns a dialer whose timeouts match those of
+// http.DefaultTransport. A non-empty resolverAddr makes it resolve host names by
+// querying that DNS server rather than the system resolver, and a non-empty
+// sourceIP binds its connections to that local address.
+func newProbeDialer(resolverAddr, sourceIP string) *net.Dialer {
+	dialer := &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+	}
+	if sourceIP != "" {
+		// Port 0 lets the system pick an ephemeral port on that address
+		dialer.LocalAddr = &net.TCPAddr{IP: net.ParseIP(sourceIP)}
+	}
+	if resolverAddr == "" {
+		return dialer
+	}
+	dialer.Resolver = &net.Resolver{
+		// The pure Go resolver is required for Dial to be honored
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, network, resolverAddr)
+		},
+	}
+	return dialer
+}
+
+// evaluateHTTPCheck returns the reason an HTTP check failed, or an empty string if it passed
+func evaluateHTTPCheck(check *HTTPCheck, resp *probeResponse) string {
+	// TLS 1.3 suites can't be restricted up front, so check what was negotiated
+	if names := listStrings(check.AllowedCipherSuites); len(names) > 0 && resp.cipherSuite != 0 && !cipherSuiteAllowed(resp.cipherSuite, names) {
+		return fmt.Sprintf("negotiated TLS cipher suite %s is not in allowed_cipher_suites", tls.CipherSuiteName(resp.cipherSuite))
+	}
+
+	// A conditional request answered with 304 has validated the cache and
+	// carries no body to assert against
+	if resp.statusCode == http.StatusNotModified && isConditionalRequest(check) {
+		return ""
+	}
+
+	// A redirect to the right place is all that is expected of the URL
+	if !check.ExpectedRedirectLocation.IsNull() {
+		return checkRedirectLocation(check, resp)
+	}
+
+	// An unfollowed redirect may be all that is expected of the URL
+	if check.RedirectStatusIsSuccess.ValueBool() && resp.statusCode/100 == 3 {
+		return ""
+	}
+
+	expectedStatus := 200
+	if !check.ExpectedStatus.IsNull() {
+		expectedStatus = int(check.ExpectedStatus.ValueInt64())
+	}
+	if resp.statusCode != expectedStatus {
+		return fmt.Sprintf("expected status %d, got %d", expectedStatus, resp.statusCode)
+	}
+
+	if reason := checkContentType(check.ExpectedContentType, check.Accept, resp.header); reason != "" {
+		return reason
+	}
+
+	if reason := checkHeaders(check.ExpectedHeaders, resp.header); reason != "" {
+		return reason
+	}
+
+	if check.ExpectChunked.ValueBool() && !resp.streamed {
+		return "expected a chunked response, got one with a Content-Length"
+	}
+	if reason := checkTrailers(check.ExpectedTrailers, resp.trailer); reason != "" {
+		return reason
+	}
+
+	if !check.MinResponseSize.IsNull() && resp.size < check.MinResponseSize.ValueInt64() {
+		return fmt.Sprintf("response body is %d bytes, smaller than min_response_size %d", resp.size, check.MinResponseSize.ValueInt64())
+	}
+	if !check.MaxResponseSize.IsNull() && resp.size > check.MaxResponseSize.ValueInt64() {
+		return fmt.Sprintf("response body is larger than max_response_size %d", check.MaxResponseSize.ValueInt64())
+	}
+
+	if !check.ExpectedResponse.IsNull() {
+		searched := resp.body
+		if limit := bodySearchLimit(check); int64(len(searched)) > limit {
+			searched = searched[:limit]
+		}
+
+		// Match against the text, not the raw bytes, for non-UTF-8 pages
+		enc, err := responseEncoding(check.ResponseCharset, resp.header)
+		if err != nil {
+			return err.Error()
+		}
+		decoded, err := decodeBody(searched, enc)
+		if err != nil {
+			return fmt.Sprintf("could not decode response body: %s", err)
+		}
+
+		if !bytes.Contains(decoded, []byte(check.ExpectedResponse.ValueString())) {
+			return fmt.Sprintf("response body does not contain %q within the first %d bytes", check.ExpectedResponse.ValueString(), len(searched))
+		}
+	}
+
+	if !check.ExpectedBodySHA256.IsNull() {
+		if resp.bodySHA256 == "" {
+			return "could not read the whole response body to hash it"
+		}
+		if resp.bodySHA256 != check.ExpectedBodySHA256.ValueString() {
+			return fmt.Sprintf("response body SHA-256 is %s, expected %s", resp.bodySHA256, check.ExpectedBodySHA256.ValueString())
+		}
+	}
+
+	return ""
+}
+
+// certificateExpiryWarning returns why a response's certificate is close enough
+// to expiry to degrade the check, or an empty string if it isn't
+func certificateExpiryWarning(check *HTTPCheck, resp *probeResponse, now time.Time) string {
+	if check.TLSExpiryWarningDays.IsNull() || resp.certExpiry.IsZero() {
+		return ""
+	}
+
+	days := check.TLSExpiryWarningDays.ValueInt64()
+	if resp.certExpiry.After(now.AddDate(0, 0, int(days))) {
+		return ""
+	}
+
+	return fmt.Sprintf("TLS certificate expires at %s, within tls_expiry_warning_days (%d)", resp.certExpiry.UTC().Format(time.RFC3339), days)
+}
+
+// isConditionalRequest reports whether an HTTP check sends cache validation headers
+func isConditionalRequest(check *HTTPCheck) bool {
+	return !check.IfModifiedSince.IsNull() || !check.IfNoneMatch.IsNull()
+}
+
+// probeAPICheck executes an API check from the provider host and evaluates its assertions
+func (c *cloudCanaryClient) probeAPICheck(ctx context.Context, check *APICheck) CheckResult {
+	checkID := check.ID.ValueString()
+
+	// Apply the same defaults the API would use
+	method := "GET"
+	if !check.Method.IsNull() {
+		method = check.Method.ValueString()
+	}
+	timeout := 30 * time.Second
+	if !check.Timeout.IsNull() {
+		timeout = time.Duration(check.Timeout.ValueInt64()) * time.Second
+	}
+
+	req, err := newProbeRequest(ctx, method, check.Endpoint.ValueString(), check.Headers, check.Body, false)
+	if err != nil {
+		return newProbeResult(checkID, nil, fmt.Sprintf("could not build request: %s", err))
+	}
+	setAccept(req, check.Accept)
+
+	switch check.AuthType.ValueString() {
+	case "bearer":
+		req.Header.Set("Authorization", "Bearer "+check.AuthValue.ValueString())
+	case "basic":
+		username, password, _ := strings.Cut(check.AuthValue.ValueString(), ":")
+		req.SetBasicAuth(username, password)
+	case "api_key":
+		req.Header.Set("X-API-Key", check.AuthValue.ValueString())
+	case "jwt":
+		// Mint a fresh short-lived token for every probe
+		algorithm := "HS256"
+		if !check.JWTAlgorithm.IsNull() {
+			algorithm = check.JWTAlgorithm.ValueString()
+		}
+		token, err := mintJWT(algorithm, check.JWTSecret.ValueString(), mapStrings(check.JWTClaims), time.Now())
+		if err != nil {
+			return newProbeResult(checkID, nil, fmt.Sprintf("could not mint JWT: %s", err))
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+	case "token_refresh":
+		token, err := c.refreshedToken(ctx, check, timeout)
+		if err != nil {
+			return newProbeResult(checkID, nil, fmt.Sprintf("could not refresh token: %s", err))
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+	case "aws_sigv4":
+		// Sign last so the signature covers the final request
+		signSigV4(req, []byte(check.Body.ValueString()), sigV4Credentials{
+			accessKeyID:     check.AWSAccessKeyID.ValueString(),
+			secretAccessKey: check.AWSSecretAccessKey.ValueString(),
+			region:          check.AWSRegion.ValueString(),
+			service:         check.AWSService.ValueString(),
+		}, time.Now())
+	}
+
+	resp, err := c.doProbe(req, &http.Client{Timeout: timeout}, nil)
+	if err != nil {
+		return newProbeResult(checkID, nil, err.Error())
+	}
+
+	result := newProbeResult(checkID, resp, evaluateAPICheck(check, resp))
+
+	tflog.Debug(ctx, "Probed API check", map[string]any{
+		"id":     checkID,
+		"status": result.Status.ValueString(),
+	})
+
+	return result
+}
+
+// defaultJSONErrorField is where fail_on_json_error_field looks when unset,
+// the errors array of a GraphQL response
+const defaultJSONErrorField = "$.errors"
+
+// evaluateAPICheck returns the reason an API check failed, or an empty string if it passed
+func evaluateAPICheck(check *APICheck, resp *probeResponse) string {
+	// An error reported in the body fails the check whatever the status code
+	if reason := checkJSONErrorField(check, resp.body); reason != "" {
+		return reason
+	}
+
+	// Explicit success codes take precedence over every other criterion
+	for _, elem := range check.SuccessStatusCodes.Elements() {
+		if code, ok := elem.(types.Int64); ok && code.ValueInt64() == int64(resp.statusCode) {
+			return ""
+		}
+	}
+
+	expectedStatus := 200
+	if !check.ExpectedStatus.IsNull() {
+		expectedStatus = int(check.ExpectedStatus.ValueInt64())
+	}
+	if resp.statusCode != expectedStatus {
+		return fmt.Sprintf("expected status %d, got %d", expectedStatus, resp.statusCode)
+	}
+
+	if reason := checkContentType(check.ExpectedContentType, check.Accept, resp.header); reason != "" {
+		return reason
+	}
+
+	if reason := checkHeaders(check.ExpectedHeaders, resp.header); reason != "" {
+		return reason
+	}
+
+	// Parse the body when valid JSON is required, strict_json is set or a JSON assertion needs it
+	strict := check.StrictJSON.ValueBool()
+	var doc any
+	if strict || check.RequireValidJSON.ValueBool() || !check.ExpectedJSONBody.IsNull() {
+		var err error
+		doc, err = decodeResponseJSON(resp.body, strict)
+		if err != nil {
+			return fmt.Sprintf("response body is not valid JSON: %s", err)
+		}
+	}
+
+	if !check.ExpectedJSONBody.IsNull() {
+		err := compareJSONBody(check.ExpectedJSONBody.ValueString(), doc, listStrings(check.IgnorePaths))
+		if err != nil {
+			return err.Error()
+		}
+	}
+
+	return ""
+}
+
+// checkJSONErrorField returns the reason a response body reports an error at
+// fail_on_json_error_field, or an empty string if the field is absent or
+// empty. Bodies that aren't JSON report no error.
+func checkJSONErrorField(check *APICheck, body []byte) string {
+	field := defaultJSONErrorField
+	if !check.FailOnJSONErrorField.IsNull() {
+		field = check.FailOnJSONErrorField.ValueString()
+	}
+	if field == "" {
+		return ""
+	}
+
+	segments, err := parseJSONPath(field)
+	if err != nil {
+		return fmt.Sprintf("invalid fail_on_json_error_field: %s", err)
+	}
+	doc, err := decodeResponseJSON(body, false)
+	if err != nil {
+		return ""
+	}
+
+	for _, match := range lookupJSONPath(doc, segments) {
+		if !jsonValueEmpty(match) {
+			detail := jsonValueString(match)
+			if len(detail) > maxReportedJSONError {
+				detail = strings.ToValidUTF8(detail[:maxReportedJSONError], "") + "..."
+			}
+			return fmt.Sprintf("response reports errors at %s: %s", field, detail)
+		}
+	}
+	return ""
+}
+
+// maxReportedJSONError caps how much of an error field a failure reason quotes
+const maxReportedJSONError = 200
+
+// checkContentType compares the response media type against the expected one,
+// ignoring parameters such as charset, and checks it is one accept allows when
+// that is set too. It returns a failure reason on mismatch.
+func checkContentType(expected, accept types.String, header http.Header) string {
+	if expected.IsNull() {
+		return ""
+	}
+
+	want := mediaType(expected.ValueString())
+	got := mediaType(header.Get("Content-Type"))
+	if got != want {
+		return fmt.Sprintf("expected content type %q, got %q", want, got)
+	}
+
+	return checkAccepted(accept, header)
+}
+
+// checkRedirectLocation returns the reason an unfollowed redirect doesn't
+// point at expected_redirect_location, or an empty string if it does
+func checkRedirectLocation(check *HTTPCheck, resp *probeResponse) string {
+	if !check.ExpectedStatus.IsNull() && resp.statusCode != int(check.ExpectedStatus.ValueInt64()) {
+		return fmt.Sprintf("expected status %d, got %d", check.ExpectedStatus.ValueInt64(), resp.statusCode)
+	}
+	if resp.statusCode/100 != 3 {
+		return fmt.Sprintf("expected a redirect, got status %d", resp.statusCode)
+	}
+
+	location, ok := resp.header["Location"]
+	if !ok {
+		return "redirect has no Location header"
+	}
+	got := strings.Join(location, ", ")
+
+	expected := check.ExpectedRedirectLocation.ValueString()
+	if pattern, ok := headerPattern(expected); ok {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return fmt.Sprintf("invalid pattern for expected_redirect_location: %s", err)
+		}
+		if !re.MatchString(got) {
+			return fmt.Sprintf("redirect location %q does not match %q", got, pattern)
+		}
+		return ""
+	}
+	if got != expected {
+		return fmt.Sprintf("expected redirect to %q, got %q", expected, got)
+	}
+	return ""
+}
+
+// checkTrailers returns the reason a response's trailers don't match the
+// expected ones, or an empty string if they all do or none are expected. A nil
+// trailer means the body wasn't read to the end, where trailers are sent.
+func checkTrailers(expected types.Map, trailer http.Header) string {
+	if expected.IsNull() {
+		return ""
+	}
+	if trailer == nil {
+		return "could not read the whole response body to check its trailers"
+	}
+	if len(trailer) == 0 {
+		return "server sent no trailers"
+	}
+	return checkFields("trailer", expected, trailer)
+}
+
+// checkHeaders returns the reason a response's headers don't match the
+// expected ones, or an empty string if they all do. Headers are checked in name
+// order so the reported failure is stable.
+func checkHeaders(expected types.Map, header http.Header) string {
+	return checkFields("header", expected, header)
+}
+
+// checkFields compares header or trailer fields, named by kind in failure
+// reasons, against the expected values
+func checkFields(kind string, expected types.Map, header http.Header) string {
+	want := mapStrings(expected)
+	names := make([]string, 0, len(want))
+	for name := range want {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		values, ok := header[http.CanonicalHeaderKey(name)]
+		if !ok {
+			return fmt.Sprintf("expected %s %s is missing", kind, name)
+		}
+		got := strings.Join(values, ", ")
+
+		if pattern, ok := headerPattern(want[name]); ok {
+			re, err := regexp.Compile(pattern)
+			if err != nil {
+				return fmt.Sprintf("invalid pattern for %s %s: %s", kind, name, err)
+			}
+			if !re.MatchString(got) {
+				return fmt.Sprintf("%s %s value %q does not match %q", kind, name, got, pattern)
+			}
+			continue
+		}
+		if got != want[name] {
+			return fmt.Sprintf("expected %s %s to be %q, got %q", kind, name, want[name], got)
+		}
+	}
+
+	return ""
+}
+
+// headerPattern returns the regular expression of an expected header or
+// redirect location written as ~pattern, and whether the value was one
+func headerPattern(expected string) (string, bool) {
+	if !strings.HasPrefix(expected, "~") {
+		return "", false
+	}
+	return expected[1:], true
+}
+
+// mediaType returns the lower-cased media type of a Content-Type value without its parameters
+func mediaType(contentType string) string {
+	if parsed, _, err := mime.ParseMediaType(contentType); err == nil {
+		return parsed
+	}
+	base, _, _ := strings.Cut(contentType, ";")
+	return strings.ToLower(strings.TrimSpace(base))
+}
+
+// newProbeRequest builds the HTTP request sent by a probe. When preserveCase is
+// set, header names bypass canonicalization and are written exactly as given.
+func newProbeRequest(ctx context.Context, method, url string, headers types.Map, body types.String, preserveCase bool) (*http.Request, error) {
+	var reader io.Reader
+	if !body.IsNull() {
+		reader = strings.NewReader(body.ValueString())
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, reader)
+	if err != nil {
+		return nil, err
+	}
+
+	for name, value := range mapStrings(headers) {
+		if preserveCase {
+			// Assigning the map directly skips CanonicalHeaderKey; the
+			// transport writes keys as stored
+			req.Header[name] = []string{value}
+			continue
+		}
+		req.Header.Set(name, value)
+	}
+
+	return req, nil
+}
+
+// bodyReader reads as much of a response body as a probe needs
+type bodyReader func(r io.Reader) ([]byte, error)
+
+// readUntilMatch returns a body reader that stops as soon as keyword has been
+// seen or limit bytes have been read
+func readUntilMatch(keyword []byte, limit int64) bodyReader {
+	return func(r io.Reader) ([]byte, error) {
+		var body []byte
+		chunk := make([]byte, 32<<10)
+		lr := io.LimitReader(r, limit)
+		for {
+			n, err := lr.Read(chunk)
+			body = append(body, chunk[:n]...)
+
+			// Only the new bytes, plus enough of the old ones to catch a match spanning chunks, need searching
+			from := len(body) - n - len(keyword) + 1
+			if from < 0 {
+				from = 0
+			}
+			if bytes.Contains(body[from:], keyword) || err == io.EOF {
+				return body, nil
+			}
+			if err != nil {
+				return body, err
+			}
+		}
+	}
+}
+
+// bodySearchLimit returns how many bytes of the body are searched for expected_response
+func bodySearchLimit(check *HTTPCheck) int64 {
+	limit := int64(maxProbeBodyBytes)
+	if !check.BodySearchLimitBytes.IsNull() && check.BodySearchLimitBytes.ValueInt64() < limit {
+		limit = check.BodySearchLimitBytes.ValueInt64()
+	}
+	return limit
+}
+
+// readLimitedBody captures a response body up to maxProbeBodyBytes
+func readLimitedBody(r io.Reader) ([]byte, error) {
+	return io.ReadAll(io.LimitReader(r, maxProbeBodyBytes))
+}
+
+// measureBody wraps a body reader so that the rest of the body is read and
+// discarded afterwards, up to limit bytes in total, letting doProbe count its
+// size. A negative limit reads to the end.
+func measureBody(readBody bodyReader, limit int64) bodyReader {
+	if readBody == nil {
+		readBody = readLimitedBody
+	}
+	return func(r io.Reader) ([]byte, error) {
+		body, err := readBody(r)
+		if err != nil {
+			return body, err
+		}
+
+		rest := r
+		if limit >= 0 {
+			rest = io.LimitReader(r, limit-int64(len(body)))
+		}
+		_, err = io.Copy(io.Discard, rest)
+		return body, err
+	}
+}
+
+// countingReader counts and hashes the bytes read through it
+type countingReader struct {
+	r    io.Reader
+	n    int64
+	hash hash.Hash
+	// eof is set once the underlying reader has been read to the end
+	eof bool
+}
+
+// Read reads from the underlying reader and counts and hashes the bytes returned
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	c.hash.Write(p[:n])
+	if err == io.EOF {
+		c.eof = true
+	}
+	return n, err
+}
+
+// doProbe sends a probe request and captures the response. A nil readBody
+// captures the body up to maxProbeBodyBytes.
+func (c *cloudCanaryClient) doProbe(req *http.Request, httpClient *http.Client, readBody bodyReader) (*probeResponse, error) {
+	if readBody == nil {
+		readBody = readLimitedBody
+	}
+
+	start := time.Now()
+	tracer := &phaseTracer{start: start}
+	req = req.WithContext(httptrace.WithClientTrace(req.Context(), tracer.clientTrace()))
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	counter := &countingReader{r: resp.Body, hash: sha256.New()}
+	body, err := readBody(counter)
+	if err != nil {
+		return nil, fmt.Errorf("error reading response body: %w", err)
+	}
+
+	responseTime := time.Since(start)
+	tracer.mu.Lock()
+	phases := tracer.phases
+	hopStart := tracer.hopStart
+	connReused := tracer.connReused
+	tracer.mu.Unlock()
+	phases.download = responseTime - phases.ttfb
+	finalHopTime := responseTime
+	if !hopStart.IsZero() {
+		finalHopTime -= hopStart.Sub(start)
+	}
+
+	var certExpiry time.Time
+	var cipherSuite uint16
+	if resp.TLS != nil {
+		cipherSuite = resp.TLS.CipherSuite
+		if len(resp.TLS.PeerCertificates) > 0 {
+			certExpiry = resp.TLS.PeerCertificates[0].NotAfter
+		}
+	}
+
+	// A hash of part of the body would never match, so only report one for
+	// the whole body. Trailers likewise only arrive at its end.
+	var bodySHA256 string
+	var trailer http.Header
+	if counter.eof {
+		bodySHA256 = hex.EncodeToString(counter.hash.Sum(nil))
+		trailer = resp.Trailer
+		if trailer == nil {
+			trailer = http.Header{}
+		}
+	}
+
+	// HTTP/2 has no chunked encoding; a body of unknown length is streamed there
+	streamed := resp.ContentLength == -1 && resp.ProtoMajor >= 2
+	for _, encoding := range resp.TransferEncoding {
+		if encoding == "chunked" {
+			streamed = true
+		}
+	}
+
+	return &probeResponse{
+		statusCode:   resp.StatusCode,
+		header:       resp.Header,
+		body:         body,
+		size:         counter.n,
+		responseTime: responseTime,
+		finalHopTime: finalHopTime,
+		phases:       phases,
+		certExpiry:   certExpiry,
+		bodySHA256:   bodySHA256,
+		cipherSuite:  cipherSuite,
+		connReused:   connReused,
+		streamed:     streamed,
+		trailer:      trailer,
+	}, nil
+}
+
+// newProbeResult converts a probe outcome into a check result
+func newProbeResult(checkID string, resp *probeResponse, failureReason string) CheckResult {
+	result := CheckResult{
+		ID:            types.StringValue(fmt.Sprintf("res-%s-%d", checkID, time.Now().UnixNano())),
+		CheckID:       types.StringValue(checkID),
+		Status:        types.StringValue("SUCCESS"),
+		ResponseTime:  types.Int64Value(0),
+		Message:       types.StringValue("Check completed successfully"),
+		Timestamp:     types.StringValue(time.Now().Format(time.RFC3339)),
+		Region:        types.StringNull(),
+		ResponseBody:  types.StringNull(),
+		ResponseCode:  types.Int64Null(),
+		NotModified:   types.BoolNull(),
+		ResponseSize:  types.Int64Null(),
+		BodySHA256:    types.StringNull(),
+		DNSTime:       types.Int64Null(),
+		ConnectTime:   types.Int64Null(),
+		TLSTime:       types.Int64Null(),
+		TTFB:          types.Int64Null(),
+		DownloadTime:  types.Int64Null(),
+		FailureReason: types.StringNull(),
+		Labels:        types.MapNull(types.StringType),
+	}
+
+	if resp != nil {
+		result.ResponseTime = types.Int64Value(resp.responseTime.Milliseconds())
+		result.ResponseBody = types.StringValue(string(resp.body))
+		result.ResponseCode = types.Int64Value(int64(resp.statusCode))
+		result.NotModified = types.BoolValue(resp.statusCode == http.StatusNotModified)
+		result.ResponseSize = types.Int64Value(resp.size)
+		if resp.bodySHA256 != "" {
+			result.BodySHA256 = types.StringValue(resp.bodySHA256)
+		}
+		result.DNSTime = types.Int64Value(resp.phases.dns.Milliseconds())
+		result.ConnectTime = types.Int64Value(resp.phases.connect.Milliseconds())
+		result.TLSTime = types.Int64Value(resp.phases.tls.Milliseconds())
+		result.TTFB = types.Int64Value(resp.phases.ttfb.Milliseconds())
+		result.DownloadTime = types.Int64Value(resp.phases.download.Milliseconds())
+	}
+
+	if failureReason != "" {
+		result.Status = types.StringValue("FAILURE")
+		result.Message = types.StringValue("Check failed")
+		result.FailureReason = types.StringValue(failureReason)
+	}
+
+	return result
+}
+
+// listStrings returns the known string elements of a list attribute
+func listStrings(l types.List) []string {
+	var values []string
+	for _, elem := range l.Elements() {
+		if s, ok := elem.(types.String); ok && !s.IsNull() && !s.IsUnknown() {
+			values = append(values, s.ValueString())
+		}
+	}
+	return values
+}
+
+// mapStrings returns the known string elements of a map attribute
+func mapStrings(m types.Map) map[string]string {
+	values := make(map[string]string, len(m.Elements()))
+	for key, elem := range m.Elements() {
+		if s, ok := elem.(types.String); ok && !s.IsNull() && !s.IsUnknown() {
+			values[key] = s.ValueString()
+		}
+	}
+	return values
+}
+
+// maxTCPPayloadBytes caps how much a TCP probe reads while waiting for the expected payload
+const maxTCPPayloadBytes = 64 << 10
+
+// probeTCPCheck connects to a TCP check's target, optionally exchanges a payload, and evaluates the reply
+func (c *cloudCanaryClient) probeTCPCheck(ctx context.Context, check *TCPCheck) CheckResult {
+	checkID := check.ID.ValueString()
+
+	timeout := 10 * time.Second
+	if !check.Timeout.IsNull() {
+		timeout = time.Duration(check.Timeout.ValueInt64()) * time.Second
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	address := net.JoinHostPort(check.Host.ValueString(), strconv.FormatInt(check.Port.ValueInt64(), 10))
+	start := time.Now()
+
+	// With send_keepalive, pick up the connection the last probe left open.
+	// One the server dropped in between is a failure in itself, but a new
+	// connection is still opened so the next probe has one to check.
+	keepalive := check.SendKeepalive.ValueBool()
+	key := tcpKeepAliveKey(check, address)
+	var conn net.Conn
+	dropped := ""
+	if keepalive {
+		conn = c.tcpConns.take(checkID, key)
+		if conn != nil {
+			if err := connClosed(conn); err != nil {
+				conn.Close()
+				conn = nil
+				dropped = fmt.Sprintf("connection to %s was dropped since the last probe: %s", address, err)
+			}
+		}
+	} else {
+		c.tcpConns.drop(checkID)
+	}
+
+	if conn == nil {
+		var dialer net.Dialer
+		if keepalive {
+			dialer.KeepAlive = 15 * time.Second
+			if !check.KeepaliveInterval.IsNull() {
+				dialer.KeepAlive = time.Duration(check.KeepaliveInterval.ValueInt64()) * time.Second
+			}
+		}
+		var err error
+		conn, err = dialer.DialContext(ctx, "tcp", address)
+		if err != nil {
+			reason := fmt.Sprintf("could not connect to %s: %s", address, err)
+			if dropped != "" {
+				reason = fmt.Sprintf("%s; %s", dropped, reason)
+			}
+			return newProbeResult(checkID, nil, reason)
+		}
+	}
+
+	// Bound the payload exchange by the same deadline as the connect
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	encoding := check.PayloadEncoding.ValueString()
+	failureReason := dropped
+	if failureReason == "" && !check.SendPayload.IsNull() {
+		payload, err := decodePayload(check.SendPayload.ValueString(), encoding)
+		if err != nil {
+			conn.Close()
+			return newProbeResult(checkID, nil, fmt.Sprintf("invalid send_payload: %s", err))
+		}
+		if _, err := conn.Write(payload); err != nil {
+			failureReason = fmt.Sprintf("error sending payload: %s", err)
+		}
+	}
+	if failureReason == "" && !check.ExpectedPayload.IsNull() {
+		expected, err := decodePayload(check.ExpectedPayload.ValueString(), encoding)
+		if err != nil {
+			conn.Close()
+			return newProbeResult(checkID, nil, fmt.Sprintf("invalid expected_payload: %s", err))
+		}
+		failureReason = readExpectedPayload(conn, expected)
+	}
+
+	// Keep a healthy connection for the next probe; after a failed exchange
+	// its state is unknown, so start afresh
+	if keepalive && (failureReason == "" || failureReason == dropped) {
+		_ = conn.SetDeadline(time.Time{})
+		c.tcpConns.put(checkID, key, conn)
+	} else {
+		conn.Close()
+	}
+
+	result := newProbeResult(checkID, nil, failureReason)
+	result.ResponseTime = types.Int64Value(time.Since(start).Milliseconds())
+
+	tflog.Debug(ctx, "Probed TCP check", map[string]any{
+		"id":     checkID,
+		"status": result.Status.ValueString(),
+	})
+
+	return result
+}
+
+// readExpectedPayload reads from conn until the expected bytes are seen, the read
+// limit is reached, or the connection closes or times out. It returns a failure
+// reason, or an empty string if the payload was received.
+func readExpectedPayload(conn net.Conn, expected []byte) string {
+	received := make([]byte, 0, 4096)
+	chunk := make([]byte, 4096)
+	for len(received) < maxTCPPayloadBytes {
+		n, err := conn.Read(chunk)
+		received = append(received, chunk[:n]...)
+		if bytes.Contains(received, expected) {
+			return ""
+		}
+		if err != nil {
+			var netErr net.Error
+			switch {
+			case errors.As(err, &netErr) && netErr.Timeout():
+				return fmt.Sprintf("timed out waiting for expected payload after receiving %d bytes", len(received))
+			case errors.Is(err, io.EOF):
+				return fmt.Sprintf("connection closed before expected payload was received (got %d bytes)", len(received))
+			default:
+				return fmt.Sprintf("error reading response: %s", err)
+			}
+		}
+	}
+
+	return fmt.Sprintf("expected payload not found in the first %d bytes", maxTCPPayloadBytes)
+}
+
+// decodePayload converts a configured TCP payload to bytes; encoding is "text" (the default) or "hex"
+func decodePayload(value, encoding string) ([]byte, error) {
+	switch encoding {
+	case "", "text":
+		return []byte(value), nil
+	case "hex":
+		return hex.DecodeString(strings.Join(strings.Fields(value), ""))
+	default:
+		return nil, fmt.Errorf("unsupported payload encoding %q", encoding)
+	}
+}
@@ -0,0 +1,710 @@
+package cloudcanary
+
+import (
+	"bufio"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// stringList builds a list attribute value from strings
+func stringList(values ...string) types.List {
+	elems := make([]attr.Value, 0, len(values))
+	for _, v := range values {
+		elems = append(elems, types.StringValue(v))
+	}
+	return types.ListValueMust(types.StringType, elems)
+}
+
+// runAPICheck creates an API check through the client and probes it from the test host
+func runAPICheck(t *testing.T, c *cloudCanaryClient, check APICheck) CheckResult {
+	t.Helper()
+	ctx := context.Background()
+	check.Name = types.StringValue(t.Name())
+	if err := c.createAPICheck(ctx, &check); err != nil {
+		t.Fatalf("createAPICheck: %s", err)
+	}
+	return c.probeAPICheck(ctx, &check)
+}
+
+func TestEvaluateAPICheckExpectedJSONBody(t *testing.T) {
+	resp := &probeResponse{
+		statusCode: http.StatusOK,
+		header:     http.Header{"Content-Type": {"application/json"}},
+		body:       []byte(`{"status":"up","regions":["eu","us"],"generated_at":"2024-05-01T12:00:00Z"}`),
+	}
+
+	tests := []struct {
+		name        string
+		expected    string
+		ignorePaths []string
+		wantPass    bool
+	}{
+		{
+			name:        "equal ignoring key and element order",
+			expected:    `{"regions":["us","eu"],"status":"up"}`,
+			ignorePaths: []string{"$.generated_at"},
+			wantPass:    true,
+		},
+		{
+			name:     "ignored path compared when not ignored",
+			expected: `{"regions":["us","eu"],"status":"up"}`,
+		},
+		{
+			name:        "different value",
+			expected:    `{"regions":["us","eu"],"status":"down"}`,
+			ignorePaths: []string{"$.generated_at"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			check := APICheck{
+				ExpectedJSONBody: types.StringValue(tt.expected),
+				IgnorePaths:      types.ListNull(types.StringType),
+			}
+			if tt.ignorePaths != nil {
+				check.IgnorePaths = stringList(tt.ignorePaths...)
+			}
+
+			reason := evaluateAPICheck(&check, resp)
+			if got := reason == ""; got != tt.wantPass {
+				t.Errorf("passed = %t, want %t (failure reason %q)", got, tt.wantPass, reason)
+			}
+		})
+	}
+}
+
+// runHTTPCheck creates an HTTP check through the client and probes it from the test host
+func runHTTPCheck(t *testing.T, c *cloudCanaryClient, check HTTPCheck) CheckResult {
+	t.Helper()
+	ctx := context.Background()
+	check.Name = types.StringValue(t.Name())
+	if err := c.createHTTPCheck(ctx, &check); err != nil {
+		t.Fatalf("createHTTPCheck: %s", err)
+	}
+	return c.probeHTTPCheck(ctx, &check)
+}
+
+func TestProbeStoreResponseBody(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "account 1234")
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name              string
+		storeResponseBody types.Bool
+		wantBody          types.String
+	}{
+		{name: "unset", storeResponseBody: types.BoolNull(), wantBody: types.StringValue("account 1234")},
+		{name: "false", storeResponseBody: types.BoolValue(false), wantBody: types.StringNull()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := runHTTPCheck(t, newTestClient(), HTTPCheck{
+				URL:               types.StringValue(server.URL),
+				ExpectedResponse:  types.StringValue("account"),
+				StoreResponseBody: tt.storeResponseBody,
+			})
+			if result.Status.ValueString() != "SUCCESS" {
+				t.Fatalf("status = %s, want SUCCESS (failure reason %s)", result.Status.ValueString(), result.FailureReason)
+			}
+			if !result.ResponseBody.Equal(tt.wantBody) {
+				t.Errorf("response body = %s, want %s", result.ResponseBody, tt.wantBody)
+			}
+		})
+	}
+}
+
+// runTCPCheck creates a TCP check through the client and probes it from the test host
+func runTCPCheck(t *testing.T, c *cloudCanaryClient, check TCPCheck) CheckResult {
+	t.Helper()
+	ctx := context.Background()
+	check.Name = types.StringValue(t.Name())
+	if err := c.createTCPCheck(ctx, &check); err != nil {
+		t.Fatalf("createTCPCheck: %s", err)
+	}
+	return c.probeTCPCheck(ctx, &check)
+}
+
+// serveTCP accepts connections on a local port and hands each to handle
+func serveTCP(t *testing.T, handle func(net.Conn)) (string, int64) {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %s", err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				handle(conn)
+			}()
+		}
+	}()
+
+	addr := listener.Addr().(*net.TCPAddr)
+	return addr.IP.String(), int64(addr.Port)
+}
+
+func TestProbeTCPPayload(t *testing.T) {
+	// A minimal Redis: answers PING with PONG, and never answers anything else
+	host, port := serveTCP(t, func(conn net.Conn) {
+		line, err := bufio.NewReader(conn).ReadString('\n')
+		if err != nil {
+			return
+		}
+		if line == "PING\r\n" {
+			fmt.Fprint(conn, "+PONG\r\n")
+		}
+		time.Sleep(2 * time.Second)
+	})
+
+	tests := []struct {
+		name       string
+		send       string
+		expected   string
+		encoding   string
+		wantStatus string
+		wantReason string
+	}{
+		{name: "text payload", send: "PING\r\n", expected: "+PONG", wantStatus: "SUCCESS"},
+		{name: "hex payload", send: "50 49 4e 47 0d 0a", expected: "2b504f4e47", encoding: "hex", wantStatus: "SUCCESS"},
+		{name: "unexpected reply", send: "PING\r\n", expected: "+OK", wantStatus: "FAILURE", wantReason: "timed out waiting for expected payload"},
+		{name: "no reply", send: "QUIT\r\n", expected: "+PONG", wantStatus: "FAILURE", wantReason: "timed out waiting for expected payload after receiving 0 bytes"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			check := TCPCheck{
+				Host:            types.StringValue(host),
+				Port:            types.Int64Value(port),
+				Timeout:         types.Int64Value(1),
+				SendPayload:     types.StringValue(tt.send),
+				ExpectedPayload: types.StringValue(tt.expected),
+				PayloadEncoding: types.StringNull(),
+			}
+			if tt.encoding != "" {
+				check.PayloadEncoding = types.StringValue(tt.encoding)
+			}
+
+			result := runTCPCheck(t, newTestClient(), check)
+			if got := result.Status.ValueString(); got != tt.wantStatus {
+				t.Errorf("status = %s, want %s (failure reason %s)", got, tt.wantStatus, result.FailureReason)
+			}
+			if !strings.Contains(result.FailureReason.ValueString(), tt.wantReason) {
+				t.Errorf("failure reason = %s, want it to contain %q", result.FailureReason, tt.wantReason)
+			}
+		})
+	}
+}
+
+func TestProbeTCPConnectionRefused(t *testing.T) {
+	// Find a port nothing listens on
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %s", err)
+	}
+	port := int64(listener.Addr().(*net.TCPAddr).Port)
+	listener.Close()
+
+	result := runTCPCheck(t, newTestClient(), TCPCheck{
+		Host:    types.StringValue("127.0.0.1"),
+		Port:    types.Int64Value(port),
+		Timeout: types.Int64Value(1),
+	})
+	if result.Status.ValueString() != "FAILURE" || !strings.Contains(result.FailureReason.ValueString(), "could not connect") {
+		t.Errorf("got %s (%s), want a connection failure", result.Status, result.FailureReason)
+	}
+}
+
+// verifyHS256 checks the signature of a compact HS256 JWT and decodes its claims
+func verifyHS256(token, secret string) (map[string]any, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("malformed token %q", token)
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(parts[0] + "." + parts[1]))
+	if base64.RawURLEncoding.EncodeToString(mac.Sum(nil)) != parts[2] {
+		return nil, fmt.Errorf("bad signature")
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, err
+	}
+	var claims map[string]any
+	return claims, json.Unmarshal(payload, &claims)
+}
+
+func TestProbeJWTAuth(t *testing.T) {
+	const secret = "s3cret"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		claims, err := verifyHS256(strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer "), secret)
+		if err != nil || claims["sub"] != "canary" || claims["exp"] == nil {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprint(w, `{"ok":true}`)
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name       string
+		secret     string
+		wantStatus string
+	}{
+		{name: "valid signature", secret: secret, wantStatus: "SUCCESS"},
+		{name: "wrong secret", secret: "other", wantStatus: "FAILURE"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := runAPICheck(t, newTestClient(), APICheck{
+				Endpoint:  types.StringValue(server.URL),
+				AuthType:  types.StringValue("jwt"),
+				JWTSecret: types.StringValue(tt.secret),
+				JWTClaims: types.MapValueMust(types.StringType, map[string]attr.Value{"sub": types.StringValue("canary")}),
+			})
+			if got := result.Status.ValueString(); got != tt.wantStatus {
+				t.Errorf("status = %s, want %s (failure reason %s)", got, tt.wantStatus, result.FailureReason)
+			}
+		})
+	}
+}
+
+func TestProbeResponseCharset(t *testing.T) {
+	// "café" in ISO-8859-1 and UTF-16LE; neither is valid UTF-8
+	latin1 := []byte{'c', 'a', 'f', 0xe9}
+	utf16 := []byte{'c', 0, 'a', 0, 'f', 0, 0xe9, 0}
+
+	tests := []struct {
+		name            string
+		body            []byte
+		contentType     string
+		responseCharset types.String
+		wantStatus      string
+		wantReason      string
+	}{
+		{name: "latin1 from content type", body: latin1, contentType: "text/plain; charset=iso-8859-1", wantStatus: "SUCCESS"},
+		{name: "utf-16le from content type", body: utf16, contentType: "text/plain; charset=utf-16le", wantStatus: "SUCCESS"},
+		{name: "configured charset without content type charset", body: latin1, contentType: "text/plain", responseCharset: types.StringValue("latin1"), wantStatus: "SUCCESS"},
+		{name: "configured charset overrides content type", body: latin1, contentType: "text/plain; charset=utf-8", responseCharset: types.StringValue("windows-1252"), wantStatus: "SUCCESS"},
+		{name: "unknown content type charset falls back to utf-8", body: latin1, contentType: "text/plain; charset=x-unknown", wantStatus: "FAILURE", wantReason: "does not contain"},
+		{name: "undeclared charset is matched as utf-8", body: latin1, contentType: "text/plain", wantStatus: "FAILURE", wantReason: "does not contain"},
+		{name: "unsupported configured charset", body: latin1, contentType: "text/plain", responseCharset: types.StringValue("x-unknown"), wantStatus: "FAILURE", wantReason: "unsupported charset"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", tt.contentType)
+				w.Write(tt.body)
+			}))
+			defer server.Close()
+
+			result := runHTTPCheck(t, newTestClient(), HTTPCheck{
+				URL:              types.StringValue(server.URL),
+				ExpectedResponse: types.StringValue("café"),
+				ResponseCharset:  tt.responseCharset,
+			})
+			if got := result.Status.ValueString(); got != tt.wantStatus {
+				t.Fatalf("status = %s, want %s (failure reason %s)", got, tt.wantStatus, result.FailureReason)
+			}
+			if !strings.Contains(result.FailureReason.ValueString(), tt.wantReason) {
+				t.Errorf("failure reason = %q, want it to contain %q", result.FailureReason.ValueString(), tt.wantReason)
+			}
+		})
+	}
+}
+
+func TestProbeExpectContinue(t *testing.T) {
+	tests := []struct {
+		name           string
+		expectContinue types.Bool
+		body           types.String
+		wantExpect     string
+	}{
+		{name: "enabled with body", expectContinue: types.BoolValue(true), body: types.StringValue(`{"size":"large"}`), wantExpect: "100-continue"},
+		{name: "enabled without body", expectContinue: types.BoolValue(true), body: types.StringNull(), wantExpect: ""},
+		{name: "disabled", expectContinue: types.BoolValue(false), body: types.StringValue(`{"size":"large"}`), wantExpect: ""},
+		{name: "unset", expectContinue: types.BoolNull(), body: types.StringValue(`{"size":"large"}`), wantExpect: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotExpect, gotBody string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotExpect = r.Header.Get("Expect")
+				// Reading the body is what makes the server send 100 Continue
+				body, _ := io.ReadAll(r.Body)
+				gotBody = string(body)
+			}))
+			defer server.Close()
+
+			result := runHTTPCheck(t, newTestClient(), HTTPCheck{
+				URL:            types.StringValue(server.URL),
+				Method:         types.StringValue("PUT"),
+				Body:           tt.body,
+				ExpectContinue: tt.expectContinue,
+			})
+			if result.Status.ValueString() != "SUCCESS" {
+				t.Fatalf("status = %s, want SUCCESS (failure reason %s)", result.Status.ValueString(), result.FailureReason)
+			}
+			if gotExpect != tt.wantExpect {
+				t.Errorf("Expect header = %q, want %q", gotExpect, tt.wantExpect)
+			}
+			if gotBody != tt.body.ValueString() {
+				t.Errorf("server received body %q, want %q", gotBody, tt.body.ValueString())
+			}
+		})
+	}
+}
+
+func TestProbeExpectContinueRejected(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Refuse without reading the body, so no 100 Continue is sent
+		w.WriteHeader(http.StatusRequestEntityTooLarge)
+	}))
+	defer server.Close()
+
+	result := runHTTPCheck(t, newTestClient(), HTTPCheck{
+		URL:            types.StringValue(server.URL),
+		Method:         types.StringValue("PUT"),
+		Body:           types.StringValue(strings.Repeat("x", 1<<20)),
+		ExpectContinue: types.BoolValue(true),
+	})
+	if result.Status.ValueString() != "FAILURE" || !strings.Contains(result.FailureReason.ValueString(), "413") {
+		t.Errorf("status = %s with failure reason %s, want FAILURE for a 413", result.Status.ValueString(), result.FailureReason)
+	}
+}
+
+func TestProbeResponseTimeMode(t *testing.T) {
+	// /a and /b are slow to redirect, the final page at /c answers at once
+	const hopDelay = 150 * time.Millisecond
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/a":
+			time.Sleep(hopDelay)
+			http.Redirect(w, r, "/b", http.StatusFound)
+		case "/b":
+			time.Sleep(hopDelay)
+			http.Redirect(w, r, "/c", http.StatusFound)
+		default:
+			fmt.Fprint(w, "final page")
+		}
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name             string
+		responseTimeMode types.String
+		wantWholeChain   bool
+	}{
+		{name: "unset", responseTimeMode: types.StringNull(), wantWholeChain: true},
+		{name: "total", responseTimeMode: types.StringValue("total"), wantWholeChain: true},
+		{name: "final", responseTimeMode: types.StringValue("final"), wantWholeChain: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := runHTTPCheck(t, newTestClient(), HTTPCheck{
+				URL:              types.StringValue(server.URL + "/a"),
+				ResponseTimeMode: tt.responseTimeMode,
+			})
+			if result.Status.ValueString() != "SUCCESS" {
+				t.Fatalf("status = %s, want SUCCESS (failure reason %s)", result.Status.ValueString(), result.FailureReason)
+			}
+
+			// The final hop alone takes less than either redirect
+			responseTime := time.Duration(result.ResponseTime.ValueInt64()) * time.Millisecond
+			if tt.wantWholeChain && responseTime < 2*hopDelay {
+				t.Errorf("response time = %s, want the whole chain of two %s redirects counted", responseTime, hopDelay)
+			}
+			if !tt.wantWholeChain && responseTime >= hopDelay {
+				t.Errorf("response time = %s, want only the final hop counted", responseTime)
+			}
+		})
+	}
+}
+
+// countingServer starts an httptest server that counts the connections made to it
+func countingServer(t *testing.T, handler http.HandlerFunc) (*httptest.Server, *int32) {
+	t.Helper()
+	var conns int32
+	server := httptest.NewUnstartedServer(handler)
+	server.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			atomic.AddInt32(&conns, 1)
+		}
+	}
+	server.Start()
+	t.Cleanup(server.Close)
+	return server, &conns
+}
+
+func TestProbeAssertConnectionReused(t *testing.T) {
+	server, conns := countingServer(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "ok")
+	})
+
+	ctx := context.Background()
+	c := newTestClient()
+	check := HTTPCheck{
+		Name:                   types.StringValue(t.Name()),
+		URL:                    types.StringValue(server.URL),
+		AssertConnectionReused: types.BoolValue(true),
+	}
+	if err := c.createHTTPCheck(ctx, &check); err != nil {
+		t.Fatalf("createHTTPCheck: %s", err)
+	}
+	run := func() CheckResult {
+		return c.probeHTTPCheck(ctx, &check)
+	}
+
+	// The first probe has nothing to reuse, so it isn't held against the check
+	if result := run(); result.Status.ValueString() != "SUCCESS" {
+		t.Fatalf("first probe status = %s, want SUCCESS (message %s)", result.Status.ValueString(), result.Message)
+	}
+	if result := run(); result.Status.ValueString() != "SUCCESS" {
+		t.Fatalf("second probe status = %s, want SUCCESS (message %s)", result.Status.ValueString(), result.Message)
+	}
+	if got := atomic.LoadInt32(conns); got != 1 {
+		t.Errorf("server saw %d connections over two probes, want 1", got)
+	}
+
+	// A server dropping idle connections is what the assertion surfaces. Give
+	// the probe's transport a moment to notice, as it would between runs.
+	server.CloseClientConnections()
+	time.Sleep(100 * time.Millisecond)
+	result := run()
+	if result.Status.ValueString() != "DEGRADED" || !strings.Contains(result.Message.ValueString(), "not reused") {
+		t.Errorf("probe after the server closed the connection = %s (message %s), want DEGRADED", result.Status.ValueString(), result.Message)
+	}
+
+	// Changing a connection setting starts a fresh pool, and a fresh first probe
+	check.ExpectContinue = types.BoolValue(true)
+	if result := run(); result.Status.ValueString() != "SUCCESS" {
+		t.Errorf("first probe with new settings = %s (message %s), want SUCCESS", result.Status.ValueString(), result.Message)
+	}
+}
+
+func TestProbeConnectionNotKeptAlive(t *testing.T) {
+	server, conns := countingServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Connection", "close")
+		fmt.Fprint(w, "ok")
+	})
+
+	ctx := context.Background()
+	c := newTestClient()
+	check := HTTPCheck{
+		Name:                   types.StringValue(t.Name()),
+		URL:                    types.StringValue(server.URL),
+		AssertConnectionReused: types.BoolValue(true),
+	}
+	if err := c.createHTTPCheck(ctx, &check); err != nil {
+		t.Fatalf("createHTTPCheck: %s", err)
+	}
+
+	var statuses []string
+	for i := 0; i < 3; i++ {
+		result := c.probeHTTPCheck(ctx, &check)
+		statuses = append(statuses, result.Status.ValueString())
+	}
+	if want := "SUCCESS DEGRADED DEGRADED"; strings.Join(statuses, " ") != want {
+		t.Errorf("statuses = %v, want %s", statuses, want)
+	}
+	if got := atomic.LoadInt32(conns); got != 3 {
+		t.Errorf("server saw %d connections over three probes, want 3", got)
+	}
+}
+
+func TestProbeConnectionReuseNotAsserted(t *testing.T) {
+	server, _ := countingServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Connection", "close")
+		fmt.Fprint(w, "ok")
+	})
+
+	c := newTestClient()
+	check := HTTPCheck{URL: types.StringValue(server.URL)}
+	for i := 0; i < 2; i++ {
+		if result := runHTTPCheck(t, c, check); result.Status.ValueString() != "SUCCESS" {
+			t.Errorf("probe %d status = %s (message %s), want SUCCESS", i+1, result.Status.ValueString(), result.Message)
+		}
+	}
+}
+
+// recordingRoundTripper is a transport that records whether its idle
+// connections were closed
+type recordingRoundTripper struct {
+	http.RoundTripper
+	closed int32
+}
+
+func (r *recordingRoundTripper) CloseIdleConnections() {
+	atomic.AddInt32(&r.closed, 1)
+}
+
+func TestTransportPool(t *testing.T) {
+	var p transportPool
+	first, second, changed := &recordingRoundTripper{}, &recordingRoundTripper{}, &recordingRoundTripper{}
+
+	if got, kept := p.transport("hc-1", "https://example.com", "a", first); got != first || kept {
+		t.Fatalf("first probe got kept = %t, want the fresh transport", kept)
+	}
+	if got, kept := p.transport("hc-1", "https://example.com", "a", second); got != first || !kept {
+		t.Fatalf("second probe got kept = %t, want the first transport", kept)
+	}
+
+	// Changed settings replace the kept transport and close its connections
+	if got, kept := p.transport("hc-1", "https://example.com", "b", changed); got != changed || kept {
+		t.Fatalf("probe with changed settings got kept = %t, want the fresh transport", kept)
+	}
+	if atomic.LoadInt32(&first.closed) != 1 {
+		t.Error("replaced transport's idle connections not closed")
+	}
+
+	other := &recordingRoundTripper{}
+	p.transport("hc-2", "https://example.com", "b", other)
+	p.drop("hc-1")
+	if atomic.LoadInt32(&changed.closed) != 1 {
+		t.Error("dropped transport's idle connections not closed")
+	}
+	if atomic.LoadInt32(&other.closed) != 0 {
+		t.Error("another check's transport closed")
+	}
+	if _, kept := p.transport("hc-1", "https://example.com", "b", &recordingRoundTripper{}); kept {
+		t.Error("transport still kept after drop")
+	}
+}
+
+// openConnServer starts a server and returns a function reporting how many
+// connections to it are open
+func openConnServer(t *testing.T) (*httptest.Server, func() int32) {
+	t.Helper()
+	var open int32
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "ok")
+	}))
+	server.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		switch state {
+		case http.StateNew:
+			atomic.AddInt32(&open, 1)
+		case http.StateClosed, http.StateHijacked:
+			atomic.AddInt32(&open, -1)
+		}
+	}
+	server.Start()
+	t.Cleanup(server.Close)
+	return server, func() int32 { return atomic.LoadInt32(&open) }
+}
+
+// waitForOpenConns waits for the server to see want open connections
+func waitForOpenConns(t *testing.T, open func() int32, want int32, after string) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for open() != want {
+		if time.Now().After(deadline) {
+			t.Fatalf("%d connections open %s, want %d", open(), after, want)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestProbeKeptConnectionsClosed(t *testing.T) {
+	server, open := openConnServer(t)
+
+	ctx := context.Background()
+	c := newTestClient()
+	check := HTTPCheck{
+		Name:                   types.StringValue(t.Name()),
+		URL:                    types.StringValue(server.URL),
+		AssertConnectionReused: types.BoolValue(true),
+	}
+	if err := c.createHTTPCheck(ctx, &check); err != nil {
+		t.Fatalf("createHTTPCheck: %s", err)
+	}
+
+	c.probeHTTPCheck(ctx, &check)
+	waitForOpenConns(t, open, 1, "after the first probe")
+
+	check.ExpectContinue = types.BoolValue(true)
+	c.probeHTTPCheck(ctx, &check)
+	waitForOpenConns(t, open, 1, "after a probe with changed settings")
+
+	check.AssertConnectionReused = types.BoolValue(false)
+	c.probeHTTPCheck(ctx, &check)
+	waitForOpenConns(t, open, 0, "once assert_connection_reused is off")
+
+	check.AssertConnectionReused = types.BoolValue(true)
+	c.probeHTTPCheck(ctx, &check)
+	waitForOpenConns(t, open, 1, "after asserting reuse again")
+	if err := c.deleteHTTPCheck(ctx, check.ID.ValueString()); err != nil {
+		t.Fatalf("deleteHTTPCheck: %s", err)
+	}
+	waitForOpenConns(t, open, 0, "after the check was deleted")
+}
+
+func TestProbeKeptTCPConnectionsClosed(t *testing.T) {
+	var open int32
+	host, port := serveTCP(t, func(conn net.Conn) {
+		atomic.AddInt32(&open, 1)
+		defer atomic.AddInt32(&open, -1)
+		// Hold the connection until the probe closes it
+		_, _ = io.Copy(io.Discard, conn)
+	})
+	openConns := func() int32 { return atomic.LoadInt32(&open) }
+
+	ctx := context.Background()
+	c := newTestClient()
+	check := TCPCheck{
+		Name:          types.StringValue(t.Name()),
+		Host:          types.StringValue(host),
+		Port:          types.Int64Value(port),
+		SendKeepalive: types.BoolValue(true),
+	}
+	if err := c.createTCPCheck(ctx, &check); err != nil {
+		t.Fatalf("createTCPCheck: %s", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if result := c.probeTCPCheck(ctx, &check); result.Status.ValueString() != "SUCCESS" {
+			t.Fatalf("probe %d status = %s (failure reason %s)", i+1, result.Status.ValueString(), result.FailureReason)
+		}
+	}
+	waitForOpenConns(t, openConns, 1, "after two probes with send_keepalive")
+
+	check.SendKeepalive = types.BoolValue(false)
+	c.probeTCPCheck(ctx, &check)
+	waitForOpenConns(t, openConns, 0, "once send_keepalive is off")
+
+	check.SendKeepalive = types.BoolValue(true)
+	c.probeTCPCheck(ctx, &check)
+	waitForOpenConns(t, openConns, 1, "after sending keepalives again")
+	if err := c.deleteTCPCheck(ctx, check.ID.ValueString()); err != nil {
+		t.Fatalf("deleteTCPCheck: %s", err)
+	}
+	waitForOpenConns(t, openConns, 0, "after the check was deleted")
+}
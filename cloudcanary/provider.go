@@ -4,32 +4,59 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"os"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
+// defaultMaxAllowedLimit is the largest data source limit honored unless max_allowed_limit is set
+const defaultMaxAllowedLimit = 10000
+
+// Keys of the tags added to checks the provider creates
+const (
+	autoTagManagedBy = "managed-by"
+	autoTagWorkspace = "terraform-workspace"
+)
+
+// defaultAllowedEnvironments are the environments a check may be scoped to unless allowed_environments is set
+var defaultAllowedEnvironments = []string{"prod", "staging", "dev"}
+
 // Ensure the implementation satisfies the expected interfaces
 var (
 	_ provider.Provider = &cloudCanaryProvider{}
 )
 
 // CloudCanaryProvider defines the provider implementation.
-type cloudCanaryProvider struct{}
+type cloudCanaryProvider struct {
+	// version is set to the provider version on release, "dev" when built
+	// locally
+	version string
+}
 
-// New creates a new provider instance
-func New() provider.Provider {
-	return &cloudCanaryProvider{}
+// New returns a function that creates provider instances of the given version
+func New(version string) func() provider.Provider {
+	return func() provider.Provider {
+		return &cloudCanaryProvider{
+			version: version,
+		}
+	}
 }
 
 // Metadata returns the provider type name.
 func (p *cloudCanaryProvider) Metadata(_ context.Context, _ provider.MetadataRequest, resp *provider.MetadataResponse) {
 	resp.TypeName = "cloudcanary"
+	resp.Version = p.version
 }
 
 // Schema defines the provider-level schema for configuration data.
@@ -38,13 +65,77 @@ func (p *cloudCanaryProvider) Schema(_ context.Context, _ provider.SchemaRequest
 		Description: "CloudCanary provider for monitoring HTTP endpoints and APIs.",
 		Attributes: map[string]schema.Attribute{
 			"api_key": schema.StringAttribute{
-				Required:    true,
+				Optional:    true,
 				Sensitive:   true,
-				Description: "API key for CloudCanary service.",
+				Description: "API key for CloudCanary service. May also be set with the CLOUDCANARY_API_KEY environment variable or in credentials_file.",
 			},
 			"base_url": schema.StringAttribute{
 				Optional:    true,
-				Description: "Base URL for the CloudCanary API.",
+				Description: "Base URL for the CloudCanary API. May also be set with the CLOUDCANARY_BASE_URL environment variable or in credentials_file.",
+			},
+			"organization_id": schema.StringAttribute{
+				Optional:    true,
+				Description: "Organization to scope every API call to, for accounts with several organizations. May also be set with the CLOUDCANARY_ORGANIZATION_ID environment variable or in credentials_file. Required when the account has more than one organization.",
+			},
+			"credentials_file": schema.StringAttribute{
+				Optional:    true,
+				Description: "Path to a JSON file containing api_key, base_url and optionally organization_id. Values set in the provider block or the environment take precedence.",
+			},
+			"skip_auth_verification": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Skip verifying the API key during provider configuration. Defaults to false.",
+			},
+			"circuit_breaker_threshold": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Number of consecutive API calls failing with a timeout, connection reset, 429 or 5xx after which further calls fail immediately until the cooldown has passed. Set to 0 to disable. Defaults to 5.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"circuit_breaker_cooldown": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Seconds to wait after the circuit breaker opens before letting a single probe call through. Defaults to 30.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"enforce_unique_names": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Refuse to create a check when one with the same name already exists. Costs an extra API call per create. Defaults to false.",
+			},
+			"max_allowed_limit": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Upper bound on the limit of data sources. Larger limits are clamped with a warning. Defaults to 10000.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"allowed_environments": schema.ListAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "Values a check's environment attribute may take. Defaults to prod, staging and dev.",
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+			},
+			"auto_tag": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Tag every check the provider creates with managed-by = terraform, for auditing. Defaults to true.",
+			},
+			"auto_tag_workspace": schema.StringAttribute{
+				Optional:    true,
+				Description: "Workspace identifier added to the automatic tags as terraform-workspace, e.g. terraform.workspace. Ignored when auto_tag is false.",
+			},
+			"structured_errors": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Give failed check operations a JSON-encoded diagnostic detail, holding the message, API status, request ID and the check's attributes, for automation to parse. The summary stays human-readable. Defaults to false.",
+			},
+			"duplicate_headers": schema.StringAttribute{
+				Optional:    true,
+				Description: "How to read back a check header the API returns more than once: join joins the values with \", \" in the order returned, first keeps only the first. Defaults to join.",
+				Validators: []validator.String{
+					stringvalidator.OneOf(duplicateHeaderPolicies...),
+				},
 			},
 		},
 	}
@@ -62,45 +153,136 @@ func (p *cloudCanaryProvider) Configure(ctx context.Context, req provider.Config
 		return
 	}
 
-	// Set defaults
-	baseURL := "https://api.cloudcanary.io/v1"
-	if !config.BaseURL.IsNull() {
-		baseURL = config.BaseURL.ValueString()
+	// Load the credentials file first so that anything set explicitly overrides it
+	var creds credentialsFile
+	if !config.CredentialsFile.IsNull() {
+		loaded, err := loadCredentialsFile(config.CredentialsFile.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("credentials_file"),
+				"Invalid Credentials File",
+				fmt.Sprintf("Could not load credentials file: %s", err),
+			)
+			return
+		}
+		creds = *loaded
 	}
 
+	// Resolve each setting from the provider block, then the environment,
+	// then the credentials file
+	baseURL := firstNonEmpty(config.BaseURL.ValueString(), os.Getenv("CLOUDCANARY_BASE_URL"), creds.BaseURL, "https://api.cloudcanary.io/v1")
+
 	// Initialize the client
-	apiKey := config.APIKey.ValueString()
+	apiKey := firstNonEmpty(config.APIKey.ValueString(), os.Getenv("CLOUDCANARY_API_KEY"), creds.APIKey)
 	if apiKey == "" {
 		resp.Diagnostics.AddError(
 			"Missing API Key",
-			"The API key is required to authenticate with CloudCanary.",
+			"The API key is required to authenticate with CloudCanary. Set api_key, the CLOUDCANARY_API_KEY environment variable, or credentials_file.",
 		)
 		return
 	}
 
+	// Scope calls to an organization when one is given
+	organizationID := firstNonEmpty(config.OrganizationID.ValueString(), os.Getenv("CLOUDCANARY_ORGANIZATION_ID"), creds.OrganizationID)
+
 	client := &cloudCanaryClient{
-		apiKey:  apiKey,
-		baseURL: baseURL,
+		apiKey:         apiKey,
+		baseURL:        baseURL,
+		organizationID: organizationID,
 		httpClient: &http.Client{
 			Timeout: 30 * time.Second,
 		},
+		enforceUniqueNames: config.EnforceUniqueNames.ValueBool(),
+		providerVersion:    p.version,
+		retryDelay:         500 * time.Millisecond,
 	}
 
-	// Verify authentication
-	err := client.verifyAuth(ctx)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Failed to authenticate with CloudCanary",
-			fmt.Sprintf("Error verifying authentication: %s", err),
-		)
-		return
+	// Stop hammering the API once it is clearly failing
+	threshold := 5
+	if !config.CircuitBreakerThreshold.IsNull() {
+		threshold = int(config.CircuitBreakerThreshold.ValueInt64())
+	}
+	cooldown := 30 * time.Second
+	if !config.CircuitBreakerCooldown.IsNull() {
+		cooldown = time.Duration(config.CircuitBreakerCooldown.ValueInt64()) * time.Second
+	}
+	client.breaker = newCircuitBreaker(threshold, cooldown)
+
+	// Cap how many results a single data source read may request
+	client.maxAllowedLimit = defaultMaxAllowedLimit
+	if !config.MaxAllowedLimit.IsNull() {
+		client.maxAllowedLimit = int(config.MaxAllowedLimit.ValueInt64())
+	}
+
+	// Restrict the environments checks can be scoped to. Copy the defaults so
+	// no two clients share a backing array.
+	client.allowedEnvironments = append([]string(nil), defaultAllowedEnvironments...)
+	if !config.AllowedEnvironments.IsNull() {
+		var allowed []string
+		diags = config.AllowedEnvironments.ElementsAs(ctx, &allowed, false)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		client.allowedEnvironments = allowed
+	}
+
+	// Decide how repeated header names are read back
+	client.duplicateHeaders = duplicateHeadersJoin
+	if !config.DuplicateHeaders.IsNull() {
+		client.duplicateHeaders = config.DuplicateHeaders.ValueString()
+	}
+
+	client.structuredErrors = config.StructuredErrors.ValueBool()
+
+	// Mark checks created here as managed by Terraform
+	if config.AutoTag.IsNull() || config.AutoTag.ValueBool() {
+		client.autoTags = map[string]string{autoTagManagedBy: "terraform"}
+		if workspace := config.AutoTagWorkspace.ValueString(); workspace != "" {
+			client.autoTags[autoTagWorkspace] = workspace
+		}
+	}
+
+	// Verify authentication unless explicitly disabled
+	if config.SkipAuthVerification.ValueBool() {
+		tflog.Debug(ctx, "Skipping CloudCanary authentication verification")
+	} else {
+		err := client.verifyAuth(ctx)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Failed to authenticate with CloudCanary",
+				fmt.Sprintf("Error verifying authentication: %s", err),
+			)
+			return
+		}
+
+		// Accounts with several organizations only accept calls scoped to one
+		if organizationID == "" {
+			info, err := client.getAPIInfo(ctx)
+			if err != nil {
+				resp.Diagnostics.AddError(
+					"Failed to read CloudCanary API info",
+					fmt.Sprintf("Error reading API info: %s", err),
+				)
+				return
+			}
+			if info.OrganizationRequired {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("organization_id"),
+					"Missing Organization ID",
+					"This CloudCanary account has several organizations, so API calls must be scoped to one. Set organization_id, the CLOUDCANARY_ORGANIZATION_ID environment variable, or organization_id in credentials_file.",
+				)
+				return
+			}
+		}
 	}
 
 	resp.ResourceData = client
 	resp.DataSourceData = client
 
 	tflog.Info(ctx, "Configured CloudCanary provider", map[string]any{
-		"base_url": baseURL,
+		"base_url":        baseURL,
+		"organization_id": organizationID,
 	})
 }
 
@@ -109,6 +291,11 @@ func (p *cloudCanaryProvider) Resources(ctx context.Context) []func() resource.R
 	return []func() resource.Resource{
 		NewHTTPCheckResource,
 		NewAPICheckResource,
+		NewTCPCheckResource,
+		NewWebSocketCheckResource,
+		NewCompositeCheckResource,
+		NewCheckProbeResource,
+		NewCheckCleanupResource,
 	}
 }
 
@@ -116,11 +303,46 @@ func (p *cloudCanaryProvider) Resources(ctx context.Context) []func() resource.R
 func (p *cloudCanaryProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		NewCheckResultsDataSource,
+		NewCheckResultsStreamDataSource,
+		NewCheckStatsDataSource,
+		NewAPIInfoDataSource,
+		NewQuotaDataSource,
+		NewHealthSummaryDataSource,
+		NewPrometheusMetricsDataSource,
+		NewCheckImportCandidatesDataSource,
+		NewCheckDriftDataSource,
+		NewCheckHistoryDataSource,
+		NewPostDeployCheckDataSource,
+		NewIncidentsDataSource,
+		NewLatestResultDataSource,
+		NewGroupStatsDataSource,
 	}
 }
 
 // providerConfig stores API configuration
 type providerConfig struct {
-	APIKey  types.String `tfsdk:"api_key"`
-	BaseURL types.String `tfsdk:"base_url"`
-}
\ No newline at end of file
+	APIKey                  types.String `tfsdk:"api_key"`
+	BaseURL                 types.String `tfsdk:"base_url"`
+	OrganizationID          types.String `tfsdk:"organization_id"`
+	SkipAuthVerification    types.Bool   `tfsdk:"skip_auth_verification"`
+	EnforceUniqueNames      types.Bool   `tfsdk:"enforce_unique_names"`
+	CredentialsFile         types.String `tfsdk:"credentials_file"`
+	CircuitBreakerThreshold types.Int64  `tfsdk:"circuit_breaker_threshold"`
+	CircuitBreakerCooldown  types.Int64  `tfsdk:"circuit_breaker_cooldown"`
+	MaxAllowedLimit         types.Int64  `tfsdk:"max_allowed_limit"`
+	AllowedEnvironments     types.List   `tfsdk:"allowed_environments"`
+	DuplicateHeaders        types.String `tfsdk:"duplicate_headers"`
+	StructuredErrors        types.Bool   `tfsdk:"structured_errors"`
+	AutoTag                 types.Bool   `tfsdk:"auto_tag"`
+	AutoTagWorkspace        types.String `tfsdk:"auto_tag_workspace"`
+}
+
+// firstNonEmpty returns the first of values that is not empty
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -3,13 +3,19 @@ package cloudcanary
 import (
 	"context"
 	"fmt"
-	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -21,6 +27,8 @@ type apiCheckResource struct {
 // Ensure the implementation satisfies the expected interfaces
 var _ resource.Resource = &apiCheckResource{}
 var _ resource.ResourceWithImportState = &apiCheckResource{}
+var _ resource.ResourceWithUpgradeState = &apiCheckResource{}
+var _ resource.ResourceWithConfigValidators = &apiCheckResource{}
 
 // NewAPICheckResource creates a new API check resource
 func NewAPICheckResource() resource.Resource {
@@ -35,6 +43,7 @@ func (r *apiCheckResource) Metadata(_ context.Context, req resource.MetadataRequ
 // Schema defines the schema for the resource
 func (r *apiCheckResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version:     1,
 		Description: "Manages an API check for a web API endpoint.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
@@ -48,6 +57,33 @@ func (r *apiCheckResource) Schema(_ context.Context, _ resource.SchemaRequest, r
 				Required:    true,
 				Description: "The name of the check.",
 			},
+			"environment": schema.StringAttribute{
+				Optional:    true,
+				Description: "Environment the check belongs to, e.g. prod, staging or dev. Used for filtering and display; must be one of the provider's allowed_environments.",
+			},
+			"description": schema.StringAttribute{
+				Optional:    true,
+				Description: "Free-form notes on what the check covers, for responders. Has no effect on how the check runs.",
+			},
+			"runbook_url": schema.StringAttribute{
+				Optional:    true,
+				Description: "URL of the runbook to follow when the check fails. Reported on the check's incidents; has no effect on how the check runs.",
+				Validators: []validator.String{
+					validHTTPURL(),
+				},
+			},
+			"severity": schema.StringAttribute{
+				Optional:    true,
+				Description: "Priority alerts for the check are routed with: info, warning or critical. For example, critical checks can page while info checks only log.",
+				Validators: []validator.String{
+					stringvalidator.OneOf(checkSeverities...),
+				},
+			},
+			"result_labels": schema.MapAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "Labels attached to every result of the check, such as team or service, so results can be grouped without looking the check up.",
+			},
 			"endpoint": schema.StringAttribute{
 				Required:    true,
 				Description: "The API endpoint URL to check.",
@@ -61,48 +97,279 @@ func (r *apiCheckResource) Schema(_ context.Context, _ resource.SchemaRequest, r
 				Optional:    true,
 				Description: "HTTP headers to include in the request.",
 			},
+			"accept": schema.StringAttribute{
+				Optional:    true,
+				Description: "Accept header the probe sends, to pin the format negotiated with endpoints that serve several (e.g. application/json). Replaces an Accept header in headers. When expected_content_type is also set, the response's media type must be one accept allows. Defaults to */*, or the Accept header in headers.",
+				Validators: []validator.String{
+					validAccept(),
+				},
+			},
 			"body": schema.StringAttribute{
 				Optional:    true,
 				Description: "HTTP request body, typically JSON for API requests.",
+				PlanModifiers: []planmodifier.String{
+					normalizeJSONBody(),
+				},
+			},
+			"normalize_json_body": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Whether to ignore whitespace and key order differences when the body is JSON.",
 			},
 			"expected_status": schema.Int64Attribute{
 				Optional:    true,
 				Description: "The expected HTTP status code.",
 			},
+			"success_status_codes": schema.ListAttribute{
+				ElementType: types.Int64Type,
+				Optional:    true,
+				Description: "Status codes that always count as SUCCESS, overriding expected_status and all other assertions.",
+				Validators: []validator.List{
+					listvalidator.ValueInt64sAre(int64validator.Between(100, 599)),
+				},
+			},
 			"response_validation": schema.ListAttribute{
 				ElementType: types.StringType,
 				Optional:    true,
 				Description: "JSONPath validation expressions to validate the response.",
 			},
+			"expected_content_type": schema.StringAttribute{
+				Optional:    true,
+				Description: "Expected media type of the response (e.g. application/json). Parameters such as charset are ignored.",
+			},
+			"expected_headers": schema.MapAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "Response headers the check asserts on, mapped to the value each must equal. Prefix a value with ~ to match it as a regular expression instead.",
+				Validators: []validator.Map{
+					validHeaderExpectations(),
+				},
+			},
+			"expected_json_body": schema.StringAttribute{
+				Optional:    true,
+				Description: "JSON document the response body must equal, ignoring key and array element order.",
+				Validators: []validator.String{
+					validJSON(),
+				},
+			},
+			"ignore_paths": schema.ListAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "JSONPath expressions (e.g. $.meta.timestamp) excluded when comparing against expected_json_body.",
+				Validators: []validator.List{
+					validJSONPaths(),
+				},
+			},
+			"strict_json": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Whether to fail the check when the response is not strictly valid JSON (duplicate keys or trailing data). Defaults to false.",
+			},
+			"require_valid_json": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Whether to fail the check when the response body doesn't parse as JSON, even if no JSON assertion is configured. Catches HTML error pages served with a 200. Defaults to false.",
+			},
+			"fail_on_json_error_field": schema.StringAttribute{
+				Optional:    true,
+				Description: "JSONPath of an error field that fails the check whenever it is present and non-empty, whatever the status code, to catch errors reported with 200 as GraphQL APIs do. Defaults to $.errors; set to an empty string to disable. Bodies that aren't JSON are not checked.",
+				Validators: []validator.String{
+					validJSONPath(),
+				},
+			},
+			"extract": schema.MapAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "Values to extract from the latest successful response, as a map of name to JSONPath expression (e.g. version = \"$.version\").",
+				Validators: []validator.Map{
+					validJSONPathValues(),
+				},
+			},
+			"extracted_values": schema.MapAttribute{
+				ElementType: types.StringType,
+				Computed:    true,
+				Description: "Values extracted from the latest successful response using extract, keyed by name.",
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.UseStateForUnknown(),
+				},
+			},
 			"interval": schema.Int64Attribute{
 				Optional:    true,
 				Description: "Check interval in seconds.",
 			},
+			"jitter_seconds": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Maximum random delay in seconds the server adds to each run to stagger checks sharing an interval. Must be less than interval.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"warmup_period": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Seconds after the check is created during which failures are recorded in results but don't trigger alerts. Defaults to 0.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"priority": schema.StringAttribute{
+				Optional:    true,
+				Description: "Execution tier the check is scheduled in (low, normal, high). Once set, the interval must be at least the tier minimum: 300 seconds for low, 60 for normal and 10 for high. Defaults to normal.",
+				Validators: []validator.String{
+					stringvalidator.OneOf("low", "normal", "high"),
+				},
+			},
 			"timeout": schema.Int64Attribute{
 				Optional:    true,
 				Description: "Timeout in seconds.",
 			},
 			"auth_type": schema.StringAttribute{
 				Optional:    true,
-				Description: "Authentication type (none, basic, bearer, api_key).",
+				Description: "Authentication type (none, basic, bearer, api_key, jwt, aws_sigv4, token_refresh).",
+			},
+			"run_if_check_id": schema.StringAttribute{
+				Optional:    true,
+				Description: "ID of a prerequisite check; this check only runs while that check is in run_if_status.",
+				Validators: []validator.String{
+					validCheckID(),
+				},
+			},
+			"run_if_status": schema.StringAttribute{
+				Optional:    true,
+				Description: "Status the prerequisite check must be in for this check to run (SUCCESS, FAILURE).",
+				Validators: []validator.String{
+					stringvalidator.OneOf("SUCCESS", "FAILURE"),
+				},
 			},
 			"auth_value": schema.StringAttribute{
 				Optional:    true,
 				Sensitive:   true,
-				Description: "Authentication value (token, API key, etc.).",
+				Description: "Authentication value (token, API key, etc.). With auth_type token_refresh, the optional bearer token sent to token_refresh_url.",
+			},
+			"jwt_secret": schema.StringAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				Description: "Key used to sign the JWT when auth_type is jwt: the shared secret for HS256, or a PEM encoded RSA private key for RS256.",
+			},
+			"jwt_claims": schema.MapAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "Claims included in the JWT when auth_type is jwt. iat and exp are set on every probe and can't be configured.",
+			},
+			"jwt_algorithm": schema.StringAttribute{
+				Optional:    true,
+				Description: "Algorithm used to sign the JWT when auth_type is jwt (HS256, RS256). Defaults to HS256.",
+				Validators: []validator.String{
+					stringvalidator.OneOf(jwtAlgorithms...),
+				},
+			},
+			"aws_access_key_id": schema.StringAttribute{
+				Optional:    true,
+				Description: "AWS access key ID used to sign probe requests when auth_type is aws_sigv4.",
+			},
+			"aws_secret_access_key": schema.StringAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				Description: "AWS secret access key used to sign probe requests when auth_type is aws_sigv4.",
+			},
+			"aws_region": schema.StringAttribute{
+				Optional:    true,
+				Description: "AWS region of the endpoint when auth_type is aws_sigv4, e.g. us-east-1.",
+			},
+			"aws_service": schema.StringAttribute{
+				Optional:    true,
+				Description: "AWS service name the request is signed for when auth_type is aws_sigv4, e.g. execute-api for API Gateway.",
+			},
+			"token_refresh_url": schema.StringAttribute{
+				Optional:    true,
+				Description: "URL the token is fetched from when auth_type is token_refresh. It is called with a POST, sending auth_value as a bearer token when set.",
+				Validators: []validator.String{
+					validHTTPURL(),
+				},
+			},
+			"token_refresh_json_path": schema.StringAttribute{
+				Optional:    true,
+				Description: "JSONPath to the token in the response from token_refresh_url when auth_type is token_refresh, e.g. $.access_token.",
+				Validators: []validator.String{
+					validJSONPath(),
+				},
 			},
 			"last_result": schema.StringAttribute{
 				Computed:    true,
-				Description: "The result of the last check (SUCCESS, FAILURE).",
+				Description: "The result of the last check (UNKNOWN, PENDING, SUCCESS, DEGRADED, FAILURE). UNKNOWN until the check has produced a result.",
 			},
 			"last_check_time": schema.StringAttribute{
 				Computed:    true,
-				Description: "The time of the last check.",
+				Description: "The time of the last check. Null while last_result is UNKNOWN.",
+			},
+			"next_check_time": schema.StringAttribute{
+				Computed:    true,
+				Description: "When the check is next scheduled to run. Taken from the API when it reports one, otherwise derived from last_check_time and interval. Null while last_result is UNKNOWN.",
+			},
+			"is_flapping": schema.BoolAttribute{
+				Computed:    true,
+				Description: "Whether the check changed status at least 3 times over the last hour.",
+			},
+			"flap_count_1h": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Number of times the check changed status over the last hour.",
 			},
+			"last_result_detail": schema.SingleNestedAttribute{
+				Computed:    true,
+				Description: "Details of the most recent check result.",
+				PlanModifiers: []planmodifier.Object{
+					objectplanmodifier.UseStateForUnknown(),
+				},
+				Attributes: map[string]schema.Attribute{
+					"status": schema.StringAttribute{
+						Computed:    true,
+						Description: "The status of the check (SUCCESS, FAILURE).",
+					},
+					"response_time": schema.Int64Attribute{
+						Computed:    true,
+						Description: "Response time in milliseconds.",
+					},
+					"response_code": schema.Int64Attribute{
+						Computed:    true,
+						Description: "HTTP response code.",
+					},
+					"message": schema.StringAttribute{
+						Computed:    true,
+						Description: "Message associated with the result.",
+					},
+				},
+			},
+			"created_at": schema.StringAttribute{
+				Computed:    true,
+				Description: "When the check was created (RFC3339 format).",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"updated_at": schema.StringAttribute{
+				Computed:    true,
+				Description: "When the check was last updated (RFC3339 format).",
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"active_schedule": activeScheduleBlock(),
+			"timeouts":        timeoutsBlock(),
 		},
 	}
 }
 
+// ConfigValidators returns validators that check relationships between attributes
+func (r *apiCheckResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		jitterValidator{defaultInterval: 300},
+		priorityIntervalValidator{defaultInterval: 300},
+		jwtAuthValidator{},
+		sigV4AuthValidator{},
+		tokenRefreshAuthValidator{},
+		acceptContentTypeValidator{},
+		resourcevalidator.RequiredTogether(
+			path.MatchRoot("run_if_check_id"),
+			path.MatchRoot("run_if_status"),
+		),
+	}
+}
+
 // Configure adds the provider configured client to the resource
 func (r *apiCheckResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
@@ -131,38 +398,109 @@ func (r *apiCheckResource) Create(ctx context.Context, req resource.CreateReques
 		return
 	}
 
+	// Bound the whole operation by the configured or default timeout
+	ctx, cancel := context.WithTimeout(ctx, plan.Timeouts.create())
+	defer cancel()
+
+	// Fail early when the account can't create this type of check, rather
+	// than with whatever error the API gives
+	if err := r.client.ensureCheckTypeEnabled(ctx, "api"); err != nil {
+		resp.Diagnostics.AddError(
+			"Check Type Not Enabled",
+			err.Error(),
+		)
+		return
+	}
+
+	// Only accept environments the provider allows
+	if err := r.client.ensureAllowedEnvironment(plan.Environment); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("environment"),
+			"Invalid Environment",
+			err.Error(),
+		)
+		return
+	}
+
+	// Guard against duplicate names when enabled on the provider
+	if err := r.client.ensureUniqueName(ctx, plan.Name.ValueString()); err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating API check",
+			r.client.errorDetail(fmt.Sprintf("Could not create API check: %s", err), err, map[string]string{"name": plan.Name.ValueString()}),
+		)
+		return
+	}
+
 	// Create a working copy for the API call
 	// This allows us to use defaults for the API call without modifying the plan
 	apiCheck := APICheck{
 		Name:     plan.Name,
 		Endpoint: plan.Endpoint,
 	}
-	
+
 	// Copy all other fields directly from plan
 	apiCheck.Method = plan.Method
 	apiCheck.Headers = plan.Headers
+	apiCheck.Accept = plan.Accept
 	apiCheck.Body = plan.Body
+	apiCheck.NormalizeJSONBody = plan.NormalizeJSONBody
 	apiCheck.ExpectedStatus = plan.ExpectedStatus
+	apiCheck.SuccessStatusCodes = plan.SuccessStatusCodes
 	apiCheck.ResponseValidation = plan.ResponseValidation
+	apiCheck.ExpectedContentType = plan.ExpectedContentType
+	apiCheck.ExpectedHeaders = plan.ExpectedHeaders
+	apiCheck.ExpectedJSONBody = plan.ExpectedJSONBody
+	apiCheck.IgnorePaths = plan.IgnorePaths
+	apiCheck.StrictJSON = plan.StrictJSON
+	apiCheck.RequireValidJSON = plan.RequireValidJSON
+	apiCheck.FailOnJSONErrorField = plan.FailOnJSONErrorField
+	apiCheck.Extract = plan.Extract
 	apiCheck.Interval = plan.Interval
+	apiCheck.JitterSeconds = plan.JitterSeconds
+	apiCheck.WarmupPeriod = plan.WarmupPeriod
+	apiCheck.Priority = plan.Priority
 	apiCheck.Timeout = plan.Timeout
+	apiCheck.Environment = plan.Environment
+	apiCheck.Description = plan.Description
+	apiCheck.RunbookURL = plan.RunbookURL
+	apiCheck.Severity = plan.Severity
+	apiCheck.ResultLabels = plan.ResultLabels
 	apiCheck.AuthType = plan.AuthType
+	apiCheck.JWTClaims = plan.JWTClaims
+	apiCheck.JWTAlgorithm = plan.JWTAlgorithm
+	apiCheck.AWSAccessKeyID = plan.AWSAccessKeyID
+	apiCheck.AWSRegion = plan.AWSRegion
+	apiCheck.AWSService = plan.AWSService
+	apiCheck.TokenRefreshURL = plan.TokenRefreshURL
+	apiCheck.TokenRefreshJSONPath = plan.TokenRefreshJSONPath
+	apiCheck.RunIfCheckID = plan.RunIfCheckID
+	apiCheck.RunIfStatus = plan.RunIfStatus
+	apiCheck.ActiveSchedule = plan.ActiveSchedule
 	apiCheck.AuthValue = plan.AuthValue
+	apiCheck.JWTSecret = plan.JWTSecret
+	apiCheck.AWSSecretAccessKey = plan.AWSSecretAccessKey
 
 	// Call the API using the working copy
 	err := r.client.createAPICheck(ctx, &apiCheck)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating API check",
-			fmt.Sprintf("Could not create API check: %s", err),
+			r.client.errorDetail(fmt.Sprintf("Could not create API check: %s", err), err, map[string]string{"name": plan.Name.ValueString()}),
 		)
 		return
 	}
 
 	// Now update the original plan with only computed fields
 	plan.ID = apiCheck.ID
-	plan.LastResult = types.StringValue("PENDING")
-	plan.LastCheckTime = types.StringValue(time.Now().Format(time.RFC3339))
+	plan.CreatedAt = apiCheck.CreatedAt
+	plan.UpdatedAt = apiCheck.UpdatedAt
+	plan.LastResult = types.StringValue(lastResultUnknown)
+	plan.LastCheckTime = types.StringNull()
+	plan.NextCheckTime = types.StringNull()
+	plan.IsFlapping = types.BoolValue(false)
+	plan.FlapCount1h = types.Int64Value(0)
+	plan.LastResultDetail = types.ObjectNull(lastResultDetailAttrTypes)
+	plan.ExtractedValues = types.MapNull(types.StringType)
 
 	// Set state
 	diags = resp.State.Set(ctx, plan)
@@ -179,12 +517,16 @@ func (r *apiCheckResource) Read(ctx context.Context, req resource.ReadRequest, r
 		return
 	}
 
+	// Bound the whole operation by the configured or default timeout
+	ctx, cancel := context.WithTimeout(ctx, state.Timeouts.read())
+	defer cancel()
+
 	// Call API to get the latest data
 	apiCheck, err := r.client.readAPICheck(ctx, state.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error reading API check",
-			fmt.Sprintf("Could not read API check ID %s: %s", state.ID.ValueString(), err),
+			r.client.errorDetail(fmt.Sprintf("Could not read API check ID %s: %s", state.ID.ValueString(), err), err, map[string]string{"id": state.ID.ValueString()}),
 		)
 		return
 	}
@@ -205,34 +547,183 @@ func (r *apiCheckResource) Read(ctx context.Context, req resource.ReadRequest, r
 	if !apiCheck.Headers.IsNull() {
 		state.Headers = apiCheck.Headers
 	}
+	if !apiCheck.Accept.IsNull() {
+		state.Accept = apiCheck.Accept
+	}
 	if !apiCheck.Body.IsNull() {
 		state.Body = apiCheck.Body
 	}
+	if !apiCheck.NormalizeJSONBody.IsNull() {
+		state.NormalizeJSONBody = apiCheck.NormalizeJSONBody
+	}
 	if !apiCheck.ExpectedStatus.IsNull() {
 		state.ExpectedStatus = apiCheck.ExpectedStatus
 	}
+	if !apiCheck.SuccessStatusCodes.IsNull() {
+		state.SuccessStatusCodes = apiCheck.SuccessStatusCodes
+	}
 	if !apiCheck.ResponseValidation.IsNull() {
 		state.ResponseValidation = apiCheck.ResponseValidation
 	}
+	if !apiCheck.ExpectedContentType.IsNull() {
+		state.ExpectedContentType = apiCheck.ExpectedContentType
+	}
+	if !apiCheck.ExpectedHeaders.IsNull() {
+		state.ExpectedHeaders = apiCheck.ExpectedHeaders
+	}
+	if !apiCheck.ExpectedJSONBody.IsNull() {
+		state.ExpectedJSONBody = apiCheck.ExpectedJSONBody
+	}
+	if !apiCheck.IgnorePaths.IsNull() {
+		state.IgnorePaths = apiCheck.IgnorePaths
+	}
+	if !apiCheck.StrictJSON.IsNull() {
+		state.StrictJSON = apiCheck.StrictJSON
+	}
+	if !apiCheck.RequireValidJSON.IsNull() {
+		state.RequireValidJSON = apiCheck.RequireValidJSON
+	}
+	if !apiCheck.FailOnJSONErrorField.IsNull() {
+		state.FailOnJSONErrorField = apiCheck.FailOnJSONErrorField
+	}
+	if !apiCheck.Extract.IsNull() {
+		state.Extract = apiCheck.Extract
+	}
 	if !apiCheck.Interval.IsNull() {
 		state.Interval = apiCheck.Interval
 	}
+	if !apiCheck.JitterSeconds.IsNull() {
+		state.JitterSeconds = apiCheck.JitterSeconds
+	}
+	if !apiCheck.WarmupPeriod.IsNull() {
+		state.WarmupPeriod = apiCheck.WarmupPeriod
+	}
+	if !apiCheck.Priority.IsNull() {
+		state.Priority = apiCheck.Priority
+	}
 	if !apiCheck.Timeout.IsNull() {
 		state.Timeout = apiCheck.Timeout
 	}
+	if !apiCheck.Environment.IsNull() {
+		state.Environment = apiCheck.Environment
+	}
+	if !apiCheck.Description.IsNull() {
+		state.Description = apiCheck.Description
+	}
+	if !apiCheck.RunbookURL.IsNull() {
+		state.RunbookURL = apiCheck.RunbookURL
+	}
+	if !apiCheck.Severity.IsNull() {
+		state.Severity = apiCheck.Severity
+	}
+	if !apiCheck.ResultLabels.IsNull() {
+		state.ResultLabels = apiCheck.ResultLabels
+	}
 	if !apiCheck.AuthType.IsNull() {
 		state.AuthType = apiCheck.AuthType
 	}
-	
+	if !apiCheck.JWTClaims.IsNull() {
+		state.JWTClaims = apiCheck.JWTClaims
+	}
+	if !apiCheck.JWTAlgorithm.IsNull() {
+		state.JWTAlgorithm = apiCheck.JWTAlgorithm
+	}
+	if !apiCheck.AWSAccessKeyID.IsNull() {
+		state.AWSAccessKeyID = apiCheck.AWSAccessKeyID
+	}
+	if !apiCheck.AWSRegion.IsNull() {
+		state.AWSRegion = apiCheck.AWSRegion
+	}
+	if !apiCheck.AWSService.IsNull() {
+		state.AWSService = apiCheck.AWSService
+	}
+	if !apiCheck.TokenRefreshURL.IsNull() {
+		state.TokenRefreshURL = apiCheck.TokenRefreshURL
+	}
+	if !apiCheck.TokenRefreshJSONPath.IsNull() {
+		state.TokenRefreshJSONPath = apiCheck.TokenRefreshJSONPath
+	}
+	if !apiCheck.RunIfCheckID.IsNull() {
+		state.RunIfCheckID = apiCheck.RunIfCheckID
+	}
+	if !apiCheck.RunIfStatus.IsNull() {
+		state.RunIfStatus = apiCheck.RunIfStatus
+	}
+	if apiCheck.ActiveSchedule != nil {
+		state.ActiveSchedule = apiCheck.ActiveSchedule
+	}
+
 	// Be extremely careful with sensitive values
 	// Only update auth_value if the new value isn't null AND the state value is null
 	if !apiCheck.AuthValue.IsNull() && state.AuthValue.IsNull() {
 		state.AuthValue = apiCheck.AuthValue
 	}
-	
+	if !apiCheck.JWTSecret.IsNull() && state.JWTSecret.IsNull() {
+		state.JWTSecret = apiCheck.JWTSecret
+	}
+	// The AWS secret key is write-only on the API side, so it is never read back
+
+	if !apiCheck.CreatedAt.IsNull() {
+		state.CreatedAt = apiCheck.CreatedAt
+	}
+
 	// Always update computed fields
-	state.LastResult = apiCheck.LastResult
-	state.LastCheckTime = apiCheck.LastCheckTime
+	state.LastResult, state.LastCheckTime = lastRun(apiCheck.LastResult, apiCheck.LastCheckTime)
+	state.NextCheckTime = nextRun(apiCheck.NextCheckTime, state.LastCheckTime, state.Interval)
+	state.UpdatedAt = apiCheck.UpdatedAt
+
+	// Derive flapping from how often the status changed over the last hour
+	stats, err := r.client.getCheckStats(ctx, state.ID.ValueString(), 1, "none")
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading API check stats",
+			fmt.Sprintf("Could not read stats for API check ID %s: %s", state.ID.ValueString(), err),
+		)
+		return
+	}
+	state.IsFlapping = stats.IsFlapping
+	state.FlapCount1h = stats.FlapCount1h
+
+	// Populate the latest result detail from the most recent result
+	results, err := r.client.getCheckResults(ctx, state.ID.ValueString(), recentResultsLimit, 1)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading API check results",
+			fmt.Sprintf("Could not read results for API check ID %s: %s", state.ID.ValueString(), err),
+		)
+		return
+	}
+	state.LastResultDetail = types.ObjectNull(lastResultDetailAttrTypes)
+	if len(results) > 0 {
+		state.LastResultDetail, diags = newLastResultDetail(ctx, results[0])
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// Extract values from the latest successful response
+	state.ExtractedValues = types.MapNull(types.StringType)
+	if !state.Extract.IsNull() {
+		for _, result := range results {
+			if result.Status.ValueString() != "SUCCESS" || result.ResponseBody.IsNull() {
+				continue
+			}
+
+			values, err := extractJSONValues(result.ResponseBody.ValueString(), mapStrings(state.Extract))
+			if err != nil {
+				resp.Diagnostics.AddWarning(
+					"Could not extract values from API check response",
+					fmt.Sprintf("The latest successful response for API check ID %s could not be parsed: %s", state.ID.ValueString(), err),
+				)
+				break
+			}
+
+			state.ExtractedValues, diags = types.MapValueFrom(ctx, types.StringType, values)
+			resp.Diagnostics.Append(diags...)
+			break
+		}
+	}
 
 	// Set state
 	diags = resp.State.Set(ctx, state)
@@ -251,6 +742,20 @@ func (r *apiCheckResource) Update(ctx context.Context, req resource.UpdateReques
 		return
 	}
 
+	// Bound the whole operation by the configured or default timeout
+	ctx, cancel := context.WithTimeout(ctx, plan.Timeouts.update())
+	defer cancel()
+
+	// Only accept environments the provider allows
+	if err := r.client.ensureAllowedEnvironment(plan.Environment); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("environment"),
+			"Invalid Environment",
+			err.Error(),
+		)
+		return
+	}
+
 	// Preserve the ID from state
 	plan.ID = state.ID
 
@@ -259,13 +764,17 @@ func (r *apiCheckResource) Update(ctx context.Context, req resource.UpdateReques
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error updating API check",
-			fmt.Sprintf("Could not update API check ID %s: %s", plan.ID.ValueString(), err),
+			r.client.errorDetail(fmt.Sprintf("Could not update API check ID %s: %s", plan.ID.ValueString(), err), err, map[string]string{"id": plan.ID.ValueString()}),
 		)
 		return
 	}
 
-	// Update computed fields
-	plan.LastCheckTime = types.StringValue(time.Now().Format(time.RFC3339))
+	// Update computed fields; changing a check doesn't run it
+	plan.LastResult = state.LastResult
+	plan.LastCheckTime = state.LastCheckTime
+	plan.NextCheckTime = state.NextCheckTime
+	plan.IsFlapping = state.IsFlapping
+	plan.FlapCount1h = state.FlapCount1h
 
 	// Set state
 	diags = resp.State.Set(ctx, plan)
@@ -282,12 +791,16 @@ func (r *apiCheckResource) Delete(ctx context.Context, req resource.DeleteReques
 		return
 	}
 
+	// Bound the whole operation by the configured or default timeout
+	ctx, cancel := context.WithTimeout(ctx, state.Timeouts.delete())
+	defer cancel()
+
 	// Call API to delete the check
 	err := r.client.deleteAPICheck(ctx, state.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error deleting API check",
-			fmt.Sprintf("Could not delete API check ID %s: %s", state.ID.ValueString(), err),
+			r.client.errorDetail(fmt.Sprintf("Could not delete API check ID %s: %s", state.ID.ValueString(), err), err, map[string]string{"id": state.ID.ValueString()}),
 		)
 		return
 	}
@@ -295,7 +808,20 @@ func (r *apiCheckResource) Delete(ctx context.Context, req resource.DeleteReques
 	// Terraform will remove the resource from state
 }
 
+// recentResultsLimit is how many recent results Read inspects to find the latest successful response
+const recentResultsLimit = 10
+
 // ImportState imports an existing resource into Terraform
 func (r *apiCheckResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
-}
\ No newline at end of file
+}
+
+// UpgradeState upgrades state stored by earlier schema versions
+func (r *apiCheckResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema:   apiCheckSchemaV0(),
+			StateUpgrader: upgradeAPICheckStateV0,
+		},
+	}
+}
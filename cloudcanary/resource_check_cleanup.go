@@ -0,0 +1,182 @@
+package cloudcanary
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// checkCleanupResource implements a bulk deletion of the checks carrying a tag
+type checkCleanupResource struct {
+	client *cloudCanaryClient
+}
+
+// Ensure the implementation satisfies the expected interfaces
+var _ resource.Resource = &checkCleanupResource{}
+
+// NewCheckCleanupResource creates a new check cleanup resource
+func NewCheckCleanupResource() resource.Resource {
+	return &checkCleanupResource{}
+}
+
+// Metadata returns the resource type name
+func (r *checkCleanupResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_check_cleanup"
+}
+
+// Schema defines the schema for the resource
+func (r *checkCleanupResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Deletes every check carrying a tag when created, for tearing down environments. Destructive: matching checks are deleted whoever manages them, and destroying this resource does not restore them. Change trigger to delete matching checks again.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The unique identifier for this cleanup run.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"tag_key": schema.StringAttribute{
+				Required:    true,
+				Description: "Key of the tag that marks checks for deletion.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"tag_value": schema.StringAttribute{
+				Optional:    true,
+				Description: "Value the tag must have. When unset, every check carrying tag_key is deleted, whatever its value.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"confirm": schema.BoolAttribute{
+				Required:    true,
+				Description: "Must be true, acknowledging that matching checks are deleted.",
+				Validators: []validator.Bool{
+					validConfirmed(),
+				},
+			},
+			"trigger": schema.StringAttribute{
+				Optional:    true,
+				Description: "Arbitrary value; changing it deletes the checks matching the tag again.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"deleted_count": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Number of checks deleted.",
+			},
+			"deleted_check_ids": schema.ListAttribute{
+				ElementType: types.StringType,
+				Computed:    true,
+				Description: "IDs of the checks deleted.",
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the resource
+func (r *checkCleanupResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*cloudCanaryClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *cloudCanaryClient, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+// Create deletes the checks carrying the tag and records which were deleted
+func (r *checkCleanupResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Get the plan
+	var plan CheckCleanup
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The validator rejects false, but confirm may have been unknown then
+	if !plan.Confirm.ValueBool() {
+		resp.Diagnostics.AddError(
+			"Cleanup Not Confirmed",
+			"Set confirm = true to delete the checks carrying the tag.",
+		)
+		return
+	}
+
+	// Call API to delete the matching checks
+	deleted, err := r.client.deleteChecksByTag(ctx, plan.TagKey.ValueString(), plan.TagValue.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting checks",
+			fmt.Sprintf("Could not delete checks tagged %s after deleting %d: %s", plan.TagKey.ValueString(), len(deleted), err),
+		)
+		return
+	}
+
+	// Record the deleted checks as computed fields
+	plan.ID = types.StringValue(fmt.Sprintf("cleanup-%s-%d", plan.TagKey.ValueString(), time.Now().Unix()))
+	plan.DeletedCount = types.Int64Value(int64(len(deleted)))
+	plan.DeletedCheckIDs, diags = types.ListValueFrom(ctx, types.StringType, deleted)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set state
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read keeps the recorded deletions; a cleanup run is not refreshed
+func (r *checkCleanupResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state CheckCleanup
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update only records a changed confirm; every other configurable attribute forces replacement
+func (r *checkCleanupResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state CheckCleanup
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Keep what the cleanup run deleted
+	plan.ID = state.ID
+	plan.DeletedCount = state.DeletedCount
+	plan.DeletedCheckIDs = state.DeletedCheckIDs
+
+	diags := resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete removes the cleanup run from state; deleted checks are not restored
+func (r *checkCleanupResource) Delete(_ context.Context, _ resource.DeleteRequest, _ *resource.DeleteResponse) {
+	// Terraform will remove the resource from state
+}
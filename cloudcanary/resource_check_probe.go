@@ -0,0 +1,161 @@
+package cloudcanary
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+)
+
+// checkProbeResource implements an on-demand CloudCanary check run
+type checkProbeResource struct {
+	client *cloudCanaryClient
+}
+
+// Ensure the implementation satisfies the expected interfaces
+var _ resource.Resource = &checkProbeResource{}
+
+// NewCheckProbeResource creates a new check probe resource
+func NewCheckProbeResource() resource.Resource {
+	return &checkProbeResource{}
+}
+
+// Metadata returns the resource type name
+func (r *checkProbeResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_check_probe"
+}
+
+// Schema defines the schema for the resource
+func (r *checkProbeResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Triggers an immediate run of an existing check when created. Change trigger to run it again.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The unique identifier for this probe run.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"check_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The ID of the check to run.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"trigger": schema.StringAttribute{
+				Optional:    true,
+				Description: "Arbitrary value; changing it runs the check again (e.g. a deploy version).",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"status": schema.StringAttribute{
+				Computed:    true,
+				Description: "The status of the probe run (SUCCESS, DEGRADED, FAILURE).",
+			},
+			"response_time": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Response time in milliseconds.",
+			},
+			"message": schema.StringAttribute{
+				Computed:    true,
+				Description: "Message associated with the probe run.",
+			},
+			"failure_reason": schema.StringAttribute{
+				Computed:    true,
+				Description: "Why the probe run failed. Null unless status is FAILURE.",
+			},
+			"probed_at": schema.StringAttribute{
+				Computed:    true,
+				Description: "When the probe ran (RFC3339 format).",
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the resource
+func (r *checkProbeResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*cloudCanaryClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *cloudCanaryClient, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+// Create runs the check and records the result
+func (r *checkProbeResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Get the plan
+	var plan CheckProbe
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Call API to run the check
+	result, err := r.client.runCheckNow(ctx, plan.CheckID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error running check",
+			fmt.Sprintf("Could not run check ID %s: %s", plan.CheckID.ValueString(), err),
+		)
+		return
+	}
+
+	// Record the result as computed fields
+	plan.ID = result.ID
+	plan.Status = result.Status
+	plan.ResponseTime = result.ResponseTime
+	plan.Message = result.Message
+	plan.FailureReason = result.FailureReason
+	plan.ProbedAt = result.Timestamp
+
+	// Set state
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read keeps the recorded result; a probe run is not refreshed
+func (r *checkProbeResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state CheckProbe
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update is never called since every configurable attribute forces replacement
+func (r *checkProbeResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan CheckProbe
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete removes the probe run from state; there is nothing to delete remotely
+func (r *checkProbeResource) Delete(_ context.Context, _ resource.DeleteRequest, _ *resource.DeleteResponse) {
+	// Terraform will remove the resource from state
+}
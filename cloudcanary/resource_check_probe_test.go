@@ -0,0 +1,89 @@
+package cloudcanary
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// createCheckProbe applies a cloudcanary_check_probe for checkID and returns its state
+func createCheckProbe(t *testing.T, c *cloudCanaryClient, checkID string) CheckProbe {
+	t.Helper()
+	ctx := context.Background()
+	r := &checkProbeResource{client: c}
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	plan := tfsdk.Plan{Schema: schemaResp.Schema}
+	diags := plan.Set(ctx, CheckProbe{
+		ID:            types.StringUnknown(),
+		CheckID:       types.StringValue(checkID),
+		Trigger:       types.StringNull(),
+		Status:        types.StringUnknown(),
+		ResponseTime:  types.Int64Unknown(),
+		Message:       types.StringUnknown(),
+		FailureReason: types.StringUnknown(),
+		ProbedAt:      types.StringUnknown(),
+	})
+	if diags.HasError() {
+		t.Fatalf("building plan: %v", diags)
+	}
+
+	resp := resource.CreateResponse{State: tfsdk.State{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}}
+	r.Create(ctx, resource.CreateRequest{Plan: plan}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Create: %v", resp.Diagnostics)
+	}
+
+	var state CheckProbe
+	if diags := resp.State.Get(ctx, &state); diags.HasError() {
+		t.Fatalf("reading state: %v", diags)
+	}
+	return state
+}
+
+func TestCheckProbeReportsLatestResult(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("check probed from the provider host: %s %s", r.Method, r.URL)
+	}))
+	defer server.Close()
+
+	c := newTestClient()
+	managed := HTTPCheck{
+		Name: types.StringValue("Managed site"),
+		URL:  types.StringValue(server.URL),
+	}
+	if err := c.createHTTPCheck(context.Background(), &managed); err != nil {
+		t.Fatalf("createHTTPCheck: %s", err)
+	}
+
+	// Whether or not this provider created the check, the run is the API's,
+	// so nothing is probed from the provider host
+	for _, id := range []string{managed.ID.ValueString(), "hc-0123456789abcdef"} {
+		latest, err := c.getLatestResult(context.Background(), id)
+		if err != nil {
+			t.Fatalf("getLatestResult(%s): %s", id, err)
+		}
+
+		state := createCheckProbe(t, c, id)
+		if state.Status != latest.Status {
+			t.Errorf("%s: status = %s, want the latest result's %s", id, state.Status, latest.Status)
+		}
+		if state.ResponseTime != latest.ResponseTime {
+			t.Errorf("%s: response_time = %s, want the latest result's %s", id, state.ResponseTime, latest.ResponseTime)
+		}
+		if state.FailureReason != latest.FailureReason {
+			t.Errorf("%s: failure_reason = %s, want the latest result's %s", id, state.FailureReason, latest.FailureReason)
+		}
+	}
+}
@@ -0,0 +1,259 @@
+package cloudcanary
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// compositeCheckResource implements a CloudCanary composite check resource
+type compositeCheckResource struct {
+	client *cloudCanaryClient
+}
+
+// Ensure the implementation satisfies the expected interfaces
+var _ resource.Resource = &compositeCheckResource{}
+var _ resource.ResourceWithImportState = &compositeCheckResource{}
+
+// NewCompositeCheckResource creates a new composite check resource
+func NewCompositeCheckResource() resource.Resource {
+	return &compositeCheckResource{}
+}
+
+// Metadata returns the resource type name
+func (r *compositeCheckResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_composite_check"
+}
+
+// Schema defines the schema for the resource
+func (r *compositeCheckResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a composite check whose status is derived from the last results of other checks.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The unique identifier for this check.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "The name of the check.",
+			},
+			"member_check_ids": schema.ListAttribute{
+				ElementType: types.StringType,
+				Required:    true,
+				Description: "IDs of the checks whose results are aggregated.",
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.UniqueValues(),
+					listvalidator.ValueStringsAre(validCheckID()),
+				},
+			},
+			"aggregation": schema.StringAttribute{
+				Optional:    true,
+				Description: "How member results combine into the status (all, any, majority): SUCCESS when all, at least one, or more than half of the members succeeded. Defaults to all.",
+				Validators: []validator.String{
+					stringvalidator.OneOf(compositeAggregations...),
+				},
+			},
+			"status": schema.StringAttribute{
+				Computed:    true,
+				Description: "The aggregated status (SUCCESS, FAILURE), derived by the API from the members' last results.",
+			},
+			"created_at": schema.StringAttribute{
+				Computed:    true,
+				Description: "When the check was created (RFC3339 format).",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"updated_at": schema.StringAttribute{
+				Computed:    true,
+				Description: "When the check was last updated (RFC3339 format).",
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the resource
+func (r *compositeCheckResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*cloudCanaryClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *cloudCanaryClient, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+// Create creates a new composite check
+func (r *compositeCheckResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Get the plan
+	var plan CompositeCheck
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Guard against duplicate names when enabled on the provider
+	if err := r.client.ensureUniqueName(ctx, plan.Name.ValueString()); err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating composite check",
+			r.client.errorDetail(fmt.Sprintf("Could not create composite check: %s", err), err, map[string]string{"name": plan.Name.ValueString()}),
+		)
+		return
+	}
+
+	// Create a working copy for the API call
+	// This allows us to use defaults for the API call without modifying the plan
+	apiCheck := CompositeCheck{
+		Name:           plan.Name,
+		MemberCheckIDs: plan.MemberCheckIDs,
+	}
+
+	// Copy all other fields directly from plan
+	apiCheck.Aggregation = plan.Aggregation
+
+	// Call the API using the working copy
+	err := r.client.createCompositeCheck(ctx, &apiCheck)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating composite check",
+			r.client.errorDetail(fmt.Sprintf("Could not create composite check: %s", err), err, map[string]string{"name": plan.Name.ValueString()}),
+		)
+		return
+	}
+
+	// Now update the original plan with only computed fields
+	plan.ID = apiCheck.ID
+	plan.CreatedAt = apiCheck.CreatedAt
+	plan.UpdatedAt = apiCheck.UpdatedAt
+	plan.Status = apiCheck.Status
+
+	// Set state
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read refreshes the Terraform state with the latest data
+func (r *compositeCheckResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Get current state
+	var state CompositeCheck
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Call API to get the latest data
+	apiCheck, err := r.client.readCompositeCheck(ctx, state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading composite check",
+			r.client.errorDetail(fmt.Sprintf("Could not read composite check ID %s: %s", state.ID.ValueString(), err), err, map[string]string{"id": state.ID.ValueString()}),
+		)
+		return
+	}
+
+	// Preserve null values in the state - copy only non-null fields from API response
+	if !apiCheck.ID.IsNull() {
+		state.ID = apiCheck.ID
+	}
+	if !apiCheck.Name.IsNull() {
+		state.Name = apiCheck.Name
+	}
+	if !apiCheck.MemberCheckIDs.IsNull() {
+		state.MemberCheckIDs = apiCheck.MemberCheckIDs
+	}
+	if !apiCheck.Aggregation.IsNull() {
+		state.Aggregation = apiCheck.Aggregation
+	}
+	if !apiCheck.CreatedAt.IsNull() {
+		state.CreatedAt = apiCheck.CreatedAt
+	}
+
+	// Always update computed fields
+	state.Status = apiCheck.Status
+	state.UpdatedAt = apiCheck.UpdatedAt
+
+	// Set state
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update updates the resource
+func (r *compositeCheckResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Get plan and current state
+	var plan, state CompositeCheck
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Preserve the ID from state
+	plan.ID = state.ID
+
+	// Call API to update the check; the API re-derives the status
+	err := r.client.updateCompositeCheck(ctx, &plan)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating composite check",
+			r.client.errorDetail(fmt.Sprintf("Could not update composite check ID %s: %s", plan.ID.ValueString(), err), err, map[string]string{"id": plan.ID.ValueString()}),
+		)
+		return
+	}
+
+	// Set state
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete deletes the resource
+func (r *compositeCheckResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Get current state
+	var state CompositeCheck
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Call API to delete the check
+	err := r.client.deleteCompositeCheck(ctx, state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting composite check",
+			r.client.errorDetail(fmt.Sprintf("Could not delete composite check ID %s: %s", state.ID.ValueString(), err), err, map[string]string{"id": state.ID.ValueString()}),
+		)
+		return
+	}
+
+	// Terraform will remove the resource from state
+}
+
+// ImportState imports an existing resource into Terraform
+func (r *compositeCheckResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
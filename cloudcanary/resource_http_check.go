@@ -3,13 +3,23 @@ package cloudcanary
 import (
 	"context"
 	"fmt"
-	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/float64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -21,6 +31,8 @@ type httpCheckResource struct {
 // Ensure the implementation satisfies the expected interfaces
 var _ resource.Resource = &httpCheckResource{}
 var _ resource.ResourceWithImportState = &httpCheckResource{}
+var _ resource.ResourceWithUpgradeState = &httpCheckResource{}
+var _ resource.ResourceWithConfigValidators = &httpCheckResource{}
 
 // NewHTTPCheckResource creates a new HTTP check resource
 func NewHTTPCheckResource() resource.Resource {
@@ -35,6 +47,7 @@ func (r *httpCheckResource) Metadata(_ context.Context, req resource.MetadataReq
 // Schema defines the schema for the resource
 func (r *httpCheckResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version:     1,
 		Description: "Manages an HTTP check for a website or endpoint.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
@@ -48,10 +61,100 @@ func (r *httpCheckResource) Schema(_ context.Context, _ resource.SchemaRequest,
 				Required:    true,
 				Description: "The name of the check.",
 			},
+			"environment": schema.StringAttribute{
+				Optional:    true,
+				Description: "Environment the check belongs to, e.g. prod, staging or dev. Used for filtering and display; must be one of the provider's allowed_environments.",
+			},
+			"description": schema.StringAttribute{
+				Optional:    true,
+				Description: "Free-form notes on what the check covers, for responders. Has no effect on how the check runs.",
+			},
+			"runbook_url": schema.StringAttribute{
+				Optional:    true,
+				Description: "URL of the runbook to follow when the check fails. Reported on the check's incidents; has no effect on how the check runs.",
+				Validators: []validator.String{
+					validHTTPURL(),
+				},
+			},
+			"severity": schema.StringAttribute{
+				Optional:    true,
+				Description: "Priority alerts for the check are routed with: info, warning or critical. For example, critical checks can page while info checks only log.",
+				Validators: []validator.String{
+					stringvalidator.OneOf(checkSeverities...),
+				},
+			},
+			"result_labels": schema.MapAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "Labels attached to every result of the check, such as team or service, so results can be grouped without looking the check up.",
+			},
+			"source_check_id": schema.StringAttribute{
+				Optional:    true,
+				Description: "ID of an HTTP check to copy when this check is created. Attributes set here override the copied ones. The copy happens once; later changes to either check are not synced.",
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(httpCheckIDPattern, "must be the ID of an HTTP check such as hc-0123456789abcdef"),
+				},
+			},
 			"url": schema.StringAttribute{
 				Required:    true,
 				Description: "The URL to check.",
 			},
+			"additional_urls": schema.ListAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "Further URLs probed with the same settings on every run, e.g. the same path on each CDN point of presence. The check's status combines url and these according to match_mode. At most 20.",
+				Validators: []validator.List{
+					listvalidator.SizeAtMost(maxAdditionalURLs),
+					listvalidator.UniqueValues(),
+					listvalidator.ValueStringsAre(validHTTPURL()),
+				},
+			},
+			"match_mode": schema.StringAttribute{
+				Optional:    true,
+				Description: "How the results of url and additional_urls combine into the check's status (all, any, majority). Defaults to all.",
+				Validators: []validator.String{
+					stringvalidator.OneOf(compositeAggregations...),
+					stringvalidator.AlsoRequires(path.MatchRoot("additional_urls")),
+				},
+			},
+			"dns_resolver": schema.StringAttribute{
+				Optional:    true,
+				Description: "DNS server (IP:port, e.g. \"10.0.0.2:53\") used to resolve the URL's host instead of the system resolver. Useful for comparing internal and public DNS.",
+				Validators: []validator.String{
+					validResolverAddress(),
+				},
+			},
+			"source_ip": schema.StringAttribute{
+				Optional:    true,
+				Description: "Local IP address probe connections are made from, for monitoring hosts with several interfaces. The address must be assigned to the host running the probe.",
+				Validators: []validator.String{
+					validIPAddress(),
+				},
+			},
+			"tls_server_name": schema.StringAttribute{
+				Optional:    true,
+				Description: "Server name sent via SNI in the TLS handshake, and used to verify the certificate, instead of the URL's host. Useful for endpoints behind SNI-based routing on a shared IP.",
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(hostnamePattern, "must be a valid hostname such as api.example.com"),
+				},
+			},
+			"tls_expiry_warning_days": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Report the check as DEGRADED when the server's TLS certificate expires within this many days, even if it is still valid.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"allowed_cipher_suites": schema.ListAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "TLS cipher suites the probe may negotiate, by IANA name (e.g. TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256). A handshake that can't use one of them fails the check. Unless a TLS 1.3 suite is listed, TLS 1.3 is disabled.",
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.UniqueValues(),
+					listvalidator.ValueStringsAre(validCipherSuite()),
+				},
+			},
 			"method": schema.StringAttribute{
 				Optional:    true,
 				Description: "The HTTP method to use (GET, POST, etc.).",
@@ -61,9 +164,72 @@ func (r *httpCheckResource) Schema(_ context.Context, _ resource.SchemaRequest,
 				Optional:    true,
 				Description: "HTTP headers to include in the request.",
 			},
+			"cookies": schema.MapAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Sensitive:   true,
+				Description: "Cookies to send with the request, by name, e.g. a session cookie the monitored page requires. Sent in a Cookie header alongside any configured in headers.",
+				Validators: []validator.Map{
+					mapvalidator.KeysAre(validCookieName()),
+				},
+			},
+			"accept": schema.StringAttribute{
+				Optional:    true,
+				Description: "Accept header the probe sends, to pin the format negotiated with endpoints that serve several (e.g. application/json). Replaces an Accept header in headers. When expected_content_type is also set, the response's media type must be one accept allows. Defaults to */*, or the Accept header in headers.",
+				Validators: []validator.String{
+					validAccept(),
+				},
+			},
+			"preserve_header_case": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Whether to send header names exactly as written in headers instead of canonicalizing them (e.g. x-api-key rather than X-Api-Key). Rarely needed. Defaults to false.",
+			},
+			"if_modified_since": schema.StringAttribute{
+				Optional:    true,
+				Description: "Sent as the If-Modified-Since header to exercise cache validation. Must be an HTTP date such as \"Wed, 21 Oct 2015 07:28:00 GMT\". When set, a 304 Not Modified response counts as SUCCESS.",
+				Validators: []validator.String{
+					validHTTPDate(),
+				},
+			},
+			"if_none_match": schema.StringAttribute{
+				Optional:    true,
+				Description: "Sent as the If-None-Match header to exercise cache validation, e.g. an ETag such as \"\\\"33a64df5\\\"\". When set, a 304 Not Modified response counts as SUCCESS.",
+			},
 			"body": schema.StringAttribute{
 				Optional:    true,
 				Description: "HTTP request body for POST/PUT requests.",
+				PlanModifiers: []planmodifier.String{
+					normalizeJSONBody(),
+				},
+			},
+			"normalize_json_body": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Whether to ignore whitespace and key order differences when the body is JSON.",
+			},
+			"expect_continue": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Whether to send Expect: 100-continue and wait for the server's go-ahead before sending the body. Useful for large PUT bodies; some servers and proxies mishandle it. Defaults to false.",
+			},
+			"form_fields": schema.MapAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "Form fields sent as a multipart/form-data body. Conflicts with body.",
+			},
+			"form_files": schema.MapAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "Files sent as a multipart/form-data body, keyed by field name with local file paths as values. Conflicts with body.",
+				Validators: []validator.Map{
+					validFilePaths(),
+				},
+			},
+			"form_files_sha256": schema.MapAttribute{
+				ElementType: types.StringType,
+				Computed:    true,
+				Description: "SHA-256 hashes of the form_files contents, keyed by field name. A change to a file's contents shows up as a diff here.",
+				PlanModifiers: []planmodifier.Map{
+					hashFormFiles(),
+				},
 			},
 			"expected_status": schema.Int64Attribute{
 				Optional:    true,
@@ -73,39 +239,397 @@ func (r *httpCheckResource) Schema(_ context.Context, _ resource.SchemaRequest,
 				Optional:    true,
 				Description: "Text that should be present in the response body.",
 			},
+			"response_charset": schema.StringAttribute{
+				Optional:    true,
+				Description: "Character set the response body is decoded from before matching expected_response (e.g. iso-8859-1, utf-16le, shift_jis). Defaults to the charset in the Content-Type header, or UTF-8.",
+				Validators: []validator.String{
+					validCharset(),
+				},
+			},
+			"body_search_limit_bytes": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Maximum number of response body bytes searched for expected_response, at least 1. Defaults to the full captured body.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"expected_content_type": schema.StringAttribute{
+				Optional:    true,
+				Description: "Expected media type of the response (e.g. application/json). Parameters such as charset are ignored.",
+			},
+			"expected_headers": schema.MapAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "Response headers the check asserts on, mapped to the value each must equal. Prefix a value with ~ to match it as a regular expression instead.",
+				Validators: []validator.Map{
+					validHeaderExpectations(),
+				},
+			},
+			"expected_trailers": schema.MapAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "Response trailers, sent after the body of a streamed response, mapped to the value each must equal. Prefix a value with ~ to match it as a regular expression instead. The whole body is read to reach them.",
+				Validators: []validator.Map{
+					validHeaderExpectations(),
+				},
+			},
+			"expect_chunked": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Require the response to be streamed: sent with chunked transfer encoding over HTTP/1.1, or without a Content-Length over HTTP/2. Defaults to false.",
+			},
+			"min_response_size": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Minimum response body size in bytes; smaller responses fail the check.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"max_response_size": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Maximum response body size in bytes; larger responses fail the check.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"expected_body_sha256": schema.StringAttribute{
+				Optional:    true,
+				Description: "Expected SHA-256 of the whole response body, hex encoded. Any other body fails the check. Copy last_body_sha256 to pin the current content.",
+				Validators: []validator.String{
+					validSHA256(),
+				},
+			},
+			"store_response_body": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Whether response bodies are kept in check results. Set to false for responses that may contain personal data. Defaults to true.",
+			},
 			"interval": schema.Int64Attribute{
 				Optional:    true,
 				Description: "Check interval in seconds.",
 			},
+			"jitter_seconds": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Maximum random delay in seconds the server adds to each run to stagger checks sharing an interval. Must be less than interval.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"warmup_period": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Seconds after the check is created during which failures are recorded in results but don't trigger alerts. Defaults to 0.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"priority": schema.StringAttribute{
+				Optional:    true,
+				Description: "Execution tier the check is scheduled in (low, normal, high). Once set, the interval must be at least the tier minimum: 300 seconds for low, 60 for normal and 10 for high. Defaults to normal.",
+				Validators: []validator.String{
+					stringvalidator.OneOf("low", "normal", "high"),
+				},
+			},
 			"timeout": schema.Int64Attribute{
 				Optional:    true,
 				Description: "Timeout in seconds.",
 			},
+			"connect_timeout": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Seconds allowed to establish the TCP connection, within the overall timeout. Defaults to the overall timeout.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"read_timeout": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Seconds allowed between sending the request and receiving the response headers, within the overall timeout. The body may take up to the rest of the overall timeout. Defaults to the overall timeout.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"max_probe_duration": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Hard cap in seconds on a whole probe, including retries and slow body reads, regardless of the other timeouts. A probe cut off by it fails with a reason saying so.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
 			"follow_redirects": schema.BoolAttribute{
 				Optional:    true,
 				Description: "Whether to follow HTTP redirects.",
 			},
+			"redirect_status_is_success": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Count any 3xx response as SUCCESS without following it. Requires follow_redirects = false. Defaults to false.",
+			},
+			"expected_redirect_location": schema.StringAttribute{
+				Optional:    true,
+				Description: "Location header the URL must redirect to, compared exactly as sent. Prefix with ~ to match a regular expression instead. The response must be a 3xx redirect, and expected_status, when set, must match too. Requires follow_redirects = false.",
+				Validators: []validator.String{
+					validExpectedPattern(),
+				},
+			},
 			"regions": schema.ListAttribute{
 				ElementType: types.StringType,
 				Optional:    true,
-				Description: "Regions to run the check from.",
+				Description: "Public regions to run the check from, in any order. May be combined with private_location_ids; at least one of the two must be set.",
+				PlanModifiers: []planmodifier.List{
+					ignoreOrder(),
+				},
+			},
+			"private_location_ids": schema.ListAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "IDs of private locations whose agents run the check, for endpoints only reachable from inside a network. May be combined with regions; at least one of the two must be set.",
+				Validators: []validator.List{
+					listvalidator.UniqueValues(),
+					listvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
+			"region_quorum": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Number of configured regions and private locations that must fail before the check is marked as FAILURE.",
 			},
 			"retries": schema.Int64Attribute{
 				Optional:    true,
 				Description: "Number of retries before marking as failed.",
 			},
+			"retry_on_empty_body": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Retry immediately, up to retries times, when a 2xx response has an empty body but expected_response is set. Works around proxies that intermittently return empty bodies. Defaults to false.",
+			},
+			"basic_auth_username": schema.StringAttribute{
+				Optional:    true,
+				Description: "Username for HTTP Basic authentication. Must be set together with basic_auth_password.",
+			},
+			"basic_auth_password": schema.StringAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				Description: "Password for HTTP Basic authentication. Must be set together with basic_auth_username.",
+			},
+			"forward_auth_on_redirect": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Whether to keep sending the Authorization header when following a redirect to a different host. This exposes credentials to whichever host the redirect points at, so only enable it when every redirect target is trusted. Defaults to false.",
+			},
+			"response_time_mode": schema.StringAttribute{
+				Optional:    true,
+				Description: "What response_time measures when redirects are followed (final, total): only the final request, or the whole redirect chain. Defaults to total.",
+				Validators: []validator.String{
+					stringvalidator.OneOf("final", "total"),
+				},
+			},
+			"assert_connection_reused": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Report the check as DEGRADED when a probe has to open a new connection instead of reusing a keep-alive connection from the previous probe. Only meaningful from the second probe on. Defaults to false.",
+			},
+			"run_if_check_id": schema.StringAttribute{
+				Optional:    true,
+				Description: "ID of a prerequisite check; this check only runs while that check is in run_if_status.",
+				Validators: []validator.String{
+					validCheckID(),
+				},
+			},
+			"run_if_status": schema.StringAttribute{
+				Optional:    true,
+				Description: "Status the prerequisite check must be in for this check to run (SUCCESS, FAILURE).",
+				Validators: []validator.String{
+					stringvalidator.OneOf("SUCCESS", "FAILURE"),
+				},
+			},
+			"sla_target": schema.Float64Attribute{
+				Optional:    true,
+				Description: "Target uptime percentage over the 30 day SLA window (e.g. 99.9). Enables sla_compliant and sla_budget_remaining.",
+				Validators: []validator.Float64{
+					float64validator.Between(0, 100),
+				},
+			},
+			"baseline_deviation_percent": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Mark a run DEGRADED, even when it otherwise passes, if its response time differs from the check's rolling baseline by more than this percentage. The baseline is reported in response_time_baseline.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
 			"last_result": schema.StringAttribute{
 				Computed:    true,
-				Description: "The result of the last check (SUCCESS, FAILURE).",
+				Description: "The result of the last check (UNKNOWN, PENDING, SUCCESS, DEGRADED, FAILURE). UNKNOWN until the check has produced a result.",
 			},
 			"last_check_time": schema.StringAttribute{
 				Computed:    true,
-				Description: "The time of the last check.",
+				Description: "The time of the last check. Null while last_result is UNKNOWN.",
 			},
+			"next_check_time": schema.StringAttribute{
+				Computed:    true,
+				Description: "When the check is next scheduled to run. Taken from the API when it reports one, otherwise derived from last_check_time and interval. Null while last_result is UNKNOWN.",
+			},
+			"is_flapping": schema.BoolAttribute{
+				Computed:    true,
+				Description: "Whether the check changed status at least 3 times over the last hour.",
+			},
+			"flap_count_1h": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Number of times the check changed status over the last hour.",
+			},
+			"last_result_detail": schema.SingleNestedAttribute{
+				Computed:    true,
+				Description: "Details of the most recent check result.",
+				PlanModifiers: []planmodifier.Object{
+					objectplanmodifier.UseStateForUnknown(),
+				},
+				Attributes: map[string]schema.Attribute{
+					"status": schema.StringAttribute{
+						Computed:    true,
+						Description: "The status of the check (SUCCESS, FAILURE).",
+					},
+					"response_time": schema.Int64Attribute{
+						Computed:    true,
+						Description: "Response time in milliseconds.",
+					},
+					"response_code": schema.Int64Attribute{
+						Computed:    true,
+						Description: "HTTP response code.",
+					},
+					"message": schema.StringAttribute{
+						Computed:    true,
+						Description: "Message associated with the result.",
+					},
+				},
+			},
+			"effective_config": schema.SingleNestedAttribute{
+				Computed:    true,
+				Description: "The configuration the check actually runs with: each attribute as configured, or the API default when it is not set.",
+				Attributes: map[string]schema.Attribute{
+					"method": schema.StringAttribute{
+						Computed:    true,
+						Description: "HTTP method.",
+					},
+					"expected_status": schema.Int64Attribute{
+						Computed:    true,
+						Description: "Expected HTTP status code.",
+					},
+					"interval": schema.Int64Attribute{
+						Computed:    true,
+						Description: "Check interval in seconds.",
+					},
+					"priority": schema.StringAttribute{
+						Computed:    true,
+						Description: "Execution tier the check is scheduled in.",
+					},
+					"timeout": schema.Int64Attribute{
+						Computed:    true,
+						Description: "Request timeout in seconds.",
+					},
+					"follow_redirects": schema.BoolAttribute{
+						Computed:    true,
+						Description: "Whether redirects are followed.",
+					},
+					"retries": schema.Int64Attribute{
+						Computed:    true,
+						Description: "Number of retry attempts.",
+					},
+					"store_response_body": schema.BoolAttribute{
+						Computed:    true,
+						Description: "Whether response bodies are kept in check results.",
+					},
+					"body_search_limit_bytes": schema.Int64Attribute{
+						Computed:    true,
+						Description: "Maximum number of response body bytes searched for expected_response.",
+					},
+				},
+			},
+			"last_response_size": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Size in bytes of the most recent response body.",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"last_body_sha256": schema.StringAttribute{
+				Computed:    true,
+				Description: "SHA-256 of the most recent response body, hex encoded. Null when the most recent run got no response.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"response_time_baseline": schema.Float64Attribute{
+				Computed:    true,
+				Description: "The rolling baseline response time in milliseconds that baseline_deviation_percent is measured against: the average over the last day. Null until the check has results.",
+				PlanModifiers: []planmodifier.Float64{
+					float64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"tls_certificate_expiry": schema.StringAttribute{
+				Computed:    true,
+				Description: "When the server's TLS certificate expires (RFC3339 format), as seen by the most recent run. Null for plain HTTP URLs.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"sla_compliant": schema.BoolAttribute{
+				Computed:    true,
+				Description: "Whether uptime over the SLA window meets sla_target. Null when sla_target is not set.",
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"sla_budget_remaining": schema.Float64Attribute{
+				Computed:    true,
+				Description: "Minutes of downtime still allowed by sla_target over the SLA window; negative once the budget is exceeded. Null when sla_target is not set.",
+				PlanModifiers: []planmodifier.Float64{
+					float64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"created_at": schema.StringAttribute{
+				Computed:    true,
+				Description: "When the check was created (RFC3339 format).",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"updated_at": schema.StringAttribute{
+				Computed:    true,
+				Description: "When the check was last updated (RFC3339 format).",
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"active_schedule": activeScheduleBlock(),
+			"timeouts":        timeoutsBlock(),
 		},
 	}
 }
 
+// ConfigValidators returns validators that check relationships between attributes
+func (r *httpCheckResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		jitterValidator{defaultInterval: 60},
+		priorityIntervalValidator{defaultInterval: 60},
+		regionQuorumValidator{},
+		responseSizeRangeValidator{},
+		redirectSuccessValidator{},
+		redirectLocationValidator{},
+		acceptContentTypeValidator{},
+		timeoutBudgetValidator{defaultTimeout: 10},
+		resourcevalidator.AtLeastOneOf(
+			path.MatchRoot("regions"),
+			path.MatchRoot("private_location_ids"),
+		),
+		resourcevalidator.Conflicting(
+			path.MatchRoot("body"),
+			path.MatchRoot("form_fields"),
+		),
+		resourcevalidator.Conflicting(
+			path.MatchRoot("body"),
+			path.MatchRoot("form_files"),
+		),
+		resourcevalidator.RequiredTogether(
+			path.MatchRoot("basic_auth_username"),
+			path.MatchRoot("basic_auth_password"),
+		),
+		resourcevalidator.RequiredTogether(
+			path.MatchRoot("run_if_check_id"),
+			path.MatchRoot("run_if_status"),
+		),
+	}
+}
+
 // Configure adds the provider configured client to the resource
 func (r *httpCheckResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
@@ -134,39 +658,156 @@ func (r *httpCheckResource) Create(ctx context.Context, req resource.CreateReque
 		return
 	}
 
+	// Bound the whole operation by the configured or default timeout
+	ctx, cancel := context.WithTimeout(ctx, plan.Timeouts.create())
+	defer cancel()
+
+	// Fail early when the account can't create this type of check, rather
+	// than with whatever error the API gives
+	if err := r.client.ensureCheckTypeEnabled(ctx, "http"); err != nil {
+		resp.Diagnostics.AddError(
+			"Check Type Not Enabled",
+			err.Error(),
+		)
+		return
+	}
+
+	// Only accept environments the provider allows
+	if err := r.client.ensureAllowedEnvironment(plan.Environment); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("environment"),
+			"Invalid Environment",
+			err.Error(),
+		)
+		return
+	}
+
+	// Guard against duplicate names when enabled on the provider
+	if err := r.client.ensureUniqueName(ctx, plan.Name.ValueString()); err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating HTTP check",
+			r.client.errorDetail(fmt.Sprintf("Could not create HTTP check: %s", err), err, map[string]string{"name": plan.Name.ValueString()}),
+		)
+		return
+	}
+
 	// Create a working copy for the API call
 	// This allows us to use defaults for the API call without modifying the plan
 	apiCheck := HTTPCheck{
 		Name: plan.Name,
 		URL:  plan.URL,
 	}
-	
+
 	// Copy all other fields directly from plan
+	apiCheck.AdditionalURLs = plan.AdditionalURLs
+	apiCheck.MatchMode = plan.MatchMode
+	apiCheck.DNSResolver = plan.DNSResolver
+	apiCheck.SourceIP = plan.SourceIP
+	apiCheck.TLSServerName = plan.TLSServerName
+	apiCheck.TLSExpiryWarningDays = plan.TLSExpiryWarningDays
+	apiCheck.AllowedCipherSuites = plan.AllowedCipherSuites
 	apiCheck.Method = plan.Method
 	apiCheck.Headers = plan.Headers
+	apiCheck.Cookies = plan.Cookies
+	apiCheck.Accept = plan.Accept
+	apiCheck.PreserveHeaderCase = plan.PreserveHeaderCase
+	apiCheck.IfModifiedSince = plan.IfModifiedSince
+	apiCheck.IfNoneMatch = plan.IfNoneMatch
 	apiCheck.Body = plan.Body
+	apiCheck.NormalizeJSONBody = plan.NormalizeJSONBody
+	apiCheck.ExpectContinue = plan.ExpectContinue
+	apiCheck.FormFields = plan.FormFields
+	apiCheck.FormFiles = plan.FormFiles
 	apiCheck.ExpectedStatus = plan.ExpectedStatus
 	apiCheck.ExpectedResponse = plan.ExpectedResponse
+	apiCheck.ResponseCharset = plan.ResponseCharset
+	apiCheck.BodySearchLimitBytes = plan.BodySearchLimitBytes
+	apiCheck.ExpectedContentType = plan.ExpectedContentType
+	apiCheck.ExpectedHeaders = plan.ExpectedHeaders
+	apiCheck.ExpectedTrailers = plan.ExpectedTrailers
+	apiCheck.ExpectChunked = plan.ExpectChunked
+	apiCheck.MinResponseSize = plan.MinResponseSize
+	apiCheck.MaxResponseSize = plan.MaxResponseSize
+	apiCheck.ExpectedBodySHA256 = plan.ExpectedBodySHA256
+	apiCheck.StoreResponseBody = plan.StoreResponseBody
 	apiCheck.Interval = plan.Interval
+	apiCheck.JitterSeconds = plan.JitterSeconds
+	apiCheck.WarmupPeriod = plan.WarmupPeriod
+	apiCheck.Priority = plan.Priority
 	apiCheck.Timeout = plan.Timeout
+	apiCheck.ConnectTimeout = plan.ConnectTimeout
+	apiCheck.ReadTimeout = plan.ReadTimeout
+	apiCheck.MaxProbeDuration = plan.MaxProbeDuration
 	apiCheck.FollowRedirects = plan.FollowRedirects
+	apiCheck.RedirectStatusIsSuccess = plan.RedirectStatusIsSuccess
+	apiCheck.ExpectedRedirectLocation = plan.ExpectedRedirectLocation
 	apiCheck.Regions = plan.Regions
+	apiCheck.PrivateLocationIDs = plan.PrivateLocationIDs
+	apiCheck.Environment = plan.Environment
+	apiCheck.Description = plan.Description
+	apiCheck.RunbookURL = plan.RunbookURL
+	apiCheck.Severity = plan.Severity
+	apiCheck.ResultLabels = plan.ResultLabels
+	apiCheck.RegionQuorum = plan.RegionQuorum
 	apiCheck.Retries = plan.Retries
+	apiCheck.RetryOnEmptyBody = plan.RetryOnEmptyBody
+	apiCheck.BasicAuthUsername = plan.BasicAuthUsername
+	apiCheck.BasicAuthPassword = plan.BasicAuthPassword
+	apiCheck.ForwardAuthOnRedirect = plan.ForwardAuthOnRedirect
+	apiCheck.ResponseTimeMode = plan.ResponseTimeMode
+	apiCheck.AssertConnectionReused = plan.AssertConnectionReused
+	apiCheck.RunIfCheckID = plan.RunIfCheckID
+	apiCheck.RunIfStatus = plan.RunIfStatus
+	apiCheck.SLATarget = plan.SLATarget
+	apiCheck.BaselineDeviationPercent = plan.BaselineDeviationPercent
+	apiCheck.ActiveSchedule = plan.ActiveSchedule
+
+	// When cloning, start from the source check and override only what is configured
+	if !plan.SourceCheckID.IsNull() {
+		source, err := r.client.readHTTPCheck(ctx, plan.SourceCheckID.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("source_check_id"),
+				"Error creating HTTP check",
+				fmt.Sprintf("Could not read source HTTP check ID %s: %s", plan.SourceCheckID.ValueString(), err),
+			)
+			return
+		}
+		overlayConfigured(source, &apiCheck)
+		apiCheck = *source
+	}
 
 	// Call the API using the working copy
 	err := r.client.createHTTPCheck(ctx, &apiCheck)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating HTTP check",
-			fmt.Sprintf("Could not create HTTP check: %s", err),
+			r.client.errorDetail(fmt.Sprintf("Could not create HTTP check: %s", err), err, map[string]string{"name": plan.Name.ValueString()}),
 		)
 		return
 	}
 
 	// Now update the original plan with only computed fields
 	plan.ID = apiCheck.ID
-	plan.LastResult = types.StringValue("PENDING")
-	plan.LastCheckTime = types.StringValue(time.Now().Format(time.RFC3339))
+	plan.CreatedAt = apiCheck.CreatedAt
+	plan.UpdatedAt = apiCheck.UpdatedAt
+	plan.LastResult = types.StringValue(lastResultUnknown)
+	plan.LastCheckTime = types.StringNull()
+	plan.NextCheckTime = types.StringNull()
+	plan.IsFlapping = types.BoolValue(false)
+	plan.FlapCount1h = types.Int64Value(0)
+	plan.LastResultDetail = types.ObjectNull(lastResultDetailAttrTypes)
+	plan.LastResponseSize = types.Int64Null()
+	plan.LastBodySHA256 = types.StringNull()
+	plan.ResponseTimeBaseline = types.Float64Null()
+	plan.TLSCertificateExpiry = types.StringNull()
+	plan.EffectiveConfig, diags = newEffectiveConfig(ctx, &apiCheck)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	plan.SLACompliant = types.BoolNull()
+	plan.SLABudgetRemaining = types.Float64Null()
 
 	// Set state
 	diags = resp.State.Set(ctx, plan)
@@ -183,12 +824,16 @@ func (r *httpCheckResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
+	// Bound the whole operation by the configured or default timeout
+	ctx, cancel := context.WithTimeout(ctx, state.Timeouts.read())
+	defer cancel()
+
 	// Call API to get the latest data
 	apiCheck, err := r.client.readHTTPCheck(ctx, state.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error reading HTTP check",
-			fmt.Sprintf("Could not read HTTP check ID %s: %s", state.ID.ValueString(), err),
+			r.client.errorDetail(fmt.Sprintf("Could not read HTTP check ID %s: %s", state.ID.ValueString(), err), err, map[string]string{"id": state.ID.ValueString()}),
 		)
 		return
 	}
@@ -203,40 +848,267 @@ func (r *httpCheckResource) Read(ctx context.Context, req resource.ReadRequest,
 	if !apiCheck.URL.IsNull() {
 		state.URL = apiCheck.URL
 	}
+	if !apiCheck.AdditionalURLs.IsNull() {
+		state.AdditionalURLs = apiCheck.AdditionalURLs
+	}
+	if !apiCheck.MatchMode.IsNull() {
+		state.MatchMode = apiCheck.MatchMode
+	}
+	if !apiCheck.DNSResolver.IsNull() {
+		state.DNSResolver = apiCheck.DNSResolver
+	}
+	if !apiCheck.SourceIP.IsNull() {
+		state.SourceIP = apiCheck.SourceIP
+	}
+	if !apiCheck.TLSServerName.IsNull() {
+		state.TLSServerName = apiCheck.TLSServerName
+	}
+	if !apiCheck.TLSExpiryWarningDays.IsNull() {
+		state.TLSExpiryWarningDays = apiCheck.TLSExpiryWarningDays
+	}
+	if !apiCheck.AllowedCipherSuites.IsNull() {
+		state.AllowedCipherSuites = apiCheck.AllowedCipherSuites
+	}
 	if !apiCheck.Method.IsNull() {
 		state.Method = apiCheck.Method
 	}
 	if !apiCheck.Headers.IsNull() {
 		state.Headers = apiCheck.Headers
 	}
+	if !apiCheck.Cookies.IsNull() {
+		state.Cookies = apiCheck.Cookies
+	}
+	if !apiCheck.Accept.IsNull() {
+		state.Accept = apiCheck.Accept
+	}
+	if !apiCheck.PreserveHeaderCase.IsNull() {
+		state.PreserveHeaderCase = apiCheck.PreserveHeaderCase
+	}
+	if !apiCheck.IfModifiedSince.IsNull() {
+		state.IfModifiedSince = apiCheck.IfModifiedSince
+	}
+	if !apiCheck.IfNoneMatch.IsNull() {
+		state.IfNoneMatch = apiCheck.IfNoneMatch
+	}
 	if !apiCheck.Body.IsNull() {
 		state.Body = apiCheck.Body
 	}
+	if !apiCheck.NormalizeJSONBody.IsNull() {
+		state.NormalizeJSONBody = apiCheck.NormalizeJSONBody
+	}
+	if !apiCheck.ExpectContinue.IsNull() {
+		state.ExpectContinue = apiCheck.ExpectContinue
+	}
+	if !apiCheck.FormFields.IsNull() {
+		state.FormFields = apiCheck.FormFields
+	}
+	if !apiCheck.FormFiles.IsNull() {
+		state.FormFiles = apiCheck.FormFiles
+	}
 	if !apiCheck.ExpectedStatus.IsNull() {
 		state.ExpectedStatus = apiCheck.ExpectedStatus
 	}
 	if !apiCheck.ExpectedResponse.IsNull() {
 		state.ExpectedResponse = apiCheck.ExpectedResponse
 	}
+	if !apiCheck.ResponseCharset.IsNull() {
+		state.ResponseCharset = apiCheck.ResponseCharset
+	}
+	if !apiCheck.BodySearchLimitBytes.IsNull() {
+		state.BodySearchLimitBytes = apiCheck.BodySearchLimitBytes
+	}
+	if !apiCheck.ExpectedContentType.IsNull() {
+		state.ExpectedContentType = apiCheck.ExpectedContentType
+	}
+	if !apiCheck.ExpectedHeaders.IsNull() {
+		state.ExpectedHeaders = apiCheck.ExpectedHeaders
+	}
+	if !apiCheck.ExpectedTrailers.IsNull() {
+		state.ExpectedTrailers = apiCheck.ExpectedTrailers
+	}
+	if !apiCheck.ExpectChunked.IsNull() {
+		state.ExpectChunked = apiCheck.ExpectChunked
+	}
+	if !apiCheck.MinResponseSize.IsNull() {
+		state.MinResponseSize = apiCheck.MinResponseSize
+	}
+	if !apiCheck.MaxResponseSize.IsNull() {
+		state.MaxResponseSize = apiCheck.MaxResponseSize
+	}
+	if !apiCheck.ExpectedBodySHA256.IsNull() {
+		state.ExpectedBodySHA256 = apiCheck.ExpectedBodySHA256
+	}
+	if !apiCheck.StoreResponseBody.IsNull() {
+		state.StoreResponseBody = apiCheck.StoreResponseBody
+	}
 	if !apiCheck.Interval.IsNull() {
 		state.Interval = apiCheck.Interval
 	}
+	if !apiCheck.JitterSeconds.IsNull() {
+		state.JitterSeconds = apiCheck.JitterSeconds
+	}
+	if !apiCheck.WarmupPeriod.IsNull() {
+		state.WarmupPeriod = apiCheck.WarmupPeriod
+	}
+	if !apiCheck.Priority.IsNull() {
+		state.Priority = apiCheck.Priority
+	}
 	if !apiCheck.Timeout.IsNull() {
 		state.Timeout = apiCheck.Timeout
 	}
+	if !apiCheck.ConnectTimeout.IsNull() {
+		state.ConnectTimeout = apiCheck.ConnectTimeout
+	}
+	if !apiCheck.ReadTimeout.IsNull() {
+		state.ReadTimeout = apiCheck.ReadTimeout
+	}
+	if !apiCheck.MaxProbeDuration.IsNull() {
+		state.MaxProbeDuration = apiCheck.MaxProbeDuration
+	}
 	if !apiCheck.FollowRedirects.IsNull() {
 		state.FollowRedirects = apiCheck.FollowRedirects
 	}
+	if !apiCheck.RedirectStatusIsSuccess.IsNull() {
+		state.RedirectStatusIsSuccess = apiCheck.RedirectStatusIsSuccess
+	}
+	if !apiCheck.ExpectedRedirectLocation.IsNull() {
+		state.ExpectedRedirectLocation = apiCheck.ExpectedRedirectLocation
+	}
 	if !apiCheck.Regions.IsNull() {
 		state.Regions = apiCheck.Regions
 	}
+	if !apiCheck.PrivateLocationIDs.IsNull() {
+		state.PrivateLocationIDs = apiCheck.PrivateLocationIDs
+	}
+	if !apiCheck.Environment.IsNull() {
+		state.Environment = apiCheck.Environment
+	}
+	if !apiCheck.Description.IsNull() {
+		state.Description = apiCheck.Description
+	}
+	if !apiCheck.RunbookURL.IsNull() {
+		state.RunbookURL = apiCheck.RunbookURL
+	}
+	if !apiCheck.Severity.IsNull() {
+		state.Severity = apiCheck.Severity
+	}
+	if !apiCheck.ResultLabels.IsNull() {
+		state.ResultLabels = apiCheck.ResultLabels
+	}
+	if !apiCheck.RegionQuorum.IsNull() {
+		state.RegionQuorum = apiCheck.RegionQuorum
+	}
 	if !apiCheck.Retries.IsNull() {
 		state.Retries = apiCheck.Retries
 	}
-	
+	if !apiCheck.RetryOnEmptyBody.IsNull() {
+		state.RetryOnEmptyBody = apiCheck.RetryOnEmptyBody
+	}
+	if !apiCheck.BasicAuthUsername.IsNull() {
+		state.BasicAuthUsername = apiCheck.BasicAuthUsername
+	}
+	if !apiCheck.ForwardAuthOnRedirect.IsNull() {
+		state.ForwardAuthOnRedirect = apiCheck.ForwardAuthOnRedirect
+	}
+	if !apiCheck.ResponseTimeMode.IsNull() {
+		state.ResponseTimeMode = apiCheck.ResponseTimeMode
+	}
+	if !apiCheck.AssertConnectionReused.IsNull() {
+		state.AssertConnectionReused = apiCheck.AssertConnectionReused
+	}
+	if !apiCheck.RunIfCheckID.IsNull() {
+		state.RunIfCheckID = apiCheck.RunIfCheckID
+	}
+	if !apiCheck.RunIfStatus.IsNull() {
+		state.RunIfStatus = apiCheck.RunIfStatus
+	}
+	if !apiCheck.SLATarget.IsNull() {
+		state.SLATarget = apiCheck.SLATarget
+	}
+	if !apiCheck.BaselineDeviationPercent.IsNull() {
+		state.BaselineDeviationPercent = apiCheck.BaselineDeviationPercent
+	}
+	if apiCheck.ActiveSchedule != nil {
+		state.ActiveSchedule = apiCheck.ActiveSchedule
+	}
+
+	// Be extremely careful with sensitive values
+	// Only update basic_auth_password if the new value isn't null AND the state value is null
+	if !apiCheck.BasicAuthPassword.IsNull() && state.BasicAuthPassword.IsNull() {
+		state.BasicAuthPassword = apiCheck.BasicAuthPassword
+	}
+
+	if !apiCheck.CreatedAt.IsNull() {
+		state.CreatedAt = apiCheck.CreatedAt
+	}
+
 	// Always update computed fields
-	state.LastResult = apiCheck.LastResult
-	state.LastCheckTime = apiCheck.LastCheckTime
+	state.LastResult, state.LastCheckTime = lastRun(apiCheck.LastResult, apiCheck.LastCheckTime)
+	state.NextCheckTime = nextRun(apiCheck.NextCheckTime, state.LastCheckTime, state.Interval)
+	state.UpdatedAt = apiCheck.UpdatedAt
+	state.TLSCertificateExpiry = apiCheck.TLSCertificateExpiry
+
+	// Derive flapping from how often the status changed over the last hour
+	stats, err := r.client.getCheckStats(ctx, state.ID.ValueString(), 1, "none")
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading HTTP check stats",
+			fmt.Sprintf("Could not read stats for HTTP check ID %s: %s", state.ID.ValueString(), err),
+		)
+		return
+	}
+	state.IsFlapping = stats.IsFlapping
+	state.FlapCount1h = stats.FlapCount1h
+
+	// The API's rolling baseline is the average response time over the last day
+	state.ResponseTimeBaseline = stats.AvgResponseTime
+
+	// Report what the API runs the check with, defaults included
+	state.EffectiveConfig, diags = newEffectiveConfig(ctx, apiCheck)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Populate the latest result detail from the most recent result
+	results, err := r.client.getCheckResults(ctx, state.ID.ValueString(), 1, 1)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading HTTP check results",
+			fmt.Sprintf("Could not read results for HTTP check ID %s: %s", state.ID.ValueString(), err),
+		)
+		return
+	}
+	state.LastResultDetail = types.ObjectNull(lastResultDetailAttrTypes)
+	state.LastResponseSize = types.Int64Null()
+	state.LastBodySHA256 = types.StringNull()
+	if len(results) > 0 {
+		state.LastResultDetail, diags = newLastResultDetail(ctx, results[0])
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		state.LastResponseSize = results[0].ResponseSize
+		state.LastBodySHA256 = results[0].BodySHA256
+	}
+
+	// Track the SLA against uptime over the SLA window
+	state.SLACompliant = types.BoolNull()
+	state.SLABudgetRemaining = types.Float64Null()
+	if !state.SLATarget.IsNull() {
+		uptime, err := r.client.getCheckUptime(ctx, state.ID.ValueString(), slaWindow)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error reading HTTP check uptime",
+				fmt.Sprintf("Could not read uptime for HTTP check ID %s: %s", state.ID.ValueString(), err),
+			)
+			return
+		}
+
+		target := state.SLATarget.ValueFloat64()
+		state.SLACompliant = types.BoolValue(uptime >= target)
+		state.SLABudgetRemaining = types.Float64Value(slaBudgetRemaining(target, uptime, slaWindow))
+	}
 
 	// Set state
 	diags = resp.State.Set(ctx, state)
@@ -255,6 +1127,20 @@ func (r *httpCheckResource) Update(ctx context.Context, req resource.UpdateReque
 		return
 	}
 
+	// Bound the whole operation by the configured or default timeout
+	ctx, cancel := context.WithTimeout(ctx, plan.Timeouts.update())
+	defer cancel()
+
+	// Only accept environments the provider allows
+	if err := r.client.ensureAllowedEnvironment(plan.Environment); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("environment"),
+			"Invalid Environment",
+			err.Error(),
+		)
+		return
+	}
+
 	// Preserve the ID from state
 	plan.ID = state.ID
 
@@ -263,13 +1149,23 @@ func (r *httpCheckResource) Update(ctx context.Context, req resource.UpdateReque
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error updating HTTP check",
-			fmt.Sprintf("Could not update HTTP check ID %s: %s", plan.ID.ValueString(), err),
+			r.client.errorDetail(fmt.Sprintf("Could not update HTTP check ID %s: %s", plan.ID.ValueString(), err), err, map[string]string{"id": plan.ID.ValueString()}),
 		)
 		return
 	}
 
-	// Update computed fields
-	plan.LastCheckTime = types.StringValue(time.Now().Format(time.RFC3339))
+	// Update computed fields; changing a check doesn't run it
+	plan.LastResult = state.LastResult
+	plan.LastCheckTime = state.LastCheckTime
+	plan.NextCheckTime = state.NextCheckTime
+	plan.IsFlapping = state.IsFlapping
+	plan.FlapCount1h = state.FlapCount1h
+	plan.ResponseTimeBaseline = state.ResponseTimeBaseline
+	plan.EffectiveConfig, diags = newEffectiveConfig(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Set state
 	diags = resp.State.Set(ctx, plan)
@@ -286,12 +1182,16 @@ func (r *httpCheckResource) Delete(ctx context.Context, req resource.DeleteReque
 		return
 	}
 
+	// Bound the whole operation by the configured or default timeout
+	ctx, cancel := context.WithTimeout(ctx, state.Timeouts.delete())
+	defer cancel()
+
 	// Call API to delete the check
 	err := r.client.deleteHTTPCheck(ctx, state.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error deleting HTTP check",
-			fmt.Sprintf("Could not delete HTTP check ID %s: %s", state.ID.ValueString(), err),
+			r.client.errorDetail(fmt.Sprintf("Could not delete HTTP check ID %s: %s", state.ID.ValueString(), err), err, map[string]string{"id": state.ID.ValueString()}),
 		)
 		return
 	}
@@ -302,4 +1202,14 @@ func (r *httpCheckResource) Delete(ctx context.Context, req resource.DeleteReque
 // ImportState imports an existing resource into Terraform
 func (r *httpCheckResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
-}
\ No newline at end of file
+}
+
+// UpgradeState upgrades state stored by earlier schema versions
+func (r *httpCheckResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema:   httpCheckSchemaV0(),
+			StateUpgrader: upgradeHTTPCheckStateV0,
+		},
+	}
+}
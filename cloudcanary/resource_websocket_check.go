@@ -0,0 +1,458 @@
+package cloudcanary
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// webSocketCheckResource implements a CloudCanary WebSocket check resource
+type webSocketCheckResource struct {
+	client *cloudCanaryClient
+}
+
+// Ensure the implementation satisfies the expected interfaces
+var _ resource.Resource = &webSocketCheckResource{}
+var _ resource.ResourceWithImportState = &webSocketCheckResource{}
+var _ resource.ResourceWithConfigValidators = &webSocketCheckResource{}
+
+// NewWebSocketCheckResource creates a new WebSocket check resource
+func NewWebSocketCheckResource() resource.Resource {
+	return &webSocketCheckResource{}
+}
+
+// Metadata returns the resource type name
+func (r *webSocketCheckResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_websocket_check"
+}
+
+// Schema defines the schema for the resource
+func (r *webSocketCheckResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a WebSocket check that performs the opening handshake and optionally exchanges a message.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The unique identifier for this check.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "The name of the check.",
+			},
+			"environment": schema.StringAttribute{
+				Optional:    true,
+				Description: "Environment the check belongs to, e.g. prod, staging or dev. Used for filtering and display; must be one of the provider's allowed_environments.",
+			},
+			"description": schema.StringAttribute{
+				Optional:    true,
+				Description: "Free-form notes on what the check covers, for responders. Has no effect on how the check runs.",
+			},
+			"runbook_url": schema.StringAttribute{
+				Optional:    true,
+				Description: "URL of the runbook to follow when the check fails. Reported on the check's incidents; has no effect on how the check runs.",
+				Validators: []validator.String{
+					validHTTPURL(),
+				},
+			},
+			"severity": schema.StringAttribute{
+				Optional:    true,
+				Description: "Priority alerts for the check are routed with: info, warning or critical. For example, critical checks can page while info checks only log.",
+				Validators: []validator.String{
+					stringvalidator.OneOf(checkSeverities...),
+				},
+			},
+			"result_labels": schema.MapAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "Labels attached to every result of the check, such as team or service, so results can be grouped without looking the check up.",
+			},
+			"url": schema.StringAttribute{
+				Required:    true,
+				Description: "The ws:// or wss:// URL to connect to.",
+				Validators: []validator.String{
+					validWebSocketURL(),
+				},
+			},
+			"subprotocol": schema.StringAttribute{
+				Optional:    true,
+				Description: "Subprotocol requested in the Sec-WebSocket-Protocol header. The server must agree to it for the check to pass.",
+			},
+			"send_message": schema.StringAttribute{
+				Optional:    true,
+				Description: "Text message sent once the connection is open.",
+			},
+			"expected_message": schema.StringAttribute{
+				Optional:    true,
+				Description: "Text that a message received from the server must contain.",
+			},
+			"interval": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Check interval in seconds.",
+			},
+			"warmup_period": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Seconds after the check is created during which failures are recorded in results but don't trigger alerts. Defaults to 0.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"timeout": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Timeout in seconds, covering the handshake and any message exchange.",
+			},
+			"regions": schema.ListAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "Public regions to run the check from, in any order. May be combined with private_location_ids; at least one of the two must be set.",
+				PlanModifiers: []planmodifier.List{
+					ignoreOrder(),
+				},
+			},
+			"private_location_ids": schema.ListAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "IDs of private locations whose agents run the check, for endpoints only reachable from inside a network. May be combined with regions; at least one of the two must be set.",
+				Validators: []validator.List{
+					listvalidator.UniqueValues(),
+					listvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
+			"last_result": schema.StringAttribute{
+				Computed:    true,
+				Description: "The result of the last check (UNKNOWN, PENDING, SUCCESS, DEGRADED, FAILURE). UNKNOWN until the check has produced a result.",
+			},
+			"last_check_time": schema.StringAttribute{
+				Computed:    true,
+				Description: "The time of the last check. Null while last_result is UNKNOWN.",
+			},
+			"next_check_time": schema.StringAttribute{
+				Computed:    true,
+				Description: "When the check is next scheduled to run. Taken from the API when it reports one, otherwise derived from last_check_time and interval. Null while last_result is UNKNOWN.",
+			},
+			"is_flapping": schema.BoolAttribute{
+				Computed:    true,
+				Description: "Whether the check changed status at least 3 times over the last hour.",
+			},
+			"flap_count_1h": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Number of times the check changed status over the last hour.",
+			},
+			"last_connect_latency": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Milliseconds the last check took to complete the opening handshake. Null until the check has run.",
+			},
+			"created_at": schema.StringAttribute{
+				Computed:    true,
+				Description: "When the check was created (RFC3339 format).",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"updated_at": schema.StringAttribute{
+				Computed:    true,
+				Description: "When the check was last updated (RFC3339 format).",
+			},
+		},
+	}
+}
+
+// ConfigValidators returns validators that check relationships between attributes
+func (r *webSocketCheckResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.AtLeastOneOf(
+			path.MatchRoot("regions"),
+			path.MatchRoot("private_location_ids"),
+		),
+	}
+}
+
+// Configure adds the provider configured client to the resource
+func (r *webSocketCheckResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*cloudCanaryClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *cloudCanaryClient, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+// Create creates a new WebSocket check
+func (r *webSocketCheckResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Get the plan
+	var plan WebSocketCheck
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Fail early when the account can't create this type of check, rather
+	// than with whatever error the API gives
+	if err := r.client.ensureCheckTypeEnabled(ctx, "websocket"); err != nil {
+		resp.Diagnostics.AddError(
+			"Check Type Not Enabled",
+			err.Error(),
+		)
+		return
+	}
+
+	// Only accept environments the provider allows
+	if err := r.client.ensureAllowedEnvironment(plan.Environment); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("environment"),
+			"Invalid Environment",
+			err.Error(),
+		)
+		return
+	}
+
+	// Guard against duplicate names when enabled on the provider
+	if err := r.client.ensureUniqueName(ctx, plan.Name.ValueString()); err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating WebSocket check",
+			r.client.errorDetail(fmt.Sprintf("Could not create WebSocket check: %s", err), err, map[string]string{"name": plan.Name.ValueString()}),
+		)
+		return
+	}
+
+	// Create a working copy for the API call
+	// This allows us to use defaults for the API call without modifying the plan
+	apiCheck := WebSocketCheck{
+		Name: plan.Name,
+		URL:  plan.URL,
+	}
+
+	// Copy all other fields directly from plan
+	apiCheck.Subprotocol = plan.Subprotocol
+	apiCheck.SendMessage = plan.SendMessage
+	apiCheck.ExpectedMessage = plan.ExpectedMessage
+	apiCheck.Interval = plan.Interval
+	apiCheck.WarmupPeriod = plan.WarmupPeriod
+	apiCheck.Timeout = plan.Timeout
+	apiCheck.Regions = plan.Regions
+	apiCheck.PrivateLocationIDs = plan.PrivateLocationIDs
+	apiCheck.Environment = plan.Environment
+	apiCheck.Description = plan.Description
+	apiCheck.RunbookURL = plan.RunbookURL
+	apiCheck.Severity = plan.Severity
+	apiCheck.ResultLabels = plan.ResultLabels
+
+	// Call the API using the working copy
+	err := r.client.createWebSocketCheck(ctx, &apiCheck)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating WebSocket check",
+			r.client.errorDetail(fmt.Sprintf("Could not create WebSocket check: %s", err), err, map[string]string{"name": plan.Name.ValueString()}),
+		)
+		return
+	}
+
+	// Now update the original plan with only computed fields
+	plan.ID = apiCheck.ID
+	plan.CreatedAt = apiCheck.CreatedAt
+	plan.UpdatedAt = apiCheck.UpdatedAt
+	plan.LastResult = types.StringValue(lastResultUnknown)
+	plan.LastCheckTime = types.StringNull()
+	plan.NextCheckTime = types.StringNull()
+	plan.IsFlapping = types.BoolValue(false)
+	plan.FlapCount1h = types.Int64Value(0)
+	plan.LastConnectLatency = types.Int64Null()
+
+	// Set state
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read refreshes the Terraform state with the latest data
+func (r *webSocketCheckResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Get current state
+	var state WebSocketCheck
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Call API to get the latest data
+	apiCheck, err := r.client.readWebSocketCheck(ctx, state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading WebSocket check",
+			r.client.errorDetail(fmt.Sprintf("Could not read WebSocket check ID %s: %s", state.ID.ValueString(), err), err, map[string]string{"id": state.ID.ValueString()}),
+		)
+		return
+	}
+
+	// Preserve null values in the state - copy only non-null fields from API response
+	if !apiCheck.ID.IsNull() {
+		state.ID = apiCheck.ID
+	}
+	if !apiCheck.Name.IsNull() {
+		state.Name = apiCheck.Name
+	}
+	if !apiCheck.URL.IsNull() {
+		state.URL = apiCheck.URL
+	}
+	if !apiCheck.Subprotocol.IsNull() {
+		state.Subprotocol = apiCheck.Subprotocol
+	}
+	if !apiCheck.SendMessage.IsNull() {
+		state.SendMessage = apiCheck.SendMessage
+	}
+	if !apiCheck.ExpectedMessage.IsNull() {
+		state.ExpectedMessage = apiCheck.ExpectedMessage
+	}
+	if !apiCheck.Interval.IsNull() {
+		state.Interval = apiCheck.Interval
+	}
+	if !apiCheck.WarmupPeriod.IsNull() {
+		state.WarmupPeriod = apiCheck.WarmupPeriod
+	}
+	if !apiCheck.Timeout.IsNull() {
+		state.Timeout = apiCheck.Timeout
+	}
+	if !apiCheck.Regions.IsNull() {
+		state.Regions = apiCheck.Regions
+	}
+	if !apiCheck.PrivateLocationIDs.IsNull() {
+		state.PrivateLocationIDs = apiCheck.PrivateLocationIDs
+	}
+	if !apiCheck.Environment.IsNull() {
+		state.Environment = apiCheck.Environment
+	}
+	if !apiCheck.Description.IsNull() {
+		state.Description = apiCheck.Description
+	}
+	if !apiCheck.RunbookURL.IsNull() {
+		state.RunbookURL = apiCheck.RunbookURL
+	}
+	if !apiCheck.Severity.IsNull() {
+		state.Severity = apiCheck.Severity
+	}
+	if !apiCheck.ResultLabels.IsNull() {
+		state.ResultLabels = apiCheck.ResultLabels
+	}
+	if !apiCheck.CreatedAt.IsNull() {
+		state.CreatedAt = apiCheck.CreatedAt
+	}
+
+	// Always update computed fields
+	state.LastResult, state.LastCheckTime = lastRun(apiCheck.LastResult, apiCheck.LastCheckTime)
+	state.NextCheckTime = nextRun(apiCheck.NextCheckTime, state.LastCheckTime, state.Interval)
+	state.LastConnectLatency = apiCheck.LastConnectLatency
+	state.UpdatedAt = apiCheck.UpdatedAt
+
+	// Derive flapping from how often the status changed over the last hour
+	stats, err := r.client.getCheckStats(ctx, state.ID.ValueString(), 1, "none")
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading WebSocket check stats",
+			fmt.Sprintf("Could not read stats for WebSocket check ID %s: %s", state.ID.ValueString(), err),
+		)
+		return
+	}
+	state.IsFlapping = stats.IsFlapping
+	state.FlapCount1h = stats.FlapCount1h
+
+	// Set state
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update updates the resource
+func (r *webSocketCheckResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Get plan and current state
+	var plan, state WebSocketCheck
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Only accept environments the provider allows
+	if err := r.client.ensureAllowedEnvironment(plan.Environment); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("environment"),
+			"Invalid Environment",
+			err.Error(),
+		)
+		return
+	}
+
+	// Preserve the ID from state
+	plan.ID = state.ID
+
+	// Call API to update the check
+	err := r.client.updateWebSocketCheck(ctx, &plan)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating WebSocket check",
+			r.client.errorDetail(fmt.Sprintf("Could not update WebSocket check ID %s: %s", plan.ID.ValueString(), err), err, map[string]string{"id": plan.ID.ValueString()}),
+		)
+		return
+	}
+
+	// Update computed fields
+	plan.LastResult = state.LastResult
+	plan.LastCheckTime = state.LastCheckTime
+	plan.NextCheckTime = state.NextCheckTime
+	plan.IsFlapping = state.IsFlapping
+	plan.FlapCount1h = state.FlapCount1h
+	plan.LastConnectLatency = state.LastConnectLatency
+
+	// Set state
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete deletes the resource
+func (r *webSocketCheckResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Get current state
+	var state WebSocketCheck
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Call API to delete the check
+	err := r.client.deleteWebSocketCheck(ctx, state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting WebSocket check",
+			r.client.errorDetail(fmt.Sprintf("Could not delete WebSocket check ID %s: %s", state.ID.ValueString(), err), err, map[string]string{"id": state.ID.ValueString()}),
+		)
+		return
+	}
+
+	// Terraform will remove the resource from state
+}
+
+// ImportState imports an existing resource into Terraform
+func (r *webSocketCheckResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
@@ -0,0 +1,55 @@
+package cloudcanary
+
+import (
+	// Embed the time zone database so timezone validation doesn't depend on the host
+	_ "time/tzdata"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// weekdays are the accepted days_of_week values, in order
+var weekdays = []string{"mon", "tue", "wed", "thu", "fri", "sat", "sun"}
+
+// activeScheduleBlock returns the schema of the active_schedule block shared by the check resources
+func activeScheduleBlock() schema.SingleNestedBlock {
+	return schema.SingleNestedBlock{
+		Description: "Restricts the check to run, and alert, only during a weekly window. Omit to run at all times.",
+		Attributes: map[string]schema.Attribute{
+			"timezone": schema.StringAttribute{
+				Required:    true,
+				Description: "IANA time zone the hours are in (e.g. Europe/Berlin).",
+				Validators: []validator.String{
+					validTimezone(),
+				},
+			},
+			"days_of_week": schema.ListAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "Days the check is active (mon, tue, wed, thu, fri, sat, sun). Defaults to every day.",
+				Validators: []validator.List{
+					listvalidator.ValueStringsAre(stringvalidator.OneOf(weekdays...)),
+					listvalidator.UniqueValues(),
+				},
+			},
+			"start_hour": schema.Int64Attribute{
+				Required:    true,
+				Description: "Hour of the day the window opens (0-23).",
+				Validators: []validator.Int64{
+					int64validator.Between(0, 23),
+				},
+			},
+			"end_hour": schema.Int64Attribute{
+				Required:    true,
+				Description: "Hour of the day the window closes (1-24). An end_hour at or before start_hour makes the window run past midnight.",
+				Validators: []validator.Int64{
+					int64validator.Between(1, 24),
+				},
+			},
+		},
+	}
+}
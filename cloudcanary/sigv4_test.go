@@ -0,0 +1,130 @@
+package cloudcanary
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// sigV4AuthorizationPattern matches the Authorization header of a SigV4 request
+var sigV4AuthorizationPattern = regexp.MustCompile(`^AWS4-HMAC-SHA256 Credential=([^/]+)/(\d{8})/([^/]+)/([^/]+)/aws4_request, SignedHeaders=([a-z0-9;-]+), Signature=([0-9a-f]{64})$`)
+
+// verifySigV4 checks a received request's SigV4 signature as AWS would,
+// recomputing it from what arrived. The test endpoints use plain paths and
+// query values, so the canonical forms are built without extra escaping.
+func verifySigV4(r *http.Request, body []byte, accessKeyID, secretAccessKey string) error {
+	m := sigV4AuthorizationPattern.FindStringSubmatch(r.Header.Get("Authorization"))
+	if m == nil {
+		return fmt.Errorf("malformed Authorization %q", r.Header.Get("Authorization"))
+	}
+	keyID, date, region, service, signedHeaders, signature := m[1], m[2], m[3], m[4], m[5], m[6]
+	if keyID != accessKeyID {
+		return fmt.Errorf("unknown access key %q", keyID)
+	}
+
+	amzDate := r.Header.Get("X-Amz-Date")
+	if !strings.HasPrefix(amzDate, date) {
+		return fmt.Errorf("X-Amz-Date %q does not match credential date %s", amzDate, date)
+	}
+	bodyHash := sha256.Sum256(body)
+	if r.Header.Get("X-Amz-Content-Sha256") != hex.EncodeToString(bodyHash[:]) {
+		return fmt.Errorf("X-Amz-Content-Sha256 does not match the body received")
+	}
+
+	var canonicalHeaders strings.Builder
+	for _, name := range strings.Split(signedHeaders, ";") {
+		value := r.Header.Get(name)
+		if name == "host" {
+			value = r.Host
+		}
+		canonicalHeaders.WriteString(name + ":" + value + "\n")
+	}
+
+	query := r.URL.Query()
+	var queryPairs []string
+	for _, name := range []string{"a", "b"} {
+		for _, value := range query[name] {
+			queryPairs = append(queryPairs, name+"="+value)
+		}
+	}
+
+	canonicalRequest := strings.Join([]string{
+		r.Method,
+		r.URL.Path,
+		strings.Join(queryPairs, "&"),
+		canonicalHeaders.String(),
+		signedHeaders,
+		hex.EncodeToString(bodyHash[:]),
+	}, "\n")
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	scope := date + "/" + region + "/" + service + "/aws4_request"
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(requestHash[:])
+
+	key := []byte("AWS4" + secretAccessKey)
+	for _, part := range []string{date, region, service, "aws4_request"} {
+		mac := hmac.New(sha256.New, key)
+		mac.Write([]byte(part))
+		key = mac.Sum(nil)
+	}
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(stringToSign))
+	if want := hex.EncodeToString(mac.Sum(nil)); signature != want {
+		return fmt.Errorf("signature mismatch")
+	}
+	return nil
+}
+
+func TestProbeSigV4Auth(t *testing.T) {
+	const (
+		accessKeyID     = "AKIDEXAMPLE"
+		secretAccessKey = "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY"
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if err := verifySigV4(r, body, accessKeyID, secretAccessKey); err != nil {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+		fmt.Fprint(w, `{"ok":true}`)
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name       string
+		method     string
+		body       types.String
+		secret     string
+		wantStatus string
+	}{
+		{name: "GET with query", method: "GET", body: types.StringNull(), secret: secretAccessKey, wantStatus: "SUCCESS"},
+		{name: "POST with body", method: "POST", body: types.StringValue(`{"id":42}`), secret: secretAccessKey, wantStatus: "SUCCESS"},
+		{name: "wrong secret", method: "GET", body: types.StringNull(), secret: "not-the-secret", wantStatus: "FAILURE"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := runAPICheck(t, newTestClient(), APICheck{
+				Endpoint:           types.StringValue(server.URL + "/prod/items?b=2&a=1"),
+				Method:             types.StringValue(tt.method),
+				Body:               tt.body,
+				AuthType:           types.StringValue("aws_sigv4"),
+				AWSAccessKeyID:     types.StringValue(accessKeyID),
+				AWSSecretAccessKey: types.StringValue(tt.secret),
+				AWSRegion:          types.StringValue("us-east-1"),
+				AWSService:         types.StringValue("execute-api"),
+			})
+			if got := result.Status.ValueString(); got != tt.wantStatus {
+				t.Errorf("status = %s, want %s (failure reason %s)", got, tt.wantStatus, result.FailureReason)
+			}
+		})
+	}
+}
@@ -0,0 +1,28 @@
+package cloudcanary
+
+import (
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// slaWindow is the rolling window SLA targets are measured over
+const slaWindow = 30 * 24 * time.Hour
+
+// slaBudgetRemaining returns the minutes of downtime still allowed by an
+// uptime target over window, given the uptime observed so far. Both
+// percentages are 0-100; the result is negative once the budget is spent.
+func slaBudgetRemaining(target, uptime float64, window time.Duration) float64 {
+	return (uptime - target) / 100 * window.Minutes()
+}
+
+// errorBudgetBurnRate returns how many times faster than sustainable an error
+// rate spends the error budget of an SLO target, given as a 0-100 percentage
+// below 100. A burn rate of 1 spends exactly the budget over the SLO window.
+// A null error rate, from a window without results, gives a null burn rate.
+func errorBudgetBurnRate(errorRate types.Float64, target float64) types.Float64 {
+	if errorRate.IsNull() {
+		return types.Float64Null()
+	}
+	return types.Float64Value(errorRate.ValueFloat64() / (1 - target/100))
+}
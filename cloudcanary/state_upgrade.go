@@ -0,0 +1,189 @@
+package cloudcanary
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// httpCheckV0 is the http_check state as stored by schema version 0
+type httpCheckV0 struct {
+	ID               types.String `tfsdk:"id"`
+	Name             types.String `tfsdk:"name"`
+	URL              types.String `tfsdk:"url"`
+	Method           types.String `tfsdk:"method"`
+	Headers          types.Map    `tfsdk:"headers"`
+	Body             types.String `tfsdk:"body"`
+	ExpectedStatus   types.Int64  `tfsdk:"expected_status"`
+	ExpectedResponse types.String `tfsdk:"expected_response"`
+	Interval         types.Int64  `tfsdk:"interval"`
+	Timeout          types.Int64  `tfsdk:"timeout"`
+	FollowRedirects  types.Bool   `tfsdk:"follow_redirects"`
+	Regions          types.List   `tfsdk:"regions"`
+	Retries          types.Int64  `tfsdk:"retries"`
+	LastResult       types.String `tfsdk:"last_result"`
+	LastCheckTime    types.String `tfsdk:"last_check_time"`
+}
+
+// apiCheckV0 is the api_check state as stored by schema version 0
+type apiCheckV0 struct {
+	ID                 types.String `tfsdk:"id"`
+	Name               types.String `tfsdk:"name"`
+	Endpoint           types.String `tfsdk:"endpoint"`
+	Method             types.String `tfsdk:"method"`
+	Headers            types.Map    `tfsdk:"headers"`
+	Body               types.String `tfsdk:"body"`
+	ExpectedStatus     types.Int64  `tfsdk:"expected_status"`
+	ResponseValidation types.List   `tfsdk:"response_validation"`
+	Interval           types.Int64  `tfsdk:"interval"`
+	Timeout            types.Int64  `tfsdk:"timeout"`
+	AuthType           types.String `tfsdk:"auth_type"`
+	AuthValue          types.String `tfsdk:"auth_value"`
+	LastResult         types.String `tfsdk:"last_result"`
+	LastCheckTime      types.String `tfsdk:"last_check_time"`
+}
+
+// httpCheckSchemaV0 returns the http_check schema at version 0
+func httpCheckSchemaV0() *schema.Schema {
+	return &schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id":                schema.StringAttribute{Computed: true},
+			"name":              schema.StringAttribute{Required: true},
+			"url":               schema.StringAttribute{Required: true},
+			"method":            schema.StringAttribute{Optional: true},
+			"headers":           schema.MapAttribute{ElementType: types.StringType, Optional: true},
+			"body":              schema.StringAttribute{Optional: true},
+			"expected_status":   schema.Int64Attribute{Optional: true},
+			"expected_response": schema.StringAttribute{Optional: true},
+			"interval":          schema.Int64Attribute{Optional: true},
+			"timeout":           schema.Int64Attribute{Optional: true},
+			"follow_redirects":  schema.BoolAttribute{Optional: true},
+			"regions":           schema.ListAttribute{ElementType: types.StringType, Optional: true},
+			"retries":           schema.Int64Attribute{Optional: true},
+			"last_result":       schema.StringAttribute{Computed: true},
+			"last_check_time":   schema.StringAttribute{Computed: true},
+		},
+	}
+}
+
+// apiCheckSchemaV0 returns the api_check schema at version 0
+func apiCheckSchemaV0() *schema.Schema {
+	return &schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id":                  schema.StringAttribute{Computed: true},
+			"name":                schema.StringAttribute{Required: true},
+			"endpoint":            schema.StringAttribute{Required: true},
+			"method":              schema.StringAttribute{Optional: true},
+			"headers":             schema.MapAttribute{ElementType: types.StringType, Optional: true},
+			"body":                schema.StringAttribute{Optional: true},
+			"expected_status":     schema.Int64Attribute{Optional: true},
+			"response_validation": schema.ListAttribute{ElementType: types.StringType, Optional: true},
+			"interval":            schema.Int64Attribute{Optional: true},
+			"timeout":             schema.Int64Attribute{Optional: true},
+			"auth_type":           schema.StringAttribute{Optional: true},
+			"auth_value":          schema.StringAttribute{Optional: true, Sensitive: true},
+			"last_result":         schema.StringAttribute{Computed: true},
+			"last_check_time":     schema.StringAttribute{Computed: true},
+		},
+	}
+}
+
+// upgradeHTTPCheckStateV0 copies a version 0 http_check state forward,
+// leaving attributes added since then null
+func upgradeHTTPCheckStateV0(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+	var prior httpCheckV0
+	diags := req.State.Get(ctx, &prior)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	upgraded := HTTPCheck{
+		ID:                 prior.ID,
+		Name:               prior.Name,
+		URL:                prior.URL,
+		Method:             prior.Method,
+		Headers:            prior.Headers,
+		Body:               prior.Body,
+		ExpectedStatus:     prior.ExpectedStatus,
+		ExpectedResponse:   prior.ExpectedResponse,
+		Interval:           prior.Interval,
+		Timeout:            prior.Timeout,
+		FollowRedirects:    prior.FollowRedirects,
+		Regions:            prior.Regions,
+		PrivateLocationIDs: types.ListNull(types.StringType),
+		Retries:            prior.Retries,
+		LastResult:         prior.LastResult,
+		LastCheckTime:      prior.LastCheckTime,
+		// New attributes are filled in by the next Read
+		NormalizeJSONBody:    types.BoolNull(),
+		FormFields:           types.MapNull(types.StringType),
+		FormFiles:            types.MapNull(types.StringType),
+		FormFilesSHA256:      types.MapNull(types.StringType),
+		ExpectedHeaders:      types.MapNull(types.StringType),
+		ExpectedTrailers:     types.MapNull(types.StringType),
+		ResultLabels:         types.MapNull(types.StringType),
+		Cookies:              types.MapNull(types.StringType),
+		AllowedCipherSuites:  types.ListNull(types.StringType),
+		AdditionalURLs:       types.ListNull(types.StringType),
+		LastResultDetail:     types.ObjectNull(lastResultDetailAttrTypes),
+		EffectiveConfig:      types.ObjectNull(effectiveConfigAttrTypes),
+		LastResponseSize:     types.Int64Null(),
+		LastBodySHA256:       types.StringNull(),
+		ResponseTimeBaseline: types.Float64Null(),
+		SLATarget:            types.Float64Null(),
+		SLACompliant:         types.BoolNull(),
+		SLABudgetRemaining:   types.Float64Null(),
+		CreatedAt:            types.StringNull(),
+		UpdatedAt:            types.StringNull(),
+	}
+
+	diags = resp.State.Set(ctx, upgraded)
+	resp.Diagnostics.Append(diags...)
+}
+
+// upgradeAPICheckStateV0 copies a version 0 api_check state forward,
+// leaving attributes added since then null
+func upgradeAPICheckStateV0(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+	var prior apiCheckV0
+	diags := req.State.Get(ctx, &prior)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	upgraded := APICheck{
+		ID:                 prior.ID,
+		Name:               prior.Name,
+		Endpoint:           prior.Endpoint,
+		Method:             prior.Method,
+		Headers:            prior.Headers,
+		Body:               prior.Body,
+		ExpectedStatus:     prior.ExpectedStatus,
+		ResponseValidation: prior.ResponseValidation,
+		Interval:           prior.Interval,
+		Timeout:            prior.Timeout,
+		AuthType:           prior.AuthType,
+		AuthValue:          prior.AuthValue,
+		LastResult:         prior.LastResult,
+		LastCheckTime:      prior.LastCheckTime,
+		// New attributes are filled in by the next Read
+		NormalizeJSONBody:  types.BoolNull(),
+		ExpectedJSONBody:   types.StringNull(),
+		IgnorePaths:        types.ListNull(types.StringType),
+		SuccessStatusCodes: types.ListNull(types.Int64Type),
+		JWTClaims:          types.MapNull(types.StringType),
+		ExpectedHeaders:    types.MapNull(types.StringType),
+		Extract:            types.MapNull(types.StringType),
+		ExtractedValues:    types.MapNull(types.StringType),
+		ResultLabels:       types.MapNull(types.StringType),
+		LastResultDetail:   types.ObjectNull(lastResultDetailAttrTypes),
+		CreatedAt:          types.StringNull(),
+		UpdatedAt:          types.StringNull(),
+	}
+
+	diags = resp.State.Set(ctx, upgraded)
+	resp.Diagnostics.Append(diags...)
+}
@@ -0,0 +1,238 @@
+package cloudcanary
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// upgradeV0State decodes raw version 0 JSON state with the upgrader's prior
+// schema, runs the upgrader and returns the prior and upgraded states
+func upgradeV0State(t *testing.T, r resource.ResourceWithUpgradeState, rawJSON string) (tfsdk.State, tfsdk.State) {
+	t.Helper()
+	ctx := context.Background()
+
+	upgrader, ok := r.UpgradeState(ctx)[0]
+	if !ok {
+		t.Fatal("no upgrader for schema version 0")
+	}
+	if upgrader.PriorSchema == nil {
+		t.Fatal("version 0 upgrader has no prior schema")
+	}
+
+	priorType := upgrader.PriorSchema.Type().TerraformType(ctx)
+	priorRaw, err := tfprotov6.RawState{JSON: []byte(rawJSON)}.Unmarshal(priorType)
+	if err != nil {
+		t.Fatalf("decoding V0 state: %v", err)
+	}
+	prior := tfsdk.State{Schema: *upgrader.PriorSchema, Raw: priorRaw}
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	if schemaResp.Schema.Version != 1 {
+		t.Fatalf("schema version = %d, want 1", schemaResp.Schema.Version)
+	}
+
+	resp := resource.UpgradeStateResponse{
+		State: tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+		},
+	}
+	upgrader.StateUpgrader(ctx, resource.UpgradeStateRequest{State: &prior}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("upgrade diagnostics: %v", resp.Diagnostics)
+	}
+	return prior, resp.State
+}
+
+// assertUpgradedAttributes checks every V0 attribute is carried over
+// unchanged and every attribute added since is null
+func assertUpgradedAttributes(t *testing.T, prior, upgraded tfsdk.State) {
+	t.Helper()
+
+	var priorAttrs, upgradedAttrs map[string]tftypes.Value
+	if err := prior.Raw.As(&priorAttrs); err != nil {
+		t.Fatal(err)
+	}
+	if err := upgraded.Raw.As(&upgradedAttrs); err != nil {
+		t.Fatal(err)
+	}
+
+	for name, want := range priorAttrs {
+		got, ok := upgradedAttrs[name]
+		if !ok {
+			t.Errorf("%s dropped by the upgrade", name)
+			continue
+		}
+		if !got.Equal(want) {
+			t.Errorf("%s = %s, want %s", name, got, want)
+		}
+	}
+	for name, got := range upgradedAttrs {
+		if _, ok := priorAttrs[name]; ok {
+			continue
+		}
+		if !got.IsNull() {
+			t.Errorf("new attribute %s = %s, want null", name, got)
+		}
+	}
+}
+
+func TestUpgradeHTTPCheckStateV0(t *testing.T) {
+	tests := []struct {
+		name  string
+		state string
+	}{
+		{
+			name: "all attributes set",
+			state: `{
+				"id": "hc-1234",
+				"name": "homepage",
+				"url": "https://example.com/",
+				"method": "POST",
+				"headers": {"X-Env": "prod"},
+				"body": "ping",
+				"expected_status": 201,
+				"expected_response": "pong",
+				"interval": 120,
+				"timeout": 15,
+				"follow_redirects": false,
+				"regions": ["us-east-1", "eu-west-1"],
+				"retries": 2,
+				"last_result": "success",
+				"last_check_time": "2023-06-01T12:00:00Z"
+			}`,
+		},
+		{
+			name: "optional attributes null",
+			state: `{
+				"id": "hc-5678",
+				"name": "minimal",
+				"url": "https://example.com/health",
+				"method": null,
+				"headers": null,
+				"body": null,
+				"expected_status": null,
+				"expected_response": null,
+				"interval": null,
+				"timeout": null,
+				"follow_redirects": null,
+				"regions": null,
+				"retries": null,
+				"last_result": null,
+				"last_check_time": null
+			}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewHTTPCheckResource().(*httpCheckResource)
+			prior, upgraded := upgradeV0State(t, r, tt.state)
+			assertUpgradedAttributes(t, prior, upgraded)
+
+			var check HTTPCheck
+			if diags := upgraded.Get(context.Background(), &check); diags.HasError() {
+				t.Fatalf("reading upgraded state: %v", diags)
+			}
+			if !check.PrivateLocationIDs.Equal(types.ListNull(types.StringType)) {
+				t.Errorf("private_location_ids = %s, want null list of strings", check.PrivateLocationIDs)
+			}
+			if !check.Cookies.Equal(types.MapNull(types.StringType)) {
+				t.Errorf("cookies = %s, want null map of strings", check.Cookies)
+			}
+			if !check.ResultLabels.Equal(types.MapNull(types.StringType)) {
+				t.Errorf("result_labels = %s, want null map of strings", check.ResultLabels)
+			}
+			if !check.LastResultDetail.Equal(types.ObjectNull(lastResultDetailAttrTypes)) {
+				t.Errorf("last_result_detail = %s, want null object", check.LastResultDetail)
+			}
+			if !check.EffectiveConfig.Equal(types.ObjectNull(effectiveConfigAttrTypes)) {
+				t.Errorf("effective_config = %s, want null object", check.EffectiveConfig)
+			}
+			if !check.SLATarget.IsNull() || !check.SLACompliant.IsNull() {
+				t.Errorf("sla_target = %s, sla_compliant = %s, want null", check.SLATarget, check.SLACompliant)
+			}
+		})
+	}
+}
+
+func TestUpgradeAPICheckStateV0(t *testing.T) {
+	tests := []struct {
+		name  string
+		state string
+	}{
+		{
+			name: "all attributes set",
+			state: `{
+				"id": "api-1234",
+				"name": "orders",
+				"endpoint": "https://api.example.com/orders",
+				"method": "GET",
+				"headers": {"Accept": "application/json"},
+				"body": null,
+				"expected_status": 200,
+				"response_validation": ["$.status == ok"],
+				"interval": 60,
+				"timeout": 10,
+				"auth_type": "bearer",
+				"auth_value": "secret-token",
+				"last_result": "failure",
+				"last_check_time": "2023-06-01T12:00:00Z"
+			}`,
+		},
+		{
+			name: "optional attributes null",
+			state: `{
+				"id": "api-5678",
+				"name": "minimal",
+				"endpoint": "https://api.example.com/health",
+				"method": null,
+				"headers": null,
+				"body": null,
+				"expected_status": null,
+				"response_validation": null,
+				"interval": null,
+				"timeout": null,
+				"auth_type": null,
+				"auth_value": null,
+				"last_result": null,
+				"last_check_time": null
+			}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewAPICheckResource().(*apiCheckResource)
+			prior, upgraded := upgradeV0State(t, r, tt.state)
+			assertUpgradedAttributes(t, prior, upgraded)
+
+			var check APICheck
+			if diags := upgraded.Get(context.Background(), &check); diags.HasError() {
+				t.Fatalf("reading upgraded state: %v", diags)
+			}
+			if !check.SuccessStatusCodes.Equal(types.ListNull(types.Int64Type)) {
+				t.Errorf("success_status_codes = %s, want null list of numbers", check.SuccessStatusCodes)
+			}
+			if !check.IgnorePaths.Equal(types.ListNull(types.StringType)) {
+				t.Errorf("ignore_paths = %s, want null list of strings", check.IgnorePaths)
+			}
+			if !check.JWTClaims.Equal(types.MapNull(types.StringType)) {
+				t.Errorf("jwt_claims = %s, want null map of strings", check.JWTClaims)
+			}
+			if !check.ExtractedValues.Equal(types.MapNull(types.StringType)) {
+				t.Errorf("extracted_values = %s, want null map of strings", check.ExtractedValues)
+			}
+			if !check.LastResultDetail.Equal(types.ObjectNull(lastResultDetailAttrTypes)) {
+				t.Errorf("last_result_detail = %s, want null object", check.LastResultDetail)
+			}
+		})
+	}
+}
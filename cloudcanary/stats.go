@@ -0,0 +1,176 @@
+package cloudcanary
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// statsGroupings are the accepted group_by values mapped to their bucket width
+var statsGroupings = map[string]time.Duration{
+	"none": 0,
+	"hour": time.Hour,
+	"day":  24 * time.Hour,
+}
+
+// flapWindow is how far back status transitions count towards flap_count_1h
+const flapWindow = time.Hour
+
+// Windows error_budget_burn_rate_1h and error_budget_burn_rate_6h are measured over
+const (
+	burnRateShortWindow = time.Hour
+	burnRateLongWindow  = 6 * time.Hour
+)
+
+// flapThreshold is the number of transitions within flapWindow at which a check is flapping
+const flapThreshold = 3
+
+// summarizeResults aggregates results into overall statistics plus, when
+// bucket is non-zero, per-bucket statistics ordered oldest first
+func summarizeResults(results []CheckResult, bucket time.Duration) (*CheckStats, error) {
+	stats := &CheckStats{
+		Uptime:          types.Float64Null(),
+		AvgResponseTime: types.Float64Null(),
+		P50ResponseTime: types.Int64Null(),
+		P90ResponseTime: types.Int64Null(),
+		P95ResponseTime: types.Int64Null(),
+		P99ResponseTime: types.Int64Null(),
+		Buckets:         []CheckStatsBucket{},
+	}
+	if len(results) == 0 {
+		return stats, nil
+	}
+
+	stats.Uptime = types.Float64Value(uptimePercent(results))
+	stats.AvgResponseTime = types.Float64Value(averageResponseTime(results))
+	times := responseTimes(results)
+	stats.P50ResponseTime = types.Int64Value(percentile(times, 50))
+	stats.P90ResponseTime = types.Int64Value(percentile(times, 90))
+	stats.P95ResponseTime = types.Int64Value(percentile(times, 95))
+	stats.P99ResponseTime = types.Int64Value(percentile(times, 99))
+
+	if bucket == 0 {
+		return stats, nil
+	}
+
+	grouped := make(map[time.Time][]CheckResult)
+	for _, result := range results {
+		timestamp, err := time.Parse(time.RFC3339, result.Timestamp.ValueString())
+		if err != nil {
+			return nil, fmt.Errorf("invalid result timestamp %q: %w", result.Timestamp.ValueString(), err)
+		}
+		start := timestamp.UTC().Truncate(bucket)
+		grouped[start] = append(grouped[start], result)
+	}
+
+	starts := make([]time.Time, 0, len(grouped))
+	for start := range grouped {
+		starts = append(starts, start)
+	}
+	sort.Slice(starts, func(i, j int) bool { return starts[i].Before(starts[j]) })
+
+	for _, start := range starts {
+		bucketResults := grouped[start]
+		stats.Buckets = append(stats.Buckets, CheckStatsBucket{
+			Start:           types.StringValue(start.Format(time.RFC3339)),
+			ResultCount:     types.Int64Value(int64(len(bucketResults))),
+			Uptime:          types.Float64Value(uptimePercent(bucketResults)),
+			AvgResponseTime: types.Float64Value(averageResponseTime(bucketResults)),
+		})
+	}
+
+	return stats, nil
+}
+
+// uptimePercent returns the percentage of results that succeeded
+func uptimePercent(results []CheckResult) float64 {
+	successes := 0
+	for _, result := range results {
+		if result.Status.ValueString() == "SUCCESS" {
+			successes++
+		}
+	}
+	return float64(successes) / float64(len(results)) * 100
+}
+
+// averageResponseTime returns the mean response time of results in milliseconds
+func averageResponseTime(results []CheckResult) float64 {
+	var total int64
+	for _, result := range results {
+		total += result.ResponseTime.ValueInt64()
+	}
+	return float64(total) / float64(len(results))
+}
+
+// responseTimes returns the response times of results in milliseconds
+func responseTimes(results []CheckResult) []int64 {
+	times := make([]int64, 0, len(results))
+	for _, result := range results {
+		times = append(times, result.ResponseTime.ValueInt64())
+	}
+	return times
+}
+
+// percentile returns the p-th percentile of values using the nearest-rank
+// method: the smallest value at least p percent of values are less than or
+// equal to. It is always one of values, so with few values high percentiles
+// are simply the largest, e.g. the 99th percentile of fewer than 100 values.
+func percentile(values []int64, p float64) int64 {
+	if len(values) == 0 {
+		return 0
+	}
+
+	sorted := append([]int64(nil), values...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+// errorRate returns the fraction of results recorded at or after since that
+// did not succeed, or null when there are none. results are newest first.
+func errorRate(results []CheckResult, since time.Time) (types.Float64, error) {
+	total, failures := 0, 0
+	for _, result := range results {
+		timestamp, err := time.Parse(time.RFC3339, result.Timestamp.ValueString())
+		if err != nil {
+			return types.Float64Null(), fmt.Errorf("invalid result timestamp %q: %w", result.Timestamp.ValueString(), err)
+		}
+		if timestamp.Before(since) {
+			break
+		}
+		total++
+		if result.Status.ValueString() != "SUCCESS" {
+			failures++
+		}
+	}
+	if total == 0 {
+		return types.Float64Null(), nil
+	}
+	return types.Float64Value(float64(failures) / float64(total)), nil
+}
+
+// countTransitions returns how many times the status changed between
+// consecutive results recorded at or after since. results are newest first.
+func countTransitions(results []CheckResult, since time.Time) (int, error) {
+	transitions := 0
+	for i := 1; i < len(results); i++ {
+		timestamp, err := time.Parse(time.RFC3339, results[i].Timestamp.ValueString())
+		if err != nil {
+			return 0, fmt.Errorf("invalid result timestamp %q: %w", results[i].Timestamp.ValueString(), err)
+		}
+		if timestamp.Before(since) {
+			break
+		}
+		if results[i].Status.ValueString() != results[i-1].Status.ValueString() {
+			transitions++
+		}
+	}
+	return transitions, nil
+}
@@ -0,0 +1,84 @@
+package cloudcanary
+
+import (
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Default timeouts of each Terraform operation on a check
+const (
+	defaultCreateTimeout = 5 * time.Minute
+	defaultReadTimeout   = 2 * time.Minute
+	defaultUpdateTimeout = 5 * time.Minute
+	defaultDeleteTimeout = 5 * time.Minute
+)
+
+// timeoutsBlock returns the schema of the timeouts block shared by the check resources
+func timeoutsBlock() schema.SingleNestedBlock {
+	attribute := func(operation, def string) schema.StringAttribute {
+		return schema.StringAttribute{
+			Optional:    true,
+			Description: "How long to wait for the check to be " + operation + " (e.g. \"30s\", \"10m\"). Defaults to " + def + ".",
+			Validators: []validator.String{
+				validDuration(),
+			},
+		}
+	}
+
+	return schema.SingleNestedBlock{
+		Description: "Overrides how long each operation on the check may take before it is abandoned.",
+		Attributes: map[string]schema.Attribute{
+			"create": attribute("created", defaultCreateTimeout.String()),
+			"read":   attribute("read", defaultReadTimeout.String()),
+			"update": attribute("updated", defaultUpdateTimeout.String()),
+			"delete": attribute("deleted", defaultDeleteTimeout.String()),
+		},
+	}
+}
+
+// create returns the create timeout, or the default when none is configured
+func (t *Timeouts) create() time.Duration {
+	if t == nil {
+		return defaultCreateTimeout
+	}
+	return parseTimeout(t.Create, defaultCreateTimeout)
+}
+
+// read returns the read timeout, or the default when none is configured
+func (t *Timeouts) read() time.Duration {
+	if t == nil {
+		return defaultReadTimeout
+	}
+	return parseTimeout(t.Read, defaultReadTimeout)
+}
+
+// update returns the update timeout, or the default when none is configured
+func (t *Timeouts) update() time.Duration {
+	if t == nil {
+		return defaultUpdateTimeout
+	}
+	return parseTimeout(t.Update, defaultUpdateTimeout)
+}
+
+// delete returns the delete timeout, or the default when none is configured
+func (t *Timeouts) delete() time.Duration {
+	if t == nil {
+		return defaultDeleteTimeout
+	}
+	return parseTimeout(t.Delete, defaultDeleteTimeout)
+}
+
+// parseTimeout returns value as a duration, or def when it is unset or invalid
+func parseTimeout(value types.String, def time.Duration) time.Duration {
+	if value.IsNull() || value.IsUnknown() {
+		return def
+	}
+	d, err := time.ParseDuration(value.ValueString())
+	if err != nil || d <= 0 {
+		return def
+	}
+	return d
+}
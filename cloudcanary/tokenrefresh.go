@@ -0,0 +1,151 @@
+package cloudcanary
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// tokenRefreshMargin is how long before its expiry a cached token is refreshed,
+// so it can't lapse while a probe is in flight
+const tokenRefreshMargin = 30 * time.Second
+
+// cachedToken is a token fetched from token_refresh_url and when it expires.
+// A zero expiry means the lifetime is unknown and the token is used once.
+type cachedToken struct {
+	token  string
+	expiry time.Time
+}
+
+// tokenCache holds the tokens of API checks with auth_type token_refresh,
+// in memory only. The zero value is ready to use.
+type tokenCache struct {
+	mu     sync.Mutex
+	tokens map[string]cachedToken
+}
+
+// refreshedToken returns the token an API check with auth_type token_refresh
+// sends, fetching a new one when there is none cached or the cached one is
+// about to expire. The lock is not held while fetching, so a slow token server
+// holds up only its own check's probes. Concurrent probes of one check may
+// each fetch a token, in which case the last one fetched is kept.
+func (c *cloudCanaryClient) refreshedToken(ctx context.Context, check *APICheck, timeout time.Duration) (string, error) {
+	// Changing the refresh settings must not reuse a token fetched with the old ones
+	key := strings.Join([]string{check.ID.ValueString(), check.TokenRefreshURL.ValueString(), check.TokenRefreshJSONPath.ValueString()}, "\x00")
+
+	c.tokens.mu.Lock()
+	cached, ok := c.tokens.tokens[key]
+	c.tokens.mu.Unlock()
+	if ok && time.Now().Add(tokenRefreshMargin).Before(cached.expiry) {
+		return cached.token, nil
+	}
+
+	cached, err := fetchToken(ctx, check, &http.Client{Timeout: timeout})
+	if err != nil {
+		return "", err
+	}
+
+	tflog.Debug(ctx, "Refreshed API check token", map[string]any{
+		"id":     check.ID.ValueString(),
+		"expiry": cached.expiry,
+	})
+
+	c.tokens.mu.Lock()
+	defer c.tokens.mu.Unlock()
+	if c.tokens.tokens == nil {
+		c.tokens.tokens = make(map[string]cachedToken)
+	}
+	c.tokens.tokens[key] = cached
+	return cached.token, nil
+}
+
+// fetchToken POSTs to token_refresh_url, sending auth_value as a bearer token
+// when set, and extracts the token at token_refresh_json_path from the JSON
+// response. The token expires after the response's expires_in seconds or, failing
+// that, at the exp claim of a JWT.
+func fetchToken(ctx context.Context, check *APICheck, httpClient *http.Client) (cachedToken, error) {
+	segments, err := parseJSONPath(check.TokenRefreshJSONPath.ValueString())
+	if err != nil {
+		return cachedToken{}, fmt.Errorf("invalid token_refresh_json_path: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, check.TokenRefreshURL.ValueString(), nil)
+	if err != nil {
+		return cachedToken{}, err
+	}
+	req.Header.Set("Accept", "application/json")
+	if !check.AuthValue.IsNull() {
+		req.Header.Set("Authorization", "Bearer "+check.AuthValue.ValueString())
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return cachedToken{}, err
+	}
+	defer resp.Body.Close()
+
+	body, err := readLimitedBody(resp.Body)
+	if err != nil {
+		return cachedToken{}, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return cachedToken{}, fmt.Errorf("token_refresh_url returned status %d", resp.StatusCode)
+	}
+
+	doc, err := decodeResponseJSON(body, false)
+	if err != nil {
+		return cachedToken{}, fmt.Errorf("token_refresh_url returned invalid JSON: %w", err)
+	}
+	matches := lookupJSONPath(doc, segments)
+	if len(matches) != 1 {
+		return cachedToken{}, fmt.Errorf("expected one value at %s in the token response, found %d", check.TokenRefreshJSONPath.ValueString(), len(matches))
+	}
+	token, ok := matches[0].(string)
+	if !ok || token == "" {
+		return cachedToken{}, fmt.Errorf("the value at %s in the token response is not a non-empty string", check.TokenRefreshJSONPath.ValueString())
+	}
+
+	cached := cachedToken{token: token, expiry: jwtExpiry(token)}
+	if fields, ok := doc.(map[string]any); ok {
+		if expiresIn, ok := fields["expires_in"].(json.Number); ok {
+			if seconds, err := expiresIn.Float64(); err == nil {
+				cached.expiry = time.Now().Add(time.Duration(seconds * float64(time.Second)))
+			}
+		}
+	}
+	return cached, nil
+}
+
+// jwtExpiry returns the time in the exp claim of a JWT, or the zero time if
+// token isn't a JWT or has no exp claim. The signature isn't verified; the
+// expiry is only used to decide when to refresh.
+func jwtExpiry(token string) time.Time {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return time.Time{}
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return time.Time{}
+	}
+
+	var claims struct {
+		Exp json.Number `json:"exp"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return time.Time{}
+	}
+	exp, err := strconv.ParseInt(claims.Exp.String(), 10, 64)
+	if err != nil {
+		return time.Time{}
+	}
+	return time.Unix(exp, 0)
+}
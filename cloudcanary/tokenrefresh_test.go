@@ -0,0 +1,227 @@
+package cloudcanary
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// tokenServer serves a token endpoint at /token, answering with respond for
+// the nth token requested, and an API at /api that requires a bearer token
+func tokenServer(t *testing.T, respond func(n int32) (int, string)) (*httptest.Server, *int32) {
+	t.Helper()
+	var issued int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/token":
+			if r.Method != http.MethodPost || r.Header.Get("Authorization") != "Bearer refresh-secret" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			status, body := respond(atomic.AddInt32(&issued, 1))
+			w.WriteHeader(status)
+			fmt.Fprint(w, body)
+		case "/api":
+			if !strings.HasPrefix(r.Header.Get("Authorization"), "Bearer ") {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			fmt.Fprint(w, `{"ok":true}`)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server, &issued
+}
+
+// unsignedJWT builds a JWT with the given exp claim and an empty signature,
+// enough for jwtExpiry to read
+func unsignedJWT(exp time.Time) string {
+	encode := base64.RawURLEncoding.EncodeToString
+	return encode([]byte(`{"alg":"none"}`)) + "." + encode([]byte(fmt.Sprintf(`{"exp":%d}`, exp.Unix()))) + "."
+}
+
+func TestProbeTokenRefreshCaching(t *testing.T) {
+	tests := []struct {
+		name        string
+		respond     func(n int32) (int, string)
+		wantFetches int32
+	}{
+		{
+			name: "expires_in far off is reused",
+			respond: func(n int32) (int, string) {
+				return 200, fmt.Sprintf(`{"data":{"token":"tok-%d"},"expires_in":3600}`, n)
+			},
+			wantFetches: 1,
+		},
+		{
+			name:        "expires_in within the refresh margin is refetched",
+			respond:     func(n int32) (int, string) { return 200, fmt.Sprintf(`{"data":{"token":"tok-%d"},"expires_in":10}`, n) },
+			wantFetches: 3,
+		},
+		{
+			name:        "unknown lifetime is used once",
+			respond:     func(n int32) (int, string) { return 200, fmt.Sprintf(`{"data":{"token":"tok-%d"}}`, n) },
+			wantFetches: 3,
+		},
+		{
+			name: "JWT exp claim far off is reused",
+			respond: func(n int32) (int, string) {
+				return 200, fmt.Sprintf(`{"data":{"token":%q}}`, unsignedJWT(time.Now().Add(time.Hour)))
+			},
+			wantFetches: 1,
+		},
+		{
+			name: "expired JWT is refetched",
+			respond: func(n int32) (int, string) {
+				return 200, fmt.Sprintf(`{"data":{"token":%q}}`, unsignedJWT(time.Now().Add(-time.Minute)))
+			},
+			wantFetches: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, issued := tokenServer(t, tt.respond)
+
+			ctx := context.Background()
+			c := newTestClient()
+			check := APICheck{
+				Name:                 types.StringValue(t.Name()),
+				Endpoint:             types.StringValue(server.URL + "/api"),
+				AuthType:             types.StringValue("token_refresh"),
+				AuthValue:            types.StringValue("refresh-secret"),
+				TokenRefreshURL:      types.StringValue(server.URL + "/token"),
+				TokenRefreshJSONPath: types.StringValue("$.data.token"),
+			}
+			if err := c.createAPICheck(ctx, &check); err != nil {
+				t.Fatalf("createAPICheck: %s", err)
+			}
+
+			for i := 0; i < 3; i++ {
+				result := c.probeAPICheck(ctx, &check)
+				if result.Status.ValueString() != "SUCCESS" {
+					t.Fatalf("run %d status = %s, want SUCCESS (failure reason %s)", i+1, result.Status.ValueString(), result.FailureReason)
+				}
+			}
+			if got := atomic.LoadInt32(issued); got != tt.wantFetches {
+				t.Errorf("token fetched %d times over three runs, want %d", got, tt.wantFetches)
+			}
+		})
+	}
+}
+
+func TestTokenRefreshDoesNotBlockOtherChecks(t *testing.T) {
+	// The slow token server holds its request until released
+	requested, release := make(chan struct{}), make(chan struct{})
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(requested)
+		<-release
+		fmt.Fprint(w, `{"access_token":"slow-token","expires_in":3600}`)
+	}))
+	defer slow.Close()
+	defer close(release)
+	fast := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"access_token":"fast-token","expires_in":3600}`)
+	}))
+	defer fast.Close()
+
+	ctx := context.Background()
+	c := newTestClient()
+	tokenCheck := func(id, url string) *APICheck {
+		return &APICheck{
+			ID:                   types.StringValue(id),
+			TokenRefreshURL:      types.StringValue(url),
+			TokenRefreshJSONPath: types.StringValue("$.access_token"),
+		}
+	}
+
+	go func() { _, _ = c.refreshedToken(ctx, tokenCheck("ac-slow", slow.URL), 10*time.Second) }()
+	<-requested
+
+	done := make(chan error, 1)
+	go func() {
+		token, err := c.refreshedToken(ctx, tokenCheck("ac-fast", fast.URL), 10*time.Second)
+		if err == nil && token != "fast-token" {
+			err = fmt.Errorf("token = %q, want fast-token", token)
+		}
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Error(err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Error("token refresh for one check waited on another check's token server")
+	}
+}
+
+func TestProbeTokenRefreshSendsToken(t *testing.T) {
+	var gotAuthorization atomic.Value
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/token" {
+			fmt.Fprint(w, `{"access_token":"fresh-token","expires_in":3600}`)
+			return
+		}
+		gotAuthorization.Store(r.Header.Get("Authorization"))
+	}))
+	defer server.Close()
+
+	result := runAPICheck(t, newTestClient(), APICheck{
+		Endpoint:             types.StringValue(server.URL + "/api"),
+		AuthType:             types.StringValue("token_refresh"),
+		TokenRefreshURL:      types.StringValue(server.URL + "/token"),
+		TokenRefreshJSONPath: types.StringValue("$.access_token"),
+	})
+	if result.Status.ValueString() != "SUCCESS" {
+		t.Fatalf("status = %s, want SUCCESS (failure reason %s)", result.Status.ValueString(), result.FailureReason)
+	}
+	if got := gotAuthorization.Load(); got != "Bearer fresh-token" {
+		t.Errorf("API received Authorization %q, want the refreshed token", got)
+	}
+}
+
+func TestProbeTokenRefreshFailures(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     int
+		body       string
+		authValue  types.String
+		wantReason string
+	}{
+		{name: "refresh rejected", status: 200, body: `{"data":{"token":"t"}}`, authValue: types.StringValue("wrong"), wantReason: "token_refresh_url returned status 401"},
+		{name: "server error", status: 500, body: `{}`, authValue: types.StringValue("refresh-secret"), wantReason: "token_refresh_url returned status 500"},
+		{name: "not JSON", status: 200, body: `token=t`, authValue: types.StringValue("refresh-secret"), wantReason: "invalid JSON"},
+		{name: "path missing", status: 200, body: `{"token":"t"}`, authValue: types.StringValue("refresh-secret"), wantReason: "expected one value at $.data.token"},
+		{name: "token not a string", status: 200, body: `{"data":{"token":42}}`, authValue: types.StringValue("refresh-secret"), wantReason: "not a non-empty string"},
+		{name: "empty token", status: 200, body: `{"data":{"token":""}}`, authValue: types.StringValue("refresh-secret"), wantReason: "not a non-empty string"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, _ := tokenServer(t, func(int32) (int, string) { return tt.status, tt.body })
+
+			result := runAPICheck(t, newTestClient(), APICheck{
+				Endpoint:             types.StringValue(server.URL + "/api"),
+				AuthType:             types.StringValue("token_refresh"),
+				AuthValue:            tt.authValue,
+				TokenRefreshURL:      types.StringValue(server.URL + "/token"),
+				TokenRefreshJSONPath: types.StringValue("$.data.token"),
+			})
+			if result.Status.ValueString() != "FAILURE" {
+				t.Fatalf("status = %s, want FAILURE", result.Status.ValueString())
+			}
+			if reason := result.FailureReason.ValueString(); !strings.HasPrefix(reason, "could not refresh token: ") || !strings.Contains(reason, tt.wantReason) {
+				t.Errorf("failure reason = %q, want a refresh failure containing %q", reason, tt.wantReason)
+			}
+		})
+	}
+}
@@ -0,0 +1,1265 @@
+package cloudcanary
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// checkIDPattern matches the IDs generated for HTTP (hc-), API (ac-), TCP (tc-) and WebSocket (wc-) checks
+var checkIDPattern = regexp.MustCompile(`^(hc|ac|tc|wc)-[0-9a-f]{16}$`)
+
+// hostnamePattern matches DNS hostnames made of dot-separated labels of letters, digits and hyphens
+var hostnamePattern = regexp.MustCompile(`^([a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?\.)*[a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?$`)
+
+// cookieNamePattern matches the token characters RFC 6265 allows in a cookie name
+var cookieNamePattern = regexp.MustCompile("^[!#$%&'*+.^_`|~0-9A-Za-z-]+$")
+
+// sha256Pattern matches a lowercase hex-encoded SHA-256 digest
+var sha256Pattern = regexp.MustCompile(`^[0-9a-f]{64}$`)
+
+// validCheckID returns a validator which ensures the configured string is a check ID
+func validCheckID() validator.String {
+	return stringvalidator.RegexMatches(checkIDPattern, "must be a CloudCanary check ID such as hc-0123456789abcdef")
+}
+
+// validSHA256 returns a validator which ensures the configured string is a lowercase hex-encoded SHA-256 digest
+func validSHA256() validator.String {
+	return stringvalidator.RegexMatches(sha256Pattern, "must be a lowercase hex-encoded SHA-256 digest")
+}
+
+// validCookieName returns a validator which ensures the configured string is a valid cookie name
+func validCookieName() validator.String {
+	return stringvalidator.RegexMatches(cookieNamePattern, "must be a cookie name made of letters, digits and !#$%&'*+-.^_`|~")
+}
+
+// timezoneValidator validates that a string attribute names a loadable time zone
+type timezoneValidator struct{}
+
+// validTimezone returns a validator which ensures the configured string loads with time.LoadLocation
+func validTimezone() validator.String {
+	return timezoneValidator{}
+}
+
+// Description returns a plain text description of the validator's behavior
+func (v timezoneValidator) Description(_ context.Context) string {
+	return "value must be an IANA time zone name such as Europe/Berlin"
+}
+
+// MarkdownDescription returns a markdown formatted description of the validator's behavior
+func (v timezoneValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateString checks that the configured value loads as a time zone
+func (v timezoneValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := time.LoadLocation(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Time Zone",
+			fmt.Sprintf("The value could not be loaded as a time zone: %s", err),
+		)
+	}
+}
+
+// charsetValidator validates that a string attribute names a supported character set
+type charsetValidator struct{}
+
+// validCharset returns a validator which ensures the configured string is a known charset label
+func validCharset() validator.String {
+	return charsetValidator{}
+}
+
+// Description returns a plain text description of the validator's behavior
+func (v charsetValidator) Description(_ context.Context) string {
+	return "value must be a supported charset such as iso-8859-1 or utf-16le"
+}
+
+// MarkdownDescription returns a markdown formatted description of the validator's behavior
+func (v charsetValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateString checks that the configured value is a known charset label
+func (v charsetValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := lookupCharset(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Charset",
+			fmt.Sprintf("The value is not a supported charset: %s", err),
+		)
+	}
+}
+
+// cipherSuiteValidator validates that a string attribute names a TLS cipher suite Go implements
+type cipherSuiteValidator struct{}
+
+// validCipherSuite returns a validator which ensures the configured string is a known cipher suite name
+func validCipherSuite() validator.String {
+	return cipherSuiteValidator{}
+}
+
+// Description returns a plain text description of the validator's behavior
+func (v cipherSuiteValidator) Description(_ context.Context) string {
+	return "value must be a TLS cipher suite name such as TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256"
+}
+
+// MarkdownDescription returns a markdown formatted description of the validator's behavior
+func (v cipherSuiteValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateString checks that the configured value is a cipher suite listed by crypto/tls
+func (v cipherSuiteValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := lookupCipherSuite(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Cipher Suite",
+			fmt.Sprintf("The value is not a supported TLS cipher suite: %s", err),
+		)
+	}
+}
+
+// durationValidator validates that a string attribute is a positive Go duration
+type durationValidator struct{}
+
+// validDuration returns a validator which ensures the configured string parses with time.ParseDuration
+func validDuration() validator.String {
+	return durationValidator{}
+}
+
+// Description returns a plain text description of the validator's behavior
+func (v durationValidator) Description(_ context.Context) string {
+	return "value must be a positive duration such as 30s, 10m or 1h"
+}
+
+// MarkdownDescription returns a markdown formatted description of the validator's behavior
+func (v durationValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateString checks that the configured value parses as a positive duration
+func (v durationValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	d, err := time.ParseDuration(req.ConfigValue.ValueString())
+	if err == nil && d <= 0 {
+		err = fmt.Errorf("duration must be positive")
+	}
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Duration",
+			fmt.Sprintf("The value must be a duration such as 30s, 10m or 1h: %s", err),
+		)
+	}
+}
+
+// httpDateValidator validates that a string attribute is an HTTP date
+type httpDateValidator struct{}
+
+// validHTTPDate returns a validator which ensures the configured string is an IMF-fixdate
+func validHTTPDate() validator.String {
+	return httpDateValidator{}
+}
+
+// Description returns a plain text description of the validator's behavior
+func (v httpDateValidator) Description(_ context.Context) string {
+	return "value must be an HTTP date such as Wed, 21 Oct 2015 07:28:00 GMT"
+}
+
+// MarkdownDescription returns a markdown formatted description of the validator's behavior
+func (v httpDateValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateString checks that the configured value parses in the HTTP date format
+func (v httpDateValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := time.Parse(http.TimeFormat, req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid HTTP Date",
+			fmt.Sprintf("The value must be an HTTP date such as %q: %s", "Wed, 21 Oct 2015 07:28:00 GMT", err),
+		)
+	}
+}
+
+// timestampValidator validates that a string attribute is an RFC3339 timestamp
+type timestampValidator struct{}
+
+// validTimestamp returns a validator which ensures the configured string is an RFC3339 timestamp
+func validTimestamp() validator.String {
+	return timestampValidator{}
+}
+
+// Description returns a plain text description of the validator's behavior
+func (v timestampValidator) Description(_ context.Context) string {
+	return "value must be an RFC3339 timestamp such as 2024-01-02T15:04:05Z"
+}
+
+// MarkdownDescription returns a markdown formatted description of the validator's behavior
+func (v timestampValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateString checks that the configured value parses as an RFC3339 timestamp
+func (v timestampValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := time.Parse(time.RFC3339, req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Timestamp",
+			fmt.Sprintf("The value must be an RFC3339 timestamp such as %q: %s", "2024-01-02T15:04:05Z", err),
+		)
+	}
+}
+
+// acceptValidator validates that a string attribute is an Accept header value
+type acceptValidator struct{}
+
+// validAccept returns a validator which ensures the configured string is a list of media ranges
+func validAccept() validator.String {
+	return acceptValidator{}
+}
+
+// Description returns a plain text description of the validator's behavior
+func (v acceptValidator) Description(_ context.Context) string {
+	return "value must be an Accept header value such as application/json or text/html, */*;q=0.8"
+}
+
+// MarkdownDescription returns a markdown formatted description of the validator's behavior
+func (v acceptValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateString checks that the configured value parses as media ranges, at least one acceptable
+func (v acceptValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := acceptedMediaRanges(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Accept Header",
+			fmt.Sprintf("The value must be a comma-separated list of media ranges such as %q: %s", "application/json, */*;q=0.8", err),
+		)
+	}
+}
+
+// resolverAddressValidator validates that a string attribute is a DNS server address
+type resolverAddressValidator struct{}
+
+// validResolverAddress returns a validator which ensures the configured string is an IP:port pair
+func validResolverAddress() validator.String {
+	return resolverAddressValidator{}
+}
+
+// Description returns a plain text description of the validator's behavior
+func (v resolverAddressValidator) Description(_ context.Context) string {
+	return "value must be an IP address and port such as 8.8.8.8:53 or [2001:4860:4860::8888]:53"
+}
+
+// MarkdownDescription returns a markdown formatted description of the validator's behavior
+func (v resolverAddressValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateString checks that the configured value splits into an IP address and a port
+func (v resolverAddressValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	host, port, err := net.SplitHostPort(req.ConfigValue.ValueString())
+	if err == nil && net.ParseIP(host) == nil {
+		err = fmt.Errorf("%q is not an IP address", host)
+	}
+	if err == nil {
+		if n, perr := strconv.Atoi(port); perr != nil || n < 1 || n > 65535 {
+			err = fmt.Errorf("%q is not a valid port", port)
+		}
+	}
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid DNS Resolver",
+			fmt.Sprintf("The value must be an IP address and port such as 8.8.8.8:53: %s", err),
+		)
+	}
+}
+
+// ipAddressValidator validates that a string attribute is an IPv4 or IPv6 address
+type ipAddressValidator struct{}
+
+// validIPAddress returns a validator which ensures the configured string parses as an IP address
+func validIPAddress() validator.String {
+	return ipAddressValidator{}
+}
+
+// Description returns a plain text description of the validator's behavior
+func (v ipAddressValidator) Description(_ context.Context) string {
+	return "value must be an IP address such as 192.0.2.10 or 2001:db8::10"
+}
+
+// MarkdownDescription returns a markdown formatted description of the validator's behavior
+func (v ipAddressValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateString checks that the configured value parses as an IP address
+func (v ipAddressValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if net.ParseIP(req.ConfigValue.ValueString()) == nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid IP Address",
+			fmt.Sprintf("%q is not an IP address such as 192.0.2.10 or 2001:db8::10", req.ConfigValue.ValueString()),
+		)
+	}
+}
+
+// httpURLValidator validates that a string attribute is an http:// or https:// URL
+type httpURLValidator struct{}
+
+// validHTTPURL returns a validator which ensures the configured string is an HTTP URL
+func validHTTPURL() validator.String {
+	return httpURLValidator{}
+}
+
+// Description returns a plain text description of the validator's behavior
+func (v httpURLValidator) Description(_ context.Context) string {
+	return "value must be an http:// or https:// URL with a host"
+}
+
+// MarkdownDescription returns a markdown formatted description of the validator's behavior
+func (v httpURLValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateString checks that the configured value parses as a URL with an http or https scheme
+func (v httpURLValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	u, err := url.Parse(req.ConfigValue.ValueString())
+	if err == nil && u.Scheme != "http" && u.Scheme != "https" {
+		err = fmt.Errorf("scheme must be http or https, got %q", u.Scheme)
+	}
+	if err == nil && u.Host == "" {
+		err = fmt.Errorf("URL has no host")
+	}
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid HTTP URL",
+			fmt.Sprintf("The value must be an http:// or https:// URL: %s", err),
+		)
+	}
+}
+
+// webSocketURLValidator validates that a string attribute is a ws:// or wss:// URL
+type webSocketURLValidator struct{}
+
+// validWebSocketURL returns a validator which ensures the configured string is a WebSocket URL
+func validWebSocketURL() validator.String {
+	return webSocketURLValidator{}
+}
+
+// Description returns a plain text description of the validator's behavior
+func (v webSocketURLValidator) Description(_ context.Context) string {
+	return "value must be a ws:// or wss:// URL with a host"
+}
+
+// MarkdownDescription returns a markdown formatted description of the validator's behavior
+func (v webSocketURLValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateString checks that the configured value parses as a URL with a ws or wss scheme
+func (v webSocketURLValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	u, err := url.Parse(req.ConfigValue.ValueString())
+	if err == nil && u.Scheme != "ws" && u.Scheme != "wss" {
+		err = fmt.Errorf("scheme must be ws or wss, got %q", u.Scheme)
+	}
+	if err == nil && u.Host == "" {
+		err = fmt.Errorf("URL has no host")
+	}
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid WebSocket URL",
+			fmt.Sprintf("The value must be a ws:// or wss:// URL: %s", err),
+		)
+	}
+}
+
+// jsonStringValidator validates that a string attribute contains a JSON document
+type jsonStringValidator struct{}
+
+// validJSON returns a validator which ensures the configured string parses as JSON
+func validJSON() validator.String {
+	return jsonStringValidator{}
+}
+
+// Description returns a plain text description of the validator's behavior
+func (v jsonStringValidator) Description(_ context.Context) string {
+	return "value must be a valid JSON document"
+}
+
+// MarkdownDescription returns a markdown formatted description of the validator's behavior
+func (v jsonStringValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateString checks that the configured value parses as JSON
+func (v jsonStringValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := decodeJSON(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid JSON",
+			fmt.Sprintf("The value could not be parsed as JSON: %s", err),
+		)
+	}
+}
+
+// confirmedValidator validates that a confirmation attribute is set to true
+type confirmedValidator struct{}
+
+// validConfirmed returns a validator which ensures the configured bool is true
+func validConfirmed() validator.Bool {
+	return confirmedValidator{}
+}
+
+// Description returns a plain text description of the validator's behavior
+func (v confirmedValidator) Description(_ context.Context) string {
+	return "value must be true"
+}
+
+// MarkdownDescription returns a markdown formatted description of the validator's behavior
+func (v confirmedValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateBool checks that the configured value is true
+func (v confirmedValidator) ValidateBool(_ context.Context, req validator.BoolRequest, resp *validator.BoolResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if !req.ConfigValue.ValueBool() {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Confirmation Required",
+			"This attribute must be set to true to acknowledge that the operation is destructive.",
+		)
+	}
+}
+
+// jsonPathValidator validates that a string attribute is a supported JSONPath expression
+type jsonPathValidator struct{}
+
+// validJSONPath returns a validator which ensures the configured string parses
+// as a JSONPath expression. An empty string is allowed and left to the
+// attribute to interpret.
+func validJSONPath() validator.String {
+	return jsonPathValidator{}
+}
+
+// Description returns a plain text description of the validator's behavior
+func (v jsonPathValidator) Description(_ context.Context) string {
+	return "value must be empty or a JSONPath expression such as $.errors"
+}
+
+// MarkdownDescription returns a markdown formatted description of the validator's behavior
+func (v jsonPathValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateString checks that the configured value parses as a JSONPath expression
+func (v jsonPathValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() || req.ConfigValue.ValueString() == "" {
+		return
+	}
+
+	if _, err := parseJSONPath(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid JSONPath Expression",
+			fmt.Sprintf("The value could not be parsed as a JSONPath expression: %s", err),
+		)
+	}
+}
+
+// jsonPathListValidator validates that every element of a list attribute is a supported JSONPath expression
+type jsonPathListValidator struct{}
+
+// validJSONPaths returns a validator which ensures each list element parses as a JSONPath expression
+func validJSONPaths() validator.List {
+	return jsonPathListValidator{}
+}
+
+// Description returns a plain text description of the validator's behavior
+func (v jsonPathListValidator) Description(_ context.Context) string {
+	return "each value must be a JSONPath expression such as $.data.items[0].id"
+}
+
+// MarkdownDescription returns a markdown formatted description of the validator's behavior
+func (v jsonPathListValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateList checks that each configured element parses as a JSONPath expression
+func (v jsonPathListValidator) ValidateList(_ context.Context, req validator.ListRequest, resp *validator.ListResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	for _, p := range listStrings(req.ConfigValue) {
+		if _, err := parseJSONPath(p); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				req.Path,
+				"Invalid JSONPath Expression",
+				fmt.Sprintf("The value could not be parsed as a JSONPath expression: %s", err),
+			)
+		}
+	}
+}
+
+// regionQuorumValidator ensures region_quorum is between 1 and the number of configured regions
+type regionQuorumValidator struct{}
+
+// Description returns a plain text description of the validator's behavior
+func (v regionQuorumValidator) Description(_ context.Context) string {
+	return "region_quorum must be between 1 and the number of configured regions and private locations"
+}
+
+// MarkdownDescription returns a markdown formatted description of the validator's behavior
+func (v regionQuorumValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateResource checks region_quorum against the configured regions and
+// private locations, each of which votes on the result
+func (v regionQuorumValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var quorum types.Int64
+	var regions, privateLocations types.List
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("region_quorum"), &quorum)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("regions"), &regions)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("private_location_ids"), &privateLocations)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if quorum.IsNull() || quorum.IsUnknown() || regions.IsUnknown() || privateLocations.IsUnknown() {
+		return
+	}
+
+	locationCount := len(regions.Elements()) + len(privateLocations.Elements())
+	if quorum.ValueInt64() < 1 || quorum.ValueInt64() > int64(locationCount) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("region_quorum"),
+			"Invalid Region Quorum",
+			fmt.Sprintf("region_quorum must be between 1 and the number of configured regions and private locations (%d), got: %d", locationCount, quorum.ValueInt64()),
+		)
+	}
+}
+
+// responseSizeRangeValidator ensures min_response_size does not exceed max_response_size
+type responseSizeRangeValidator struct{}
+
+// Description returns a plain text description of the validator's behavior
+func (v responseSizeRangeValidator) Description(_ context.Context) string {
+	return "min_response_size must be less than or equal to max_response_size"
+}
+
+// MarkdownDescription returns a markdown formatted description of the validator's behavior
+func (v responseSizeRangeValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateResource compares the configured response size bounds
+func (v responseSizeRangeValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var minSize, maxSize types.Int64
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("min_response_size"), &minSize)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("max_response_size"), &maxSize)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if minSize.IsNull() || minSize.IsUnknown() || maxSize.IsNull() || maxSize.IsUnknown() {
+		return
+	}
+
+	if minSize.ValueInt64() > maxSize.ValueInt64() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("min_response_size"),
+			"Invalid Response Size Range",
+			fmt.Sprintf("min_response_size (%d) must be less than or equal to max_response_size (%d)", minSize.ValueInt64(), maxSize.ValueInt64()),
+		)
+	}
+}
+
+// timeoutBudgetValidator ensures connect_timeout and read_timeout fit within
+// the overall timeout, taking defaultTimeout when timeout is unset
+type timeoutBudgetValidator struct {
+	defaultTimeout int64
+}
+
+// Description returns a plain text description of the validator's behavior
+func (v timeoutBudgetValidator) Description(_ context.Context) string {
+	return "connect_timeout plus read_timeout must not exceed timeout"
+}
+
+// MarkdownDescription returns a markdown formatted description of the validator's behavior
+func (v timeoutBudgetValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateResource compares the phase timeouts with the overall timeout
+func (v timeoutBudgetValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var timeout, connectTimeout, readTimeout types.Int64
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("timeout"), &timeout)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("connect_timeout"), &connectTimeout)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("read_timeout"), &readTimeout)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if timeout.IsUnknown() || connectTimeout.IsUnknown() || readTimeout.IsUnknown() {
+		return
+	}
+
+	overall := v.defaultTimeout
+	if !timeout.IsNull() {
+		overall = timeout.ValueInt64()
+	}
+
+	// An unset phase timeout takes no part of the budget
+	if sum := connectTimeout.ValueInt64() + readTimeout.ValueInt64(); sum > overall {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("connect_timeout"),
+			"Invalid Timeouts",
+			fmt.Sprintf("connect_timeout plus read_timeout (%ds) must not exceed timeout (%ds)", sum, overall),
+		)
+	}
+}
+
+// redirectSuccessValidator ensures redirect_status_is_success is only enabled when redirects are not followed
+type redirectSuccessValidator struct{}
+
+// Description returns a plain text description of the validator's behavior
+func (v redirectSuccessValidator) Description(_ context.Context) string {
+	return "redirect_status_is_success requires follow_redirects to be false"
+}
+
+// MarkdownDescription returns a markdown formatted description of the validator's behavior
+func (v redirectSuccessValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateResource checks redirect_status_is_success against follow_redirects
+func (v redirectSuccessValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var redirectIsSuccess, followRedirects types.Bool
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("redirect_status_is_success"), &redirectIsSuccess)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("follow_redirects"), &followRedirects)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !redirectIsSuccess.ValueBool() || followRedirects.IsUnknown() {
+		return
+	}
+
+	// follow_redirects defaults to true, so it has to be turned off explicitly
+	if followRedirects.IsNull() || followRedirects.ValueBool() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("redirect_status_is_success"),
+			"Invalid Redirect Handling",
+			"redirect_status_is_success can only be enabled when follow_redirects is set to false, since followed redirects never produce a 3xx result.",
+		)
+	}
+}
+
+// redirectLocationValidator ensures expected_redirect_location is only set when redirects are not followed
+type redirectLocationValidator struct{}
+
+// Description returns a plain text description of the validator's behavior
+func (v redirectLocationValidator) Description(_ context.Context) string {
+	return "expected_redirect_location requires follow_redirects to be false"
+}
+
+// MarkdownDescription returns a markdown formatted description of the validator's behavior
+func (v redirectLocationValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateResource checks expected_redirect_location against follow_redirects
+func (v redirectLocationValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var location types.String
+	var followRedirects types.Bool
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("expected_redirect_location"), &location)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("follow_redirects"), &followRedirects)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if location.IsNull() || followRedirects.IsUnknown() {
+		return
+	}
+
+	// follow_redirects defaults to true, so it has to be turned off explicitly
+	if followRedirects.IsNull() || followRedirects.ValueBool() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("expected_redirect_location"),
+			"Invalid Redirect Handling",
+			"expected_redirect_location can only be set when follow_redirects is set to false, since a followed redirect's Location header is never checked.",
+		)
+	}
+}
+
+// acceptContentTypeValidator ensures expected_content_type is a media type accept allows
+type acceptContentTypeValidator struct{}
+
+// Description returns a plain text description of the validator's behavior
+func (v acceptContentTypeValidator) Description(_ context.Context) string {
+	return "expected_content_type must be acceptable under accept"
+}
+
+// MarkdownDescription returns a markdown formatted description of the validator's behavior
+func (v acceptContentTypeValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateResource checks expected_content_type against the media ranges in accept
+func (v acceptContentTypeValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var accept, expected types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("accept"), &accept)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("expected_content_type"), &expected)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if accept.IsNull() || accept.IsUnknown() || expected.IsNull() || expected.IsUnknown() {
+		return
+	}
+
+	// An invalid accept is already reported by its own validator
+	ranges, err := acceptedMediaRanges(accept.ValueString())
+	if err != nil {
+		return
+	}
+
+	// No response could pass both checks
+	if want := mediaType(expected.ValueString()); !mediaTypeAccepted(ranges, want) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("expected_content_type"),
+			"Unacceptable Content Type",
+			fmt.Sprintf("expected_content_type %q is not one of the media types accept (%q) allows, so the check could never pass.", want, accept.ValueString()),
+		)
+	}
+}
+
+// jwtAuthValidator ensures the jwt_* attributes are complete and only used with auth_type jwt
+type jwtAuthValidator struct{}
+
+// Description returns a plain text description of the validator's behavior
+func (v jwtAuthValidator) Description(_ context.Context) string {
+	return "jwt_secret is required when auth_type is jwt, and jwt_* attributes are only valid with auth_type jwt"
+}
+
+// MarkdownDescription returns a markdown formatted description of the validator's behavior
+func (v jwtAuthValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateResource checks the JWT settings against the configured auth_type
+func (v jwtAuthValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var authType, secret, algorithm types.String
+	var claims types.Map
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("auth_type"), &authType)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("jwt_secret"), &secret)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("jwt_claims"), &claims)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("jwt_algorithm"), &algorithm)...)
+	if resp.Diagnostics.HasError() || authType.IsUnknown() {
+		return
+	}
+
+	if authType.ValueString() != "jwt" {
+		jwtAttributes := []struct {
+			name  string
+			value attr.Value
+		}{{"jwt_secret", secret}, {"jwt_claims", claims}, {"jwt_algorithm", algorithm}}
+		for _, a := range jwtAttributes {
+			if !a.value.IsNull() {
+				resp.Diagnostics.AddAttributeError(
+					path.Root(a.name),
+					"Invalid JWT Configuration",
+					fmt.Sprintf("%s can only be set when auth_type is jwt", a.name),
+				)
+			}
+		}
+		return
+	}
+
+	if secret.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("jwt_secret"),
+			"Missing JWT Secret",
+			"jwt_secret is required when auth_type is jwt",
+		)
+	}
+
+	if !claims.IsUnknown() {
+		for name := range mapStrings(claims) {
+			for _, reserved := range jwtReservedClaims {
+				if name == reserved {
+					resp.Diagnostics.AddAttributeError(
+						path.Root("jwt_claims").AtMapKey(name),
+						"Reserved JWT Claim",
+						fmt.Sprintf("The %s claim is set on every probe and can't be configured", name),
+					)
+				}
+			}
+		}
+	}
+
+	// An RS256 key can be checked as soon as it is known
+	if algorithm.ValueString() == "RS256" && !secret.IsNull() && !secret.IsUnknown() {
+		if _, err := parseRSAPrivateKey(secret.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("jwt_secret"),
+				"Invalid JWT Secret",
+				fmt.Sprintf("The RS256 signing key could not be used: %s", err),
+			)
+		}
+	}
+}
+
+// sigV4AuthValidator ensures the aws_* attributes are complete and only used with auth_type aws_sigv4
+type sigV4AuthValidator struct{}
+
+// sigV4Attributes are the attributes that configure aws_sigv4 signing, all of which are required with it
+var sigV4Attributes = []string{"aws_access_key_id", "aws_secret_access_key", "aws_region", "aws_service"}
+
+// Description returns a plain text description of the validator's behavior
+func (v sigV4AuthValidator) Description(_ context.Context) string {
+	return "aws_access_key_id, aws_secret_access_key, aws_region and aws_service are required when auth_type is aws_sigv4, and only valid with it"
+}
+
+// MarkdownDescription returns a markdown formatted description of the validator's behavior
+func (v sigV4AuthValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateResource checks the AWS signing settings against the configured auth_type
+func (v sigV4AuthValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var authType types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("auth_type"), &authType)...)
+	values := make([]types.String, len(sigV4Attributes))
+	for i, name := range sigV4Attributes {
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(name), &values[i])...)
+	}
+	if resp.Diagnostics.HasError() || authType.IsUnknown() {
+		return
+	}
+
+	selected := authType.ValueString() == "aws_sigv4"
+	for i, name := range sigV4Attributes {
+		switch {
+		case selected && values[i].IsNull():
+			resp.Diagnostics.AddAttributeError(
+				path.Root(name),
+				"Missing AWS SigV4 Configuration",
+				fmt.Sprintf("%s is required when auth_type is aws_sigv4", name),
+			)
+		case !selected && !values[i].IsNull():
+			resp.Diagnostics.AddAttributeError(
+				path.Root(name),
+				"Invalid AWS SigV4 Configuration",
+				fmt.Sprintf("%s can only be set when auth_type is aws_sigv4", name),
+			)
+		}
+	}
+}
+
+// tokenRefreshAuthValidator ensures the token_refresh_* attributes are complete and only used with auth_type token_refresh
+type tokenRefreshAuthValidator struct{}
+
+// tokenRefreshAttributes are the attributes that configure token_refresh, all of which are required with it
+var tokenRefreshAttributes = []string{"token_refresh_url", "token_refresh_json_path"}
+
+// Description returns a plain text description of the validator's behavior
+func (v tokenRefreshAuthValidator) Description(_ context.Context) string {
+	return "token_refresh_url and token_refresh_json_path are required when auth_type is token_refresh, and only valid with it"
+}
+
+// MarkdownDescription returns a markdown formatted description of the validator's behavior
+func (v tokenRefreshAuthValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateResource checks the token refresh settings against the configured auth_type
+func (v tokenRefreshAuthValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var authType types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("auth_type"), &authType)...)
+	values := make([]types.String, len(tokenRefreshAttributes))
+	for i, name := range tokenRefreshAttributes {
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(name), &values[i])...)
+	}
+	if resp.Diagnostics.HasError() || authType.IsUnknown() {
+		return
+	}
+
+	selected := authType.ValueString() == "token_refresh"
+	for i, name := range tokenRefreshAttributes {
+		switch {
+		case selected && values[i].IsNull():
+			resp.Diagnostics.AddAttributeError(
+				path.Root(name),
+				"Missing Token Refresh Configuration",
+				fmt.Sprintf("%s is required when auth_type is token_refresh", name),
+			)
+		case !selected && !values[i].IsNull():
+			resp.Diagnostics.AddAttributeError(
+				path.Root(name),
+				"Invalid Token Refresh Configuration",
+				fmt.Sprintf("%s can only be set when auth_type is token_refresh", name),
+			)
+		}
+	}
+}
+
+// jitterValidator ensures jitter_seconds is less than the check interval
+type jitterValidator struct {
+	// defaultInterval is the interval the API uses when none is configured
+	defaultInterval int64
+}
+
+// Description returns a plain text description of the validator's behavior
+func (v jitterValidator) Description(_ context.Context) string {
+	return "jitter_seconds must be less than interval"
+}
+
+// MarkdownDescription returns a markdown formatted description of the validator's behavior
+func (v jitterValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateResource compares jitter_seconds against the configured or default interval
+func (v jitterValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var jitter, interval types.Int64
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("jitter_seconds"), &jitter)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("interval"), &interval)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if jitter.IsNull() || jitter.IsUnknown() || interval.IsUnknown() {
+		return
+	}
+
+	intervalSeconds := v.defaultInterval
+	if !interval.IsNull() {
+		intervalSeconds = interval.ValueInt64()
+	}
+
+	if jitter.ValueInt64() >= intervalSeconds {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("jitter_seconds"),
+			"Invalid Jitter",
+			fmt.Sprintf("jitter_seconds (%d) must be less than the check interval (%d seconds)", jitter.ValueInt64(), intervalSeconds),
+		)
+	}
+}
+
+// priorityMinIntervals is the shortest interval, in seconds, each priority tier may run at
+var priorityMinIntervals = map[string]int64{
+	"low":    300,
+	"normal": 60,
+	"high":   10,
+}
+
+// priorityIntervalValidator ensures the check interval is allowed by its
+// priority tier. Checks without a priority keep the interval they have always
+// been allowed, so the tier minimum only applies once priority is set.
+type priorityIntervalValidator struct {
+	// defaultInterval is the interval the API uses when none is configured
+	defaultInterval int64
+}
+
+// Description returns a plain text description of the validator's behavior
+func (v priorityIntervalValidator) Description(_ context.Context) string {
+	return "interval must be at least the minimum interval of the priority tier"
+}
+
+// MarkdownDescription returns a markdown formatted description of the validator's behavior
+func (v priorityIntervalValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateResource compares the configured or default interval against the tier minimum
+func (v priorityIntervalValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var priority types.String
+	var interval types.Int64
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("priority"), &priority)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("interval"), &interval)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if priority.IsNull() || priority.IsUnknown() || interval.IsUnknown() {
+		return
+	}
+
+	tier := priority.ValueString()
+	minInterval, ok := priorityMinIntervals[tier]
+	if !ok {
+		// Unknown tiers are reported by the attribute validator
+		return
+	}
+
+	intervalSeconds := v.defaultInterval
+	if !interval.IsNull() {
+		intervalSeconds = interval.ValueInt64()
+	}
+
+	if intervalSeconds < minInterval {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("interval"),
+			"Interval Too Low For Priority",
+			fmt.Sprintf("The %s priority tier requires an interval of at least %d seconds, but the interval is %d seconds. "+
+				"Raise interval or choose a higher priority.", tier, minInterval, intervalSeconds),
+		)
+	}
+}
+
+// payloadEncodingValidator ensures TCP payloads decode with the configured payload_encoding
+type payloadEncodingValidator struct{}
+
+// Description returns a plain text description of the validator's behavior
+func (v payloadEncodingValidator) Description(_ context.Context) string {
+	return "send_payload and expected_payload must be valid for the configured payload_encoding"
+}
+
+// MarkdownDescription returns a markdown formatted description of the validator's behavior
+func (v payloadEncodingValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateResource decodes each configured payload with the configured encoding
+func (v payloadEncodingValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var encoding types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("payload_encoding"), &encoding)...)
+	if resp.Diagnostics.HasError() || encoding.IsUnknown() {
+		return
+	}
+
+	for _, name := range []string{"send_payload", "expected_payload"} {
+		var payload types.String
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(name), &payload)...)
+		if payload.IsNull() || payload.IsUnknown() {
+			continue
+		}
+
+		if _, err := decodePayload(payload.ValueString(), encoding.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root(name),
+				"Invalid Payload",
+				fmt.Sprintf("The payload could not be decoded as %s: %s", encoding.ValueString(), err),
+			)
+		}
+	}
+}
+
+// jsonPathMapValidator validates that every value of a map attribute is a supported JSONPath expression
+type jsonPathMapValidator struct{}
+
+// validJSONPathValues returns a validator which ensures each map value parses as a JSONPath expression
+func validJSONPathValues() validator.Map {
+	return jsonPathMapValidator{}
+}
+
+// Description returns a plain text description of the validator's behavior
+func (v jsonPathMapValidator) Description(_ context.Context) string {
+	return "each value must be a JSONPath expression such as $.data.items[0].id"
+}
+
+// MarkdownDescription returns a markdown formatted description of the validator's behavior
+func (v jsonPathMapValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateMap checks that each configured value parses as a JSONPath expression
+func (v jsonPathMapValidator) ValidateMap(_ context.Context, req validator.MapRequest, resp *validator.MapResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	for name, p := range mapStrings(req.ConfigValue) {
+		if _, err := parseJSONPath(p); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				req.Path.AtMapKey(name),
+				"Invalid JSONPath Expression",
+				fmt.Sprintf("The value could not be parsed as a JSONPath expression: %s", err),
+			)
+		}
+	}
+}
+
+// filePathMapValidator validates that every value of a map attribute names an existing regular file
+type filePathMapValidator struct{}
+
+// validFilePaths returns a validator which ensures each map value is the path of a readable file
+func validFilePaths() validator.Map {
+	return filePathMapValidator{}
+}
+
+// Description returns a plain text description of the validator's behavior
+func (v filePathMapValidator) Description(_ context.Context) string {
+	return "each value must be the path of an existing file"
+}
+
+// MarkdownDescription returns a markdown formatted description of the validator's behavior
+func (v filePathMapValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateMap checks that each configured value is an existing regular file
+func (v filePathMapValidator) ValidateMap(_ context.Context, req validator.MapRequest, resp *validator.MapResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	for name, filePath := range mapStrings(req.ConfigValue) {
+		info, err := os.Stat(filePath)
+		if err == nil && !info.Mode().IsRegular() {
+			err = fmt.Errorf("%s is not a regular file", filePath)
+		}
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				req.Path.AtMapKey(name),
+				"Invalid File Path",
+				fmt.Sprintf("The form file could not be used: %s", err),
+			)
+		}
+	}
+}
+
+// expectedPatternValidator validates that an expected value prefixed with ~ is a regular expression that compiles
+type expectedPatternValidator struct{}
+
+// validExpectedPattern returns a validator which ensures a ~ prefixed value is a valid regular expression
+func validExpectedPattern() validator.String {
+	return expectedPatternValidator{}
+}
+
+// Description returns a plain text description of the validator's behavior
+func (v expectedPatternValidator) Description(_ context.Context) string {
+	return "a value prefixed with ~ must be a valid regular expression"
+}
+
+// MarkdownDescription returns a markdown formatted description of the validator's behavior
+func (v expectedPatternValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateString checks that a regular expression value compiles
+func (v expectedPatternValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if pattern, ok := headerPattern(req.ConfigValue.ValueString()); ok {
+		if _, err := regexp.Compile(pattern); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				req.Path,
+				"Invalid Pattern",
+				fmt.Sprintf("The value could not be parsed as a regular expression: %s", err),
+			)
+		}
+	}
+}
+
+// headerExpectationsValidator validates that every regular expression in an expected_headers map compiles
+type headerExpectationsValidator struct{}
+
+// validHeaderExpectations returns a validator which ensures each ~ prefixed map value is a valid regular expression
+func validHeaderExpectations() validator.Map {
+	return headerExpectationsValidator{}
+}
+
+// Description returns a plain text description of the validator's behavior
+func (v headerExpectationsValidator) Description(_ context.Context) string {
+	return "each value prefixed with ~ must be a valid regular expression"
+}
+
+// MarkdownDescription returns a markdown formatted description of the validator's behavior
+func (v headerExpectationsValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateMap checks that each regular expression value compiles
+func (v headerExpectationsValidator) ValidateMap(_ context.Context, req validator.MapRequest, resp *validator.MapResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	for name, expected := range mapStrings(req.ConfigValue) {
+		if pattern, ok := headerPattern(expected); ok {
+			if _, err := regexp.Compile(pattern); err != nil {
+				resp.Diagnostics.AddAttributeError(
+					req.Path.AtMapKey(name),
+					"Invalid Header Pattern",
+					fmt.Sprintf("The value could not be parsed as a regular expression: %s", err),
+				)
+			}
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package cloudcanary
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// resourceConfig builds the configuration of r with the given attributes set
+// and every other attribute null
+func resourceConfig(t *testing.T, r resource.Resource, attrs map[string]tftypes.Value) tfsdk.Config {
+	t.Helper()
+	ctx := context.Background()
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	values := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+	for name, typ := range objectType.AttributeTypes {
+		values[name] = tftypes.NewValue(typ, nil)
+	}
+	for name, value := range attrs {
+		if _, ok := values[name]; !ok {
+			t.Fatalf("schema has no attribute %q", name)
+		}
+		values[name] = value
+	}
+
+	return tfsdk.Config{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, values)}
+}
+
+func TestPriorityIntervalValidator(t *testing.T) {
+	unknownString := tftypes.NewValue(tftypes.String, tftypes.UnknownValue)
+	nullNumber := tftypes.NewValue(tftypes.Number, nil)
+
+	tests := []struct {
+		name      string
+		resource  resource.Resource
+		validator priorityIntervalValidator
+		priority  tftypes.Value
+		interval  tftypes.Value
+		wantError bool
+	}{
+		{name: "unset priority allows short interval", resource: NewHTTPCheckResource(), validator: priorityIntervalValidator{defaultInterval: 60}, priority: tftypes.NewValue(tftypes.String, nil), interval: tftypes.NewValue(tftypes.Number, 30)},
+		{name: "unknown priority is not checked", resource: NewHTTPCheckResource(), validator: priorityIntervalValidator{defaultInterval: 60}, priority: unknownString, interval: tftypes.NewValue(tftypes.Number, 5)},
+		{name: "normal below minimum", resource: NewHTTPCheckResource(), validator: priorityIntervalValidator{defaultInterval: 60}, priority: tftypes.NewValue(tftypes.String, "normal"), interval: tftypes.NewValue(tftypes.Number, 30), wantError: true},
+		{name: "normal at minimum", resource: NewHTTPCheckResource(), validator: priorityIntervalValidator{defaultInterval: 60}, priority: tftypes.NewValue(tftypes.String, "normal"), interval: tftypes.NewValue(tftypes.Number, 60)},
+		{name: "high below normal minimum", resource: NewHTTPCheckResource(), validator: priorityIntervalValidator{defaultInterval: 60}, priority: tftypes.NewValue(tftypes.String, "high"), interval: tftypes.NewValue(tftypes.Number, 10)},
+		{name: "high below minimum", resource: NewHTTPCheckResource(), validator: priorityIntervalValidator{defaultInterval: 60}, priority: tftypes.NewValue(tftypes.String, "high"), interval: tftypes.NewValue(tftypes.Number, 5), wantError: true},
+		{name: "low with default HTTP interval", resource: NewHTTPCheckResource(), validator: priorityIntervalValidator{defaultInterval: 60}, priority: tftypes.NewValue(tftypes.String, "low"), interval: nullNumber, wantError: true},
+		{name: "low with default API interval", resource: NewAPICheckResource(), validator: priorityIntervalValidator{defaultInterval: 300}, priority: tftypes.NewValue(tftypes.String, "low"), interval: nullNumber},
+		{name: "unknown interval is not checked", resource: NewHTTPCheckResource(), validator: priorityIntervalValidator{defaultInterval: 60}, priority: tftypes.NewValue(tftypes.String, "low"), interval: tftypes.NewValue(tftypes.Number, tftypes.UnknownValue)},
+		{name: "invalid tier left to attribute validator", resource: NewHTTPCheckResource(), validator: priorityIntervalValidator{defaultInterval: 60}, priority: tftypes.NewValue(tftypes.String, "urgent"), interval: tftypes.NewValue(tftypes.Number, 1)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := resourceConfig(t, tt.resource, map[string]tftypes.Value{
+				"priority": tt.priority,
+				"interval": tt.interval,
+			})
+
+			var resp resource.ValidateConfigResponse
+			tt.validator.ValidateResource(context.Background(), resource.ValidateConfigRequest{Config: config}, &resp)
+			if got := resp.Diagnostics.HasError(); got != tt.wantError {
+				t.Errorf("error = %t, want %t: %v", got, tt.wantError, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestBodySearchLimitBytesValidation(t *testing.T) {
+	ctx := context.Background()
+	var schemaResp resource.SchemaResponse
+	NewHTTPCheckResource().Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	attribute := schemaResp.Schema.Attributes["body_search_limit_bytes"].(schema.Int64Attribute)
+
+	tests := []struct {
+		value     types.Int64
+		wantError bool
+	}{
+		{value: types.Int64Null()},
+		{value: types.Int64Value(1)},
+		{value: types.Int64Value(4096)},
+		// A zero limit would search nothing, so expected_response could never match
+		{value: types.Int64Value(0), wantError: true},
+		{value: types.Int64Value(-1), wantError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value.String(), func(t *testing.T) {
+			req := validator.Int64Request{Path: path.Root("body_search_limit_bytes"), ConfigValue: tt.value}
+			var resp validator.Int64Response
+			for _, v := range attribute.Validators {
+				v.ValidateInt64(ctx, req, &resp)
+			}
+			if got := resp.Diagnostics.HasError(); got != tt.wantError {
+				t.Errorf("error = %t, want %t: %v", got, tt.wantError, resp.Diagnostics)
+			}
+		})
+	}
+}
@@ -0,0 +1,324 @@
+package cloudcanary
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// webSocketGUID is appended to the handshake key to derive Sec-WebSocket-Accept (RFC 6455 section 1.3)
+const webSocketGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+// maxWebSocketMessageBytes caps how much a WebSocket probe reads while waiting for the expected message
+const maxWebSocketMessageBytes = 64 << 10
+
+// WebSocket frame opcodes used by the probe
+const (
+	wsOpContinuation = 0x0
+	wsOpText         = 0x1
+	wsOpBinary       = 0x2
+	wsOpClose        = 0x8
+	wsOpPing         = 0x9
+	wsOpPong         = 0xA
+)
+
+// probeWebSocketCheck performs a WebSocket check's opening handshake, optionally
+// exchanges a message, and evaluates the reply. The handshake latency is
+// reported as the result's connect_time.
+func (c *cloudCanaryClient) probeWebSocketCheck(ctx context.Context, check *WebSocketCheck) CheckResult {
+	checkID := check.ID.ValueString()
+
+	timeout := 10 * time.Second
+	if !check.Timeout.IsNull() {
+		timeout = time.Duration(check.Timeout.ValueInt64()) * time.Second
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	start := time.Now()
+	conn, reader, err := dialWebSocket(ctx, check.URL.ValueString(), check.Subprotocol)
+	if err != nil {
+		return newProbeResult(checkID, nil, fmt.Sprintf("WebSocket handshake failed: %s", err))
+	}
+	defer conn.Close()
+	connectLatency := time.Since(start)
+
+	failureReason := ""
+	if !check.SendMessage.IsNull() {
+		if err := writeWebSocketFrame(conn, wsOpText, []byte(check.SendMessage.ValueString())); err != nil {
+			failureReason = fmt.Sprintf("error sending message: %s", err)
+		}
+	}
+	if failureReason == "" && !check.ExpectedMessage.IsNull() {
+		failureReason = readExpectedMessage(reader, conn, []byte(check.ExpectedMessage.ValueString()))
+	}
+
+	// Close politely with status 1000; the server's reply is not awaited
+	_ = writeWebSocketFrame(conn, wsOpClose, []byte{0x03, 0xe8})
+
+	result := newProbeResult(checkID, nil, failureReason)
+	result.ResponseTime = types.Int64Value(time.Since(start).Milliseconds())
+	result.ResponseCode = types.Int64Value(http.StatusSwitchingProtocols)
+	result.ConnectTime = types.Int64Value(connectLatency.Milliseconds())
+
+	tflog.Debug(ctx, "Probed WebSocket check", map[string]any{
+		"id":     checkID,
+		"status": result.Status.ValueString(),
+	})
+
+	return result
+}
+
+// dialWebSocket connects to a ws:// or wss:// URL and completes the opening
+// handshake. The returned reader must be used for reads, as it may already
+// hold frames sent straight after the handshake response.
+func dialWebSocket(ctx context.Context, rawURL string, subprotocol types.String) (net.Conn, *bufio.Reader, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var defaultPort string
+	switch u.Scheme {
+	case "ws":
+		defaultPort = "80"
+	case "wss":
+		defaultPort = "443"
+	default:
+		return nil, nil, fmt.Errorf("unsupported scheme %q", u.Scheme)
+	}
+	port := u.Port()
+	if port == "" {
+		port = defaultPort
+	}
+	address := net.JoinHostPort(u.Hostname(), port)
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not connect to %s: %w", address, err)
+	}
+
+	// Bound the handshake and message exchange by the probe deadline
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	if u.Scheme == "wss" {
+		tlsConn := tls.Client(conn, &tls.Config{ServerName: u.Hostname()})
+		if err := tlsConn.HandshakeContext(ctx); err != nil {
+			conn.Close()
+			return nil, nil, fmt.Errorf("TLS handshake failed: %w", err)
+		}
+		conn = tlsConn
+	}
+
+	nonce := make([]byte, 16)
+	if _, err := rand.Read(nonce); err != nil {
+		conn.Close()
+		return nil, nil, err
+	}
+	key := base64.StdEncoding.EncodeToString(nonce)
+
+	req := &http.Request{
+		Method:     http.MethodGet,
+		URL:        u,
+		Host:       u.Host,
+		Proto:      "HTTP/1.1",
+		ProtoMajor: 1,
+		ProtoMinor: 1,
+		Header: http.Header{
+			"Upgrade":               {"websocket"},
+			"Connection":            {"Upgrade"},
+			"Sec-WebSocket-Key":     {key},
+			"Sec-WebSocket-Version": {"13"},
+		},
+	}
+	if !subprotocol.IsNull() {
+		req.Header.Set("Sec-WebSocket-Protocol", subprotocol.ValueString())
+	}
+	if err := req.Write(conn); err != nil {
+		conn.Close()
+		return nil, nil, fmt.Errorf("error sending handshake: %w", err)
+	}
+
+	reader := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(reader, req)
+	if err != nil {
+		conn.Close()
+		return nil, nil, fmt.Errorf("error reading handshake response: %w", err)
+	}
+	resp.Body.Close()
+
+	if err := checkHandshakeResponse(resp, key, subprotocol); err != nil {
+		conn.Close()
+		return nil, nil, err
+	}
+
+	return conn, reader, nil
+}
+
+// checkHandshakeResponse verifies that the server accepted the upgrade for the given key and subprotocol
+func checkHandshakeResponse(resp *http.Response, key string, subprotocol types.String) error {
+	if resp.StatusCode != http.StatusSwitchingProtocols {
+		return fmt.Errorf("expected status 101, got %d", resp.StatusCode)
+	}
+	if !strings.EqualFold(resp.Header.Get("Upgrade"), "websocket") {
+		return fmt.Errorf("server did not upgrade to websocket (Upgrade: %q)", resp.Header.Get("Upgrade"))
+	}
+
+	digest := sha1.Sum([]byte(key + webSocketGUID))
+	if want := base64.StdEncoding.EncodeToString(digest[:]); resp.Header.Get("Sec-WebSocket-Accept") != want {
+		return fmt.Errorf("invalid Sec-WebSocket-Accept %q", resp.Header.Get("Sec-WebSocket-Accept"))
+	}
+
+	if !subprotocol.IsNull() {
+		if got := resp.Header.Get("Sec-WebSocket-Protocol"); got != subprotocol.ValueString() {
+			return fmt.Errorf("server did not agree to subprotocol %q (got %q)", subprotocol.ValueString(), got)
+		}
+	}
+
+	return nil
+}
+
+// writeWebSocketFrame writes a single unfragmented, masked frame as required of clients
+func writeWebSocketFrame(w io.Writer, opcode byte, payload []byte) error {
+	const maskBit = 0x80
+
+	frame := []byte{0x80 | opcode}
+	switch n := len(payload); {
+	case n < 126:
+		frame = append(frame, maskBit|byte(n))
+	case n <= 0xffff:
+		var ext [2]byte
+		binary.BigEndian.PutUint16(ext[:], uint16(n))
+		frame = append(append(frame, maskBit|126), ext[:]...)
+	default:
+		var ext [8]byte
+		binary.BigEndian.PutUint64(ext[:], uint64(n))
+		frame = append(append(frame, maskBit|127), ext[:]...)
+	}
+
+	var mask [4]byte
+	if _, err := rand.Read(mask[:]); err != nil {
+		return err
+	}
+	frame = append(frame, mask[:]...)
+	for i, b := range payload {
+		frame = append(frame, b^mask[i%4])
+	}
+
+	_, err := w.Write(frame)
+	return err
+}
+
+// readWebSocketFrame reads one frame, unmasking its payload if needed. Frames
+// with a payload larger than limit are rejected without being read.
+func readWebSocketFrame(r io.Reader, limit int) (opcode byte, fin bool, payload []byte, err error) {
+	var header [2]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return 0, false, nil, err
+	}
+	fin = header[0]&0x80 != 0
+	opcode = header[0] & 0x0f
+	masked := header[1]&0x80 != 0
+
+	length := uint64(header[1] & 0x7f)
+	switch length {
+	case 126:
+		var ext [2]byte
+		if _, err := io.ReadFull(r, ext[:]); err != nil {
+			return 0, false, nil, err
+		}
+		length = uint64(binary.BigEndian.Uint16(ext[:]))
+	case 127:
+		var ext [8]byte
+		if _, err := io.ReadFull(r, ext[:]); err != nil {
+			return 0, false, nil, err
+		}
+		length = binary.BigEndian.Uint64(ext[:])
+	}
+	if length > uint64(limit) {
+		return 0, false, nil, fmt.Errorf("frame of %d bytes exceeds the %d byte limit", length, limit)
+	}
+
+	var mask [4]byte
+	if masked {
+		if _, err := io.ReadFull(r, mask[:]); err != nil {
+			return 0, false, nil, err
+		}
+	}
+
+	payload = make([]byte, length)
+	if _, err := io.ReadFull(r, payload); err != nil {
+		return 0, false, nil, err
+	}
+	if masked {
+		for i := range payload {
+			payload[i] ^= mask[i%4]
+		}
+	}
+
+	return opcode, fin, payload, nil
+}
+
+// readExpectedMessage reads messages until one contains the expected bytes, the
+// read limit is reached, or the connection closes or times out. Pings are
+// answered along the way. It returns a failure reason, or an empty string if
+// the message was received.
+func readExpectedMessage(r io.Reader, w io.Writer, expected []byte) string {
+	var message []byte
+	received := 0
+	for received < maxWebSocketMessageBytes {
+		opcode, fin, payload, err := readWebSocketFrame(r, maxWebSocketMessageBytes-received)
+		if err != nil {
+			var netErr net.Error
+			switch {
+			case errors.As(err, &netErr) && netErr.Timeout():
+				return fmt.Sprintf("timed out waiting for expected message after receiving %d bytes", received)
+			case errors.Is(err, io.EOF), errors.Is(err, io.ErrUnexpectedEOF):
+				return fmt.Sprintf("connection closed before expected message was received (got %d bytes)", received)
+			default:
+				return fmt.Sprintf("error reading message: %s", err)
+			}
+		}
+		received += len(payload)
+
+		switch opcode {
+		case wsOpPing:
+			_ = writeWebSocketFrame(w, wsOpPong, payload)
+			continue
+		case wsOpPong:
+			continue
+		case wsOpClose:
+			return fmt.Sprintf("server closed the connection before expected message was received (got %d bytes)", received)
+		case wsOpText, wsOpBinary, wsOpContinuation:
+			message = append(message, payload...)
+		}
+
+		if fin {
+			if bytes.Contains(message, expected) {
+				return ""
+			}
+			message = message[:0]
+		}
+	}
+
+	return fmt.Sprintf("expected message not found in the first %d bytes", maxWebSocketMessageBytes)
+}
@@ -0,0 +1,196 @@
+package cloudcanary
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha1"
+	"encoding/base64"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// webSocketServer accepts a WebSocket upgrade, answering with accept as the
+// Sec-WebSocket-Accept value when set, and hands the connection to handle
+func webSocketServer(t *testing.T, accept func(key string) string, subprotocol string, handle func(conn net.Conn, r *bufio.Reader)) string {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get("Sec-WebSocket-Key")
+		if r.Header.Get("Upgrade") != "websocket" || r.Header.Get("Sec-WebSocket-Version") != "13" || key == "" {
+			http.Error(w, "not a websocket handshake", http.StatusBadRequest)
+			return
+		}
+
+		conn, rw, err := w.(http.Hijacker).Hijack()
+		if err != nil {
+			t.Errorf("hijack: %s", err)
+			return
+		}
+		defer conn.Close()
+
+		rw.WriteString("HTTP/1.1 101 Switching Protocols\r\nUpgrade: websocket\r\nConnection: Upgrade\r\n")
+		rw.WriteString("Sec-WebSocket-Accept: " + accept(key) + "\r\n")
+		if subprotocol != "" {
+			rw.WriteString("Sec-WebSocket-Protocol: " + subprotocol + "\r\n")
+		}
+		rw.WriteString("\r\n")
+		rw.Flush()
+
+		handle(conn, rw.Reader)
+	}))
+	t.Cleanup(server.Close)
+
+	return "ws" + strings.TrimPrefix(server.URL, "http")
+}
+
+// webSocketAccept derives the Sec-WebSocket-Accept value for key as RFC 6455 requires
+func webSocketAccept(key string) string {
+	digest := sha1.Sum([]byte(key + webSocketGUID))
+	return base64.StdEncoding.EncodeToString(digest[:])
+}
+
+// serverFrame encodes a short unmasked frame, as servers send them
+func serverFrame(opcode byte, fin bool, payload string) []byte {
+	first := opcode
+	if fin {
+		first |= 0x80
+	}
+	return append([]byte{first, byte(len(payload))}, payload...)
+}
+
+// serverMessage is a frame a test server sends, with "%s" in its payload
+// replaced by the message the client sent
+type serverMessage struct {
+	opcode  byte
+	fin     bool
+	payload string
+}
+
+// echoMessage reads one message from the client and answers with replies
+func echoMessage(replies ...serverMessage) func(net.Conn, *bufio.Reader) {
+	return func(conn net.Conn, r *bufio.Reader) {
+		opcode, _, payload, err := readWebSocketFrame(r, maxWebSocketMessageBytes)
+		if err != nil || opcode != wsOpText {
+			return
+		}
+		for _, reply := range replies {
+			conn.Write(serverFrame(reply.opcode, reply.fin, strings.ReplaceAll(reply.payload, "%s", string(payload))))
+		}
+		// Wait for the client's close frame
+		readWebSocketFrame(r, maxWebSocketMessageBytes)
+	}
+}
+
+// runWebSocketCheck creates a WebSocket check through the client and probes it from the test host
+func runWebSocketCheck(t *testing.T, c *cloudCanaryClient, check WebSocketCheck) CheckResult {
+	t.Helper()
+	ctx := context.Background()
+	check.Name = types.StringValue(t.Name())
+	if err := c.createWebSocketCheck(ctx, &check); err != nil {
+		t.Fatalf("createWebSocketCheck: %s", err)
+	}
+	return c.probeWebSocketCheck(ctx, &check)
+}
+
+func TestProbeWebSocket(t *testing.T) {
+	tests := []struct {
+		name              string
+		accept            func(string) string
+		serverSubprotocol string
+		subprotocol       types.String
+		handle            func(net.Conn, *bufio.Reader)
+		wantStatus        string
+		wantReason        string
+	}{
+		{
+			name:       "echo",
+			accept:     webSocketAccept,
+			handle:     echoMessage(serverMessage{wsOpText, true, "echo: %s"}),
+			wantStatus: "SUCCESS",
+		},
+		{
+			name:       "ping before reply",
+			accept:     webSocketAccept,
+			handle:     echoMessage(serverMessage{wsOpPing, true, "are you there"}, serverMessage{wsOpText, true, "echo: %s"}),
+			wantStatus: "SUCCESS",
+		},
+		{
+			name:       "fragmented reply",
+			accept:     webSocketAccept,
+			handle:     echoMessage(serverMessage{wsOpText, false, "echo: "}, serverMessage{wsOpContinuation, true, "%s"}),
+			wantStatus: "SUCCESS",
+		},
+		{
+			name:              "agreed subprotocol",
+			accept:            webSocketAccept,
+			serverSubprotocol: "graphql-ws",
+			subprotocol:       types.StringValue("graphql-ws"),
+			handle:            echoMessage(serverMessage{wsOpText, true, "echo: %s"}),
+			wantStatus:        "SUCCESS",
+		},
+		{
+			name:       "unexpected reply then close",
+			accept:     webSocketAccept,
+			handle:     echoMessage(serverMessage{wsOpText, true, "nope"}, serverMessage{wsOpClose, true, ""}),
+			wantStatus: "FAILURE",
+			wantReason: "server closed the connection before expected message was received",
+		},
+		{
+			name:       "invalid accept",
+			accept:     func(string) string { return "bm90IHRoZSBrZXk=" },
+			handle:     func(net.Conn, *bufio.Reader) {},
+			wantStatus: "FAILURE",
+			wantReason: "invalid Sec-WebSocket-Accept",
+		},
+		{
+			name:              "subprotocol refused",
+			accept:            webSocketAccept,
+			serverSubprotocol: "v1",
+			subprotocol:       types.StringValue("v2"),
+			handle:            func(net.Conn, *bufio.Reader) {},
+			wantStatus:        "FAILURE",
+			wantReason:        `did not agree to subprotocol "v2"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			url := webSocketServer(t, tt.accept, tt.serverSubprotocol, tt.handle)
+
+			result := runWebSocketCheck(t, newTestClient(), WebSocketCheck{
+				URL:             types.StringValue(url),
+				Subprotocol:     tt.subprotocol,
+				SendMessage:     types.StringValue("hello"),
+				ExpectedMessage: types.StringValue("echo: hello"),
+				Timeout:         types.Int64Value(2),
+			})
+			if got := result.Status.ValueString(); got != tt.wantStatus {
+				t.Fatalf("status = %s, want %s (failure reason %s)", got, tt.wantStatus, result.FailureReason)
+			}
+			if !strings.Contains(result.FailureReason.ValueString(), tt.wantReason) {
+				t.Errorf("failure reason = %q, want it to contain %q", result.FailureReason.ValueString(), tt.wantReason)
+			}
+			if tt.wantStatus == "SUCCESS" && result.ResponseCode.ValueInt64() != http.StatusSwitchingProtocols {
+				t.Errorf("response code = %d, want 101", result.ResponseCode.ValueInt64())
+			}
+		})
+	}
+}
+
+func TestProbeWebSocketNotUpgraded(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("plain HTTP"))
+	}))
+	defer server.Close()
+
+	result := runWebSocketCheck(t, newTestClient(), WebSocketCheck{
+		URL: types.StringValue("ws" + strings.TrimPrefix(server.URL, "http")),
+	})
+	if result.Status.ValueString() != "FAILURE" || !strings.Contains(result.FailureReason.ValueString(), "expected status 101, got 200") {
+		t.Errorf("status = %s with failure reason %s, want FAILURE for a 200", result.Status.ValueString(), result.FailureReason)
+	}
+}
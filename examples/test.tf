@@ -22,6 +22,7 @@ resource "cloudcanary_http_check" "httpbin_status" {
   timeout          = 5
   follow_redirects = true
   retries          = 1
+  regions          = ["us-east-1"]
   
   headers = {
     "User-Agent" = "CloudCanary/1.0"
@@ -37,6 +38,7 @@ resource "cloudcanary_http_check" "httpbin_delay" {
   interval         = 60
   timeout          = 10
   follow_redirects = true
+  regions          = ["us-east-1"]
   
   headers = {
     "User-Agent" = "CloudCanary/1.0"
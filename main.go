@@ -36,9 +36,9 @@ func main() {
 		Debug:   debug,
 	}
 
-	err := providerserver.Serve(context.Background(), cloudcanary.New, opts)
+	err := providerserver.Serve(context.Background(), cloudcanary.New(version), opts)
 
 	if err != nil {
 		log.Fatal(err.Error())
 	}
-}
\ No newline at end of file
+}